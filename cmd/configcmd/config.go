@@ -30,5 +30,6 @@ func NewCmd(injectedApp *application.Lux) *cobra.Command {
 	cmd.AddCommand(newMigrateCmd())
 	cmd.AddCommand(newSingleNodeCmd())
 	cmd.AddCommand(newAutorizeCloudAccessCmd())
+	cmd.AddCommand(newStateBackendCmd())
 	return cmd
 }
@@ -0,0 +1,79 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"fmt"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/state"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+const stateBackendNone = "none"
+
+var (
+	stateEndpoint string
+	stateRegion   string
+)
+
+// lux config stateBackend command
+func newStateBackendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stateBackend [url | none]",
+		Short: "share subnet and node state with a team",
+		Long: `Set the backend through which the subnet configurations, the node cluster
+configs, the ansible inventories and the node instance dirs are shared with a
+team, so that anyone can work on the same subnets and clusters.
+
+The url is either file:///path/to/dir, for a directory on a shared mount, or
+s3://bucket/prefix, for an S3 compatible bucket. For a server other than AWS
+S3, as MinIO, set it with --endpoint. The S3 credentials are taken from the AWS
+environment variables or shared config files. Objects are locked while being
+written, and a command fails instead of overwriting an object changed by
+someone else since it started.
+
+The state is pulled before each command and pushed after it, so the first
+command run after setting a backend uploads the local state to it. Use none
+to stop sharing the state.`,
+		RunE:         handleStateBackendSettings,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stateEndpoint, "endpoint", "", "S3 compatible endpoint, as http://localhost:9000 for MinIO")
+	cmd.Flags().StringVar(&stateRegion, "region", "", "S3 region (default us-east-1)")
+
+	return cmd
+}
+
+func handleStateBackendSettings(_ *cobra.Command, args []string) error {
+	if args[0] == stateBackendNone {
+		ux.Logger.PrintToUser("The subnet and node state is no longer shared")
+		return saveStateBackendPreferences("", "", "")
+	}
+	backend, err := state.New(state.Config{
+		URL:      args[0],
+		Endpoint: stateEndpoint,
+		Region:   stateRegion,
+	})
+	if err != nil {
+		return err
+	}
+	// check it can be accessed
+	if _, err := backend.List(constants.SubnetDir + "/"); err != nil {
+		return fmt.Errorf("failed to access state backend %s: %w", backend.URL(), err)
+	}
+	ux.Logger.PrintToUser("The subnet and node state is shared through %s", backend.URL())
+	return saveStateBackendPreferences(args[0], stateEndpoint, stateRegion)
+}
+
+func saveStateBackendPreferences(backendURL string, endpoint string, region string) error {
+	if err := app.Conf.SetConfigValue(constants.ConfigStateEndpointKey, endpoint); err != nil {
+		return err
+	}
+	if err := app.Conf.SetConfigValue(constants.ConfigStateRegionKey, region); err != nil {
+		return err
+	}
+	return app.Conf.SetConfigValue(constants.ConfigStateBackendKey, backendURL)
+}
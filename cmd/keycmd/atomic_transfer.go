@@ -0,0 +1,464 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/coreth/ethclient"
+	"github.com/luxdefi/coreth/plugin/evm"
+	"github.com/luxdefi/node/codec"
+	"github.com/luxdefi/node/ids"
+	luxdutils "github.com/luxdefi/node/utils"
+	luxdconstants "github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/keychain"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/avm"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/chain/x"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+	"github.com/luxdefi/node/wallet/subnet/primary/common"
+)
+
+const (
+	// time between checks for exported UTXOs on the receiver chain
+	exportedUTXOsPollInterval = 500 * time.Millisecond
+	// time for exported UTXOs to be available on the receiver chain
+	exportedUTXOsTimeout = time.Minute
+)
+
+var errNoImportableUTXOs = errors.New("no UTXOs available to import")
+
+// transfers between the C-Chain and the P or X chains, done with an export on
+// the sender chain and an import on the receiver chain
+var atomicTransferChains = map[string][]string{
+	pChain: {cChain},
+	xChain: {cChain},
+	cChain: {pChain, xChain},
+}
+
+func validateTransferChains(senderChain string, receiverChain string) error {
+	if senderChain == pChain && receiverChain == pChain {
+		return nil
+	}
+	receiverChains, ok := atomicTransferChains[senderChain]
+	if !ok {
+		return fmt.Errorf("invalid sender chain %s, expected P, X or C", senderChain)
+	}
+	for _, c := range receiverChains {
+		if c == receiverChain {
+			return nil
+		}
+	}
+	return fmt.Errorf("transfers from %s-Chain to %s-Chain are not supported", senderChain, receiverChain)
+}
+
+func isAtomicTransfer(senderChain string, receiverChain string) bool {
+	return senderChain != pChain || receiverChain != pChain
+}
+
+func getChainID(wallet primary.Wallet, chain string) ids.ID {
+	switch chain {
+	case xChain:
+		return wallet.X().BlockchainID()
+	case cChain:
+		return wallet.C().BlockchainID()
+	}
+	return luxdconstants.PlatformChainID
+}
+
+// returns the fee of importing the exported funds into [receiverChain]. On the
+// C-Chain it depends on [cChainBaseFee], elsewhere it is the fixed [txFee]
+func getImportFee(receiverChain string, txFee uint64, cChainBaseFee *big.Int) (uint64, error) {
+	if receiverChain == cChain {
+		return estimateCChainImportFee(cChainBaseFee)
+	}
+	return txFee, nil
+}
+
+// exports [amount] from the sender chain to the receiver chain, owned by [to].
+// The import fee of the receiver chain is added to the exported funds, so that
+// the receiver can pay it from them
+func sendAtomicTransfer(
+	network models.Network,
+	kc keychain.Keychain,
+	ethKeychain *secp256k1fx.Keychain,
+	senderChain string,
+	receiverChain string,
+	amount uint64,
+	to secp256k1fx.OutputOwners,
+) error {
+	wallet, err := primary.MakeWallet(
+		context.Background(),
+		&primary.WalletConfig{
+			URI:         network.Endpoint,
+			LUXKeychain: kc,
+			EthKeychain: ethKeychain,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	var cChainBaseFee *big.Int
+	if receiverChain == cChain {
+		cChainBaseFee, err = getCChainBaseFee(network)
+		if err != nil {
+			return fmt.Errorf("failed to get the C-Chain base fee: %w", err)
+		}
+	}
+	importFee, err := getImportFee(receiverChain, wallet.P().BaseTxFee(), cChainBaseFee)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Adding the %s-Chain import fee of %.9f LUX to the exported amount", receiverChain, float64(importFee)/float64(units.Lux))
+	destinationChainID := getChainID(wallet, receiverChain)
+	output := &lux.TransferableOutput{
+		Asset: lux.Asset{ID: wallet.P().LUXAssetID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount + importFee,
+			OutputOwners: to,
+		},
+	}
+	ux.Logger.PrintToUser("Issuing ExportTx %s -> %s", senderChain, receiverChain)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	txID, err := issueExportTx(wallet, senderChain, destinationChainID, output, common.WithContext(ctx))
+	if err != nil {
+		return wrapIssueError(ctx, err)
+	}
+	ux.Logger.PrintToUser("Tx ID: %s", txID)
+	return nil
+}
+
+func issueExportTx(
+	wallet primary.Wallet,
+	senderChain string,
+	destinationChainID ids.ID,
+	output *lux.TransferableOutput,
+	options ...common.Option,
+) (ids.ID, error) {
+	switch senderChain {
+	case xChain:
+		tx, err := wallet.X().IssueExportTx(destinationChainID, []*lux.TransferableOutput{output}, options...)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	case cChain:
+		tx, err := wallet.C().IssueExportTx(
+			destinationChainID,
+			[]*secp256k1fx.TransferOutput{output.Out.(*secp256k1fx.TransferOutput)},
+			options...,
+		)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	}
+	tx, err := wallet.P().IssueExportTx(destinationChainID, []*lux.TransferableOutput{output}, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return tx.ID(), nil
+}
+
+// imports into the receiver chain all the funds exported to [kc] from the
+// sender chain. C-Chain funds are imported at [cChainAddr]
+func receiveAtomicTransfer(
+	network models.Network,
+	kc keychain.Keychain,
+	senderChain string,
+	receiverChain string,
+	to secp256k1fx.OutputOwners,
+	cChainAddr ethcommon.Address,
+) error {
+	// a single import is needed, so there are no later steps to recover from
+	if receiveRecoveryStep != 0 {
+		return fmt.Errorf("--%s is only valid for P-Chain to P-Chain transfers", receiveRecoveryStepFlag)
+	}
+	wallet, err := primary.MakeWallet(
+		context.Background(),
+		&primary.WalletConfig{
+			URI:         network.Endpoint,
+			LUXKeychain: kc,
+			EthKeychain: secp256k1fx.NewKeychain(),
+		},
+	)
+	if err != nil {
+		ux.Logger.PrintToUser(logging.LightRed.Wrap("ERROR: restart from this step by using the same command"))
+		return err
+	}
+	sourceChainID := getChainID(wallet, senderChain)
+	ux.Logger.PrintToUser("Issuing ImportTx %s -> %s", senderChain, receiverChain)
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	txID, err := issueImportTx(wallet, receiverChain, sourceChainID, &to, cChainAddr, common.WithContext(ctx))
+	if err != nil {
+		ux.Logger.PrintToUser(logging.LightRed.Wrap("ERROR: restart from this step by using the same command"))
+		return wrapIssueError(ctx, err)
+	}
+	ux.Logger.PrintToUser("Tx ID: %s", txID)
+	return nil
+}
+
+func issueImportTx(
+	wallet primary.Wallet,
+	receiverChain string,
+	sourceChainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	cChainAddr ethcommon.Address,
+	options ...common.Option,
+) (ids.ID, error) {
+	switch receiverChain {
+	case xChain:
+		tx, err := wallet.X().IssueImportTx(sourceChainID, to, options...)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	case cChain:
+		tx, err := wallet.C().IssueImportTx(sourceChainID, cChainAddr, options...)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	}
+	tx, err := wallet.P().IssueImportTx(sourceChainID, to, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return tx.ID(), nil
+}
+
+func wrapIssueError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("timeout issuing/verifying tx: %w", err)
+	}
+	return fmt.Errorf("error issuing tx: %w", err)
+}
+
+// waitForExportedUTXOs polls the receiver chain until the UTXOs exported to
+// [addrs] by tx [exportTxID] of the sender chain can be imported, and returns them
+func waitForExportedUTXOs(
+	network models.Network,
+	sourceChainID ids.ID,
+	receiverChain string,
+	receiverChainID ids.ID,
+	exportTxID ids.ID,
+	addrs []ids.ShortID,
+) ([]*lux.UTXO, error) {
+	var (
+		client primary.UTXOClient
+		cdc    codec.Manager
+	)
+	switch receiverChain {
+	case xChain:
+		client, cdc = avm.NewClient(network.Endpoint, xChain), x.Parser.Codec()
+	case cChain:
+		client, cdc = evm.NewCChainClient(network.Endpoint), evm.Codec
+	default:
+		client, cdc = platformvm.NewClient(network.Endpoint), txs.Codec
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportedUTXOsTimeout)
+	defer cancel()
+	ticker := time.NewTicker(exportedUTXOsPollInterval)
+	defer ticker.Stop()
+	for {
+		utxos := primary.NewUTXOs()
+		if err := primary.AddAllUTXOs(ctx, utxos, client, cdc, sourceChainID, receiverChainID, addrs); err != nil {
+			return nil, fmt.Errorf("failed to get the UTXOs exported by %s: %w", exportTxID, err)
+		}
+		allUTXOs, err := utxos.UTXOs(ctx, sourceChainID, receiverChainID)
+		if err != nil {
+			return nil, err
+		}
+		if txUTXOs := getTxUTXOs(allUTXOs, exportTxID); len(txUTXOs) > 0 {
+			return txUTXOs, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout waiting for the UTXOs exported by %s to be available on the %s-Chain", exportTxID, receiverChain)
+		case <-ticker.C:
+		}
+	}
+}
+
+// returns the UTXOs created by tx [txID]
+func getTxUTXOs(utxos []*lux.UTXO, txID ids.ID) []*lux.UTXO {
+	txUTXOs := []*lux.UTXO{}
+	for _, utxo := range utxos {
+		if utxo.TxID == txID {
+			txUTXOs = append(txUTXOs, utxo)
+		}
+	}
+	return txUTXOs
+}
+
+// getImportInputs returns the inputs spending the LUX [utxos] that [addrs]
+// can sign for, sorted as required by import txs, and the imported amount
+func getImportInputs(
+	utxos []*lux.UTXO,
+	luxAssetID ids.ID,
+	addrs set.Set[ids.ShortID],
+) ([]*lux.TransferableInput, uint64, error) {
+	minIssuanceTime := uint64(time.Now().Unix())
+	inputs := []*lux.TransferableInput{}
+	importedAmount := uint64(0)
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || utxo.AssetID() != luxAssetID {
+			continue
+		}
+		sigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		if !ok {
+			continue
+		}
+		inputs = append(inputs, &lux.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &secp256k1fx.TransferInput{
+				Amt:   out.Amt,
+				Input: secp256k1fx.Input{SigIndices: sigIndices},
+			},
+		})
+		var err error
+		importedAmount, err = math.Add64(importedAmount, out.Amt)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if len(inputs) == 0 {
+		return nil, 0, errNoImportableUTXOs
+	}
+	luxdutils.Sort(inputs)
+	return inputs, importedAmount, nil
+}
+
+// newPChainImportTx builds a P-Chain import of [utxos] only, owned by [to],
+// that pays [fee] out of the imported funds
+func newPChainImportTx(
+	networkID uint32,
+	luxAssetID ids.ID,
+	sourceChainID ids.ID,
+	utxos []*lux.UTXO,
+	addrs set.Set[ids.ShortID],
+	to *secp256k1fx.OutputOwners,
+	fee uint64,
+) (*txs.ImportTx, error) {
+	inputs, importedAmount, err := getImportInputs(utxos, luxAssetID, addrs)
+	if err != nil {
+		return nil, err
+	}
+	if importedAmount <= fee {
+		return nil, fmt.Errorf("imported amount %d does not cover the P-Chain import fee %d", importedAmount, fee)
+	}
+	return &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: luxdconstants.PlatformChainID,
+			Outs: []*lux.TransferableOutput{{
+				Asset: lux.Asset{ID: luxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          importedAmount - fee,
+					OutputOwners: *to,
+				},
+			}},
+		}},
+		SourceChain:    sourceChainID,
+		ImportedInputs: inputs,
+	}, nil
+}
+
+// newCChainImportTx builds a C-Chain import of [utxos] only, credited to [to],
+// that pays the gas based import fee at [baseFee] out of the imported funds
+func newCChainImportTx(
+	networkID uint32,
+	cChainID ids.ID,
+	luxAssetID ids.ID,
+	sourceChainID ids.ID,
+	utxos []*lux.UTXO,
+	addrs set.Set[ids.ShortID],
+	to ethcommon.Address,
+	baseFee *big.Int,
+) (*evm.UnsignedImportTx, error) {
+	inputs, importedAmount, err := getImportInputs(utxos, luxAssetID, addrs)
+	if err != nil {
+		return nil, err
+	}
+	utx := &evm.UnsignedImportTx{
+		NetworkID:      networkID,
+		BlockchainID:   cChainID,
+		SourceChain:    sourceChainID,
+		ImportedInputs: inputs,
+	}
+	fee, err := getCChainImportFee(utx, baseFee)
+	if err != nil {
+		return nil, err
+	}
+	if importedAmount <= fee {
+		return nil, fmt.Errorf("imported amount %d does not cover the C-Chain import fee %d", importedAmount, fee)
+	}
+	utx.Outs = []evm.EVMOutput{{
+		Address: to,
+		Amount:  importedAmount - fee,
+		AssetID: luxAssetID,
+	}}
+	return utx, nil
+}
+
+// getCChainImportFee returns the fee, in nLUX, of the C-Chain import [utx]
+// with a single output added. Unlike on the P and X chains, it is not fixed,
+// but given by the gas used by the tx at the current [baseFee]
+func getCChainImportFee(utx *evm.UnsignedImportTx, baseFee *big.Int) (uint64, error) {
+	// the gas used depends on the tx bytes
+	if err := (&evm.Tx{UnsignedAtomicTx: utx}).Sign(evm.Codec, nil); err != nil {
+		return 0, err
+	}
+	gasUsed, err := utx.GasUsed(true)
+	if err != nil {
+		return 0, err
+	}
+	gasUsed, err = math.Add64(gasUsed, evm.EVMOutputGas)
+	if err != nil {
+		return 0, err
+	}
+	return evm.CalculateDynamicFee(gasUsed, baseFee)
+}
+
+// estimateCChainImportFee returns the fee of importing into the C-Chain a
+// single UTXO owned by one address, at [baseFee]
+func estimateCChainImportFee(baseFee *big.Int) (uint64, error) {
+	return getCChainImportFee(&evm.UnsignedImportTx{
+		ImportedInputs: []*lux.TransferableInput{{
+			In: &secp256k1fx.TransferInput{
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+	}, baseFee)
+}
+
+// returns the current base fee of the C-Chain
+func getCChainBaseFee(network models.Network) (*big.Int, error) {
+	client, err := ethclient.Dial(network.CChainEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	return client.EstimateBaseFee(ctx)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransferChains(t *testing.T) {
+	tests := []struct {
+		senderChain   string
+		receiverChain string
+		expectErr     bool
+		atomic        bool
+	}{
+		{senderChain: pChain, receiverChain: pChain},
+		{senderChain: pChain, receiverChain: cChain, atomic: true},
+		{senderChain: xChain, receiverChain: cChain, atomic: true},
+		{senderChain: cChain, receiverChain: pChain, atomic: true},
+		{senderChain: cChain, receiverChain: xChain, atomic: true},
+		{senderChain: pChain, receiverChain: xChain, expectErr: true},
+		{senderChain: xChain, receiverChain: pChain, expectErr: true},
+		{senderChain: xChain, receiverChain: xChain, expectErr: true},
+		{senderChain: cChain, receiverChain: cChain, expectErr: true},
+		{senderChain: "D", receiverChain: cChain, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.senderChain+"->"+tt.receiverChain, func(t *testing.T) {
+			require := require.New(t)
+			err := validateTransferChains(tt.senderChain, tt.receiverChain)
+			if tt.expectErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.atomic, isAtomicTransfer(tt.senderChain, tt.receiverChain))
+		})
+	}
+}
+
+func TestGetImportFee(t *testing.T) {
+	require := require.New(t)
+
+	const txFee = 1_000_000
+	baseFee := big.NewInt(25_000_000_000)
+	for _, receiverChain := range []string{pChain, xChain} {
+		fee, err := getImportFee(receiverChain, txFee, nil)
+		require.NoError(err)
+		require.Equal(uint64(txFee), fee)
+	}
+	// the C-Chain fee is given by the gas used at the base fee
+	fee, err := getImportFee(cChain, txFee, baseFee)
+	require.NoError(err)
+	expectedFee, err := estimateCChainImportFee(baseFee)
+	require.NoError(err)
+	require.Equal(expectedFee, fee)
+	require.NotEqual(uint64(txFee), fee)
+}
+
+func TestReceiveAtomicTransferRecoveryStep(t *testing.T) {
+	require := require.New(t)
+
+	receiveRecoveryStep = 1
+	defer func() {
+		receiveRecoveryStep = 0
+	}()
+	// fails before reaching the network
+	err := receiveAtomicTransfer(
+		models.LocalNetwork,
+		secp256k1fx.NewKeychain(),
+		cChain,
+		pChain,
+		secp256k1fx.OutputOwners{},
+		ethcommon.Address{},
+	)
+	require.ErrorContains(err, receiveRecoveryStepFlag)
+}
@@ -4,6 +4,7 @@ package keycmd
 
 import (
 	"errors"
+	"os"
 	"regexp"
 
 	"github.com/luxdefi/cli/pkg/key"
@@ -17,8 +18,12 @@ const (
 )
 
 var (
-	forceCreate bool
-	filename    string
+	forceCreate  bool
+	encryptKey   bool
+	filename     string
+	useMnemonic  bool
+	mnemonicFile string
+	numAccounts  uint32
 )
 
 func createKey(_ *cobra.Command, args []string) error {
@@ -32,6 +37,20 @@ func createKey(_ *cobra.Command, args []string) error {
 		return errors.New("key already exists. Use --" + forceFlag + " parameter to overwrite")
 	}
 
+	if mnemonicFile != "" {
+		useMnemonic = true
+	}
+	if useMnemonic && filename != "" {
+		return errors.New("--mnemonic and --file are mutually exclusive")
+	}
+	if !useMnemonic && numAccounts != 1 {
+		return errors.New("--accounts can only be used with --mnemonic or --mnemonic-file")
+	}
+
+	if useMnemonic {
+		return createMnemonicKey(keyName)
+	}
+
 	if filename == "" {
 		// Create key from scratch
 		ux.Logger.PrintToUser("Generating new key...")
@@ -39,16 +58,14 @@ func createKey(_ *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		keyPath := app.GetKeyPath(keyName)
-		if err := k.Save(keyPath); err != nil {
+		if err := saveKey(k, keyName, encryptKey); err != nil {
 			return err
 		}
 		ux.Logger.PrintToUser("Key created")
 	} else {
 		// Load key from file
-		// TODO add validation that key is legal
 		ux.Logger.PrintToUser("Loading user key...")
-		if err := app.CopyKeyFile(filename, keyName); err != nil {
+		if err := importKeyFile(keyName); err != nil {
 			return err
 		}
 		keyPath := app.GetKeyPath(keyName)
@@ -69,6 +86,92 @@ func createKey(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// importKeyFile stores the key file given with --file under [keyName]. Ethereum keystores
+// are converted, other formats are stored as they are unless they have to be encrypted.
+func importKeyFile(keyName string) error {
+	kb, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	switch {
+	case key.IsEthKeystore(kb):
+		passphrase, err := app.GetKeyPassphrase("Passphrase for the keystore file")
+		if err != nil {
+			return err
+		}
+		k, err := key.LoadSoftFromEthKeystore(0, kb, passphrase)
+		if err != nil {
+			return err
+		}
+		return saveKey(k, keyName, encryptKey)
+	case key.IsEncrypted(kb):
+		return app.CopyKeyFile(filename, keyName)
+	default:
+		k, err := parsePlainKey(kb)
+		if err != nil {
+			return err
+		}
+		if encryptKey {
+			return saveKey(k, keyName, encryptKey)
+		}
+		return app.CopyKeyFile(filename, keyName)
+	}
+}
+
+func createMnemonicKey(keyName string) error {
+	var (
+		mnemonic string
+		err      error
+	)
+	generated := mnemonicFile == ""
+	if generated {
+		ux.Logger.PrintToUser("Generating new mnemonic...")
+		mnemonic, err = key.NewMnemonic()
+		if err != nil {
+			return err
+		}
+	} else {
+		ux.Logger.PrintToUser("Loading user mnemonic...")
+		mnemonicBytes, err := os.ReadFile(mnemonicFile)
+		if err != nil {
+			return err
+		}
+		mnemonic = string(mnemonicBytes)
+	}
+	h, err := key.NewHD(mnemonic, numAccounts)
+	if err != nil {
+		return err
+	}
+	if err := saveKey(h, keyName, encryptKey); err != nil {
+		return err
+	}
+	if generated {
+		ux.Logger.PrintToUser("Write down the following mnemonic and keep it in a safe place.")
+		ux.Logger.PrintToUser("It is the only way to recover the key:")
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("  %s", h.Mnemonic())
+		ux.Logger.PrintToUser("")
+	}
+	ux.Logger.PrintToUser("Key created with %d accounts", h.Accounts())
+	if h.Accounts() > 1 {
+		ux.Logger.PrintToUser("Select an account in other commands with --key %s/<index>", keyName)
+	}
+	return nil
+}
+
+// saveKey stores [k] under [keyName], encrypting it with a passphrase if [encrypt] is set
+func saveKey(k encryptableKey, keyName string, encrypt bool) error {
+	keyPath := app.GetKeyPath(keyName)
+	if !encrypt {
+		return k.Save(keyPath)
+	}
+	passphrase, err := app.GetNewKeyPassphrase()
+	if err != nil {
+		return err
+	}
+	return k.SaveEncrypted(keyPath, passphrase)
+}
+
 func newCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create [keyName]",
@@ -81,7 +184,18 @@ The command works by generating a secp256 key and storing it with the provided k
 can use this key in other commands by providing this keyName.
 
 If you'd like to import an existing key instead of generating one from scratch, provide the
---file flag.`,
+--file flag. The file can hold a "PrivateKey-" prefixed CB58 key, a hex key (0x prefixed or not),
+an encrypted Ethereum JSON keystore, or any key exported by the key export command.
+
+By default the key is stored in plain text. Provide the --encrypt flag to store it encrypted
+with a passphrase. The passphrase is asked for, or taken from the LUX_KEY_PASSPHRASE env var,
+or from the file pointed to by the LUX_KEY_PASSPHRASE_FILE env var.
+
+Provide the --mnemonic flag to generate a BIP-39 mnemonic instead of a single key, or the
+--mnemonic-file flag to import an existing one. P-Chain and X-Chain keys are derived on the
+Lux path m/44'/9000'/0'/0/<index>, and C-Chain keys on the Ethereum path m/44'/60'/0'/0/<index>.
+Use --accounts to store several derived accounts under the same keyName, and select them in
+other commands with --key keyName/<index>. keyName alone selects account 0.`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         createKey,
 		SilenceUsage: true,
@@ -93,6 +207,30 @@ If you'd like to import an existing key instead of generating one from scratch,
 		"",
 		"import the key from an existing key file",
 	)
+	cmd.Flags().BoolVar(
+		&useMnemonic,
+		"mnemonic",
+		false,
+		"generate a BIP-39 mnemonic and derive the key accounts from it",
+	)
+	cmd.Flags().StringVar(
+		&mnemonicFile,
+		"mnemonic-file",
+		"",
+		"import the BIP-39 mnemonic contained in the given file",
+	)
+	cmd.Flags().Uint32Var(
+		&numAccounts,
+		"accounts",
+		1,
+		"number of accounts to derive from the mnemonic",
+	)
+	cmd.Flags().BoolVar(
+		&encryptKey,
+		"encrypt",
+		false,
+		"encrypt the key with a passphrase",
+	)
 	cmd.Flags().BoolVarP(
 		&forceCreate,
 		forceFlag,
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
@@ -49,7 +50,7 @@ func deleteKey(_ *cobra.Command, args []string) error {
 		confStr := "Are you sure you want to delete " + keyName + "?"
 		conf, err := app.Prompt.CaptureNoYes(confStr)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+forceFlag)
 		}
 
 		if !conf {
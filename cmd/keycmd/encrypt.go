@@ -0,0 +1,80 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// lux key encrypt
+func newEncryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt [keyName]",
+		Short: "Encrypt a stored signing key with a passphrase",
+		Long: `The key encrypt command migrates a key stored in plain text to the encrypted
+key format. The key keeps its name and addresses, so it can be used as before.
+
+The passphrase is asked for, or taken from the LUX_KEY_PASSPHRASE env var,
+or from the file pointed to by the LUX_KEY_PASSPHRASE_FILE env var.`,
+		RunE:         encryptStoredKey,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func encryptStoredKey(_ *cobra.Command, args []string) error {
+	keyName := args[0]
+	if !app.KeyExists(keyName) {
+		return fmt.Errorf("key %s does not exist", keyName)
+	}
+	keyPath := app.GetKeyPath(keyName)
+	encrypted, err := key.IsEncryptedFile(keyPath)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return errors.New("key is already encrypted")
+	}
+	k, err := loadPlainKey(keyPath)
+	if err != nil {
+		return err
+	}
+	passphrase, err := app.GetNewKeyPassphrase()
+	if err != nil {
+		return err
+	}
+	if err := k.SaveEncrypted(keyPath, passphrase); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Key %s encrypted", keyName)
+	return nil
+}
+
+// encryptableKey is either a private key or a mnemonic
+type encryptableKey interface {
+	Save(p string) error
+	SaveEncrypted(p string, passphrase []byte) error
+}
+
+// loadPlainKey loads the plain text key file at [keyPath], keeping mnemonics as such
+func loadPlainKey(keyPath string) (encryptableKey, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlainKey(kb)
+}
+
+func parsePlainKey(kb []byte) (encryptableKey, error) {
+	if key.IsHD(kb) {
+		return key.LoadHDFromBytes(kb)
+	}
+	return key.LoadSoftFromBytes(0, kb)
+}
@@ -3,14 +3,19 @@
 package keycmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/key"
+	"golang.org/x/exp/slices"
 
 	"github.com/spf13/cobra"
 )
 
+var exportFormat string
+
 func newExportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export [keyName]",
@@ -18,8 +23,18 @@ func newExportCmd() *cobra.Command {
 		Long: `The key export command exports a created signing key. You can use an exported key in other
 applications or import it into another instance of Lux-CLI.
 
-By default, the tool writes the hex encoded key to stdout. If you provide the --output
-flag, the command writes the key to a file of your choosing.`,
+By default, the tool writes the key as it is stored to stdout. If you provide the --output-file
+flag, the command writes the key to a file of your choosing.
+
+Provide the --format flag to convert the key to one of the following formats:
+  cb58:     "PrivateKey-" prefixed CB58, as used by the Lux wallet
+  hex:      0x prefixed hex, as used by MetaMask, Foundry and Hardhat configs. It holds
+            the C-Chain key, which for a mnemonic account differs from its P/X-Chain key
+  keystore: encrypted Ethereum JSON keystore, as used by MetaMask and Foundry. The
+            keystore holds the C-Chain key, and is encrypted with a passphrase that is
+            asked for, or taken from the LUX_KEY_PASSPHRASE or LUX_KEY_PASSPHRASE_FILE env vars
+
+To export an account of a mnemonic key, provide keyName/<index> together with --format.`,
 		Args:         cobra.ExactArgs(1),
 		RunE:         exportKey,
 		SilenceUsage: true,
@@ -27,22 +42,45 @@ flag, the command writes the key to a file of your choosing.`,
 
 	cmd.Flags().StringVarP(
 		&filename,
-		"output",
+		"output-file",
 		"o",
 		"",
 		"write the key to the provided file path",
 	)
+	cmd.Flags().StringVar(
+		&exportFormat,
+		"format",
+		"",
+		fmt.Sprintf("convert the key to the given format %v", key.ExportFormats),
+	)
 
 	return cmd
 }
 
 func exportKey(_ *cobra.Command, args []string) error {
-	keyName := args[0]
+	keyRef := args[0]
 
-	keyPath := app.GetKeyPath(keyName)
-	keyBytes, err := os.ReadFile(keyPath)
-	if err != nil {
-		return err
+	var (
+		keyBytes []byte
+		err      error
+	)
+	if exportFormat == "" {
+		keyName, index, err := key.ParseKeyRef(keyRef)
+		if err != nil {
+			return err
+		}
+		if index != 0 {
+			return errors.New("--format is required to export an account of a mnemonic key")
+		}
+		keyBytes, err = os.ReadFile(app.GetKeyPath(keyName))
+		if err != nil {
+			return err
+		}
+	} else {
+		keyBytes, err = convertKey(keyRef, exportFormat)
+		if err != nil {
+			return err
+		}
 	}
 
 	if filename == "" {
@@ -50,5 +88,29 @@ func exportKey(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return os.WriteFile(filename, keyBytes, constants.WriteReadReadPerms)
+	// the file holds a private key, unless it is exported encrypted
+	if err := os.WriteFile(filename, keyBytes, constants.WriteReadUserOnlyPerms); err != nil {
+		return err
+	}
+	// the permissions of an existing file are not changed by os.WriteFile
+	return os.Chmod(filename, constants.WriteReadUserOnlyPerms)
+}
+
+func convertKey(keyRef string, format string) ([]byte, error) {
+	if !slices.Contains(key.ExportFormats, format) {
+		return nil, fmt.Errorf("unsupported key format %q, expected one of %v", format, key.ExportFormats)
+	}
+	// the network only affects the addresses, not the private key
+	sk, err := app.LoadKey(0, keyRef)
+	if err != nil {
+		return nil, err
+	}
+	var passphrase []byte
+	if format == key.EthKeystoreFormat {
+		passphrase, err = app.GetNewKeyPassphrase()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sk.Export(format, passphrase)
 }
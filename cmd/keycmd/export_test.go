@@ -0,0 +1,45 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/config"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// exported private keys are only readable by their owner
+func TestExportKeyPerms(t *testing.T) {
+	require := require.New(t)
+
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewNonInteractivePrompter(), application.NewDownloader())
+	defer func() {
+		app = nil
+		exportFormat = ""
+		filename = ""
+	}()
+	sk, err := key.NewSoft(0)
+	require.NoError(err)
+	require.NoError(os.MkdirAll(filepath.Dir(app.GetKeyPath("test")), constants.DefaultPerms755))
+	require.NoError(sk.Save(app.GetKeyPath("test")))
+
+	for _, format := range []string{"", key.CB58Format, key.HexFormat} {
+		exportFormat = format
+		filename = filepath.Join(t.TempDir(), "exported")
+		// also when overwriting a file readable by others
+		require.NoError(os.WriteFile(filename, nil, constants.WriteReadReadPerms))
+		require.NoError(exportKey(nil, []string{"test"}))
+		info, err := os.Stat(filename)
+		require.NoError(err)
+		require.Equal(os.FileMode(constants.WriteReadUserOnlyPerms), info.Mode().Perm(), format)
+	}
+}
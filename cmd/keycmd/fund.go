@@ -0,0 +1,364 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/luxdefi/cli/pkg/evm"
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/node/api/info"
+	"github.com/luxdefi/node/ids"
+	luxdconstants "github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/formatting/address"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+	"github.com/luxdefi/node/wallet/subnet/primary/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	pChain = "P"
+	xChain = "X"
+	cChain = "C"
+	// time to fund an address on a subnet chain
+	fundEVMTimeout = time.Minute
+)
+
+var (
+	fundDevnet   bool
+	fundEndpoint string
+	fundChain    string
+)
+
+// lux key fund
+func newFundCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fund [address]",
+		Short: "Fund an address on a local or devnet chain from the ewoq key",
+		Long: `The key fund command sends funds of the prefunded ewoq key to an address on the
+P-Chain, X-Chain, C-Chain or the chain of a deployed Subnet.
+
+X-Chain addresses are funded with a transfer. P-Chain and C-Chain addresses are funded by
+exporting the funds from the X-Chain and importing them into the destination chain. Subnet
+addresses are funded with a transfer on the Subnet RPC, so the Subnet genesis must fund the
+ewoq address.
+
+P-Chain and X-Chain addresses are given in bech32 format, and C-Chain and Subnet addresses
+in hex format.
+
+The command is only available on the local network and on devnets, as the ewoq key has no
+funds on Fuji or Mainnet.`,
+		RunE:         fundF,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVarP(&local, localFlag, "l", false, "fund an address on the local network (default)")
+	cmd.Flags().BoolVar(&fundDevnet, "devnet", false, "fund an address on a devnet")
+	cmd.Flags().StringVar(&fundEndpoint, "endpoint", "", "use the given endpoint for network operations")
+	cmd.Flags().StringVarP(&fundChain, "chain", "c", "", "chain to fund the address on: P, X, C or a subnet name")
+	cmd.Flags().Float64VarP(&amountFlt, amountFlag, "o", 0, "amount to fund (LUX units)")
+	return cmd
+}
+
+func fundF(_ *cobra.Command, args []string) error {
+	if local && fundDevnet {
+		return errors.New("only one of --local, --devnet flags should be selected")
+	}
+	network := models.LocalNetwork
+	if fundDevnet {
+		network = models.DevnetNetwork
+	}
+	if fundEndpoint != "" {
+		network.Endpoint = fundEndpoint
+	}
+	if fundChain == "" {
+		return errors.New("--chain must be given")
+	}
+	if amountFlt <= 0 {
+		return fmt.Errorf("--%s must be greater than zero", amountFlag)
+	}
+	if err := checkFundableNetwork(network); err != nil {
+		return err
+	}
+
+	addr := args[0]
+	switch fundChain {
+	case pChain, xChain:
+		to, err := address.ParseToID(addr)
+		if err != nil {
+			return fmt.Errorf("invalid %s-Chain address %s: %w", fundChain, addr, err)
+		}
+		amount := uint64(amountFlt * float64(units.Lux))
+		if fundChain == pChain {
+			err = fundPChain(network, to, amount)
+		} else {
+			err = fundXChain(network, to, amount)
+		}
+		if err != nil {
+			return err
+		}
+	case cChain:
+		if !ethcommon.IsHexAddress(addr) {
+			return fmt.Errorf("invalid C-Chain address %s", addr)
+		}
+		amount := uint64(amountFlt * float64(units.Lux))
+		if err := fundCChain(network, ethcommon.HexToAddress(addr), amount); err != nil {
+			return err
+		}
+	default:
+		if !ethcommon.IsHexAddress(addr) {
+			return fmt.Errorf("invalid address %s for subnet %s", addr, fundChain)
+		}
+		if err := fundSubnetChain(network, fundChain, ethcommon.HexToAddress(addr)); err != nil {
+			return err
+		}
+	}
+	ux.Logger.PrintToUser("Funded %s with %.9f LUX on %s", addr, amountFlt, fundChain)
+	return nil
+}
+
+// the ewoq key only holds funds on local networks and devnets, so an endpoint
+// of a public network is refused even if it was given for a devnet
+func checkFundableNetwork(network models.Network) error {
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	networkID, err := info.NewClient(network.Endpoint).GetNetworkID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the network ID of %s: %w", network.Endpoint, err)
+	}
+	if kind := models.NetworkFromNetworkID(networkID).Kind; kind == models.Fuji || kind == models.Mainnet {
+		return fmt.Errorf("key fund is not available on %s, only on the local network and devnets", kind)
+	}
+	return nil
+}
+
+func getEwoqWallet(network models.Network) (primary.Wallet, *secp256k1fx.Keychain, error) {
+	ewoq, err := key.LoadEwoq(network.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	kc := ewoq.KeyChain()
+	wallet, err := primary.MakeWallet(
+		context.Background(),
+		&primary.WalletConfig{
+			URI:         network.Endpoint,
+			LUXKeychain: kc,
+			EthKeychain: kc,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wallet, kc, nil
+}
+
+func ewoqOwner(kc *secp256k1fx.Keychain) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     kc.Addresses().List(),
+	}
+}
+
+func fundXChain(network models.Network, to ids.ShortID, amount uint64) error {
+	wallet, _, err := getEwoqWallet(network)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Issuing BaseTx on X")
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	tx, err := wallet.X().IssueBaseTx(
+		[]*lux.TransferableOutput{
+			{
+				Asset: lux.Asset{ID: wallet.X().LUXAssetID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			},
+		},
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return wrapIssueError(ctx, err)
+	}
+	ux.Logger.PrintToUser("Tx ID: %s", tx.ID())
+	return nil
+}
+
+// exports the funds from X to the ewoq key, and imports them into P owned by [to]
+func fundPChain(network models.Network, to ids.ShortID, amount uint64) error {
+	wallet, kc, err := getEwoqWallet(network)
+	if err != nil {
+		return err
+	}
+	fee := wallet.P().BaseTxFee()
+	ux.Logger.PrintToUser("Issuing ExportTx X -> P")
+	exportTxID, err := subnet.IssueXToPExportTx(
+		wallet,
+		false,
+		true,
+		wallet.P().LUXAssetID(),
+		amount+fee,
+		ewoqOwner(kc),
+	)
+	if err != nil {
+		return err
+	}
+	xChainID := wallet.X().BlockchainID()
+	utxos, err := waitForExportedUTXOs(network, xChainID, pChain, luxdconstants.PlatformChainID, exportTxID, kc.Addresses().List())
+	if err != nil {
+		return err
+	}
+	// the wallet state is fetched again for the exported UTXOs to be signed
+	wallet, _, err = getEwoqWallet(network)
+	if err != nil {
+		return err
+	}
+	// only the exported UTXOs are imported, not other funds of the ewoq key
+	utx, err := newPChainImportTx(
+		wallet.P().NetworkID(),
+		wallet.P().LUXAssetID(),
+		xChainID,
+		utxos,
+		kc.Addresses(),
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{to},
+		},
+		fee,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Issuing ImportTx X -> P")
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	tx, err := wallet.P().IssueUnsignedTx(utx, common.WithContext(ctx))
+	if err != nil {
+		return wrapIssueError(ctx, err)
+	}
+	ux.Logger.PrintToUser("Tx ID: %s", tx.ID())
+	return nil
+}
+
+// exports the funds from X to the ewoq key, and imports them into C at [to]
+func fundCChain(network models.Network, to ethcommon.Address, amount uint64) error {
+	wallet, kc, err := getEwoqWallet(network)
+	if err != nil {
+		return err
+	}
+	baseFee, err := getCChainBaseFee(network)
+	if err != nil {
+		return fmt.Errorf("failed to get the C-Chain base fee: %w", err)
+	}
+	fee, err := estimateCChainImportFee(baseFee)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Issuing ExportTx X -> C")
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	exportTx, err := wallet.X().IssueExportTx(
+		wallet.C().BlockchainID(),
+		[]*lux.TransferableOutput{
+			{
+				Asset: lux.Asset{ID: wallet.X().LUXAssetID()},
+				Out: &secp256k1fx.TransferOutput{
+					// the C-Chain import fee is paid from the imported funds
+					Amt:          amount + fee,
+					OutputOwners: *ewoqOwner(kc),
+				},
+			},
+		},
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("error issuing export tx: %w", err)
+	}
+	xChainID := wallet.X().BlockchainID()
+	utxos, err := waitForExportedUTXOs(network, xChainID, cChain, wallet.C().BlockchainID(), exportTx.ID(), kc.Addresses().List())
+	if err != nil {
+		return err
+	}
+	wallet, _, err = getEwoqWallet(network)
+	if err != nil {
+		return err
+	}
+	// the base fee may have changed since the export, in which case [to]
+	// receives the exported funds minus the current import fee
+	baseFee, err = getCChainBaseFee(network)
+	if err != nil {
+		return fmt.Errorf("failed to get the C-Chain base fee: %w", err)
+	}
+	utx, err := newCChainImportTx(
+		wallet.C().NetworkID(),
+		wallet.C().BlockchainID(),
+		wallet.C().LUXAssetID(),
+		xChainID,
+		utxos,
+		kc.Addresses(),
+		to,
+		baseFee,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Issuing ImportTx X -> C")
+	ctx, cancel = utils.GetAPIContext()
+	defer cancel()
+	tx, err := wallet.C().IssueUnsignedAtomicTx(utx, common.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("error issuing import tx: %w", err)
+	}
+	ux.Logger.PrintToUser("Tx ID: %s", tx.ID())
+	return nil
+}
+
+// transfers the funds from the ewoq address on the subnet RPC
+func fundSubnetChain(network models.Network, subnetName string, to ethcommon.Address) error {
+	if !app.SidecarExists(subnetName) {
+		return fmt.Errorf("chain %s is neither P, X, C nor a subnet", subnetName)
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if sc.VM != models.SubnetEvm {
+		return fmt.Errorf("subnet %s is not a Subnet-EVM chain", subnetName)
+	}
+	blockchainID := sc.Networks[network.Name()].BlockchainID
+	if blockchainID == ids.Empty {
+		return fmt.Errorf("subnet %s is not deployed on %s", subnetName, network.Name())
+	}
+	rpcURL := fmt.Sprintf("%s/ext/bc/%s/rpc", network.Endpoint, blockchainID)
+	ewoqKey, err := crypto.HexToECDSA(vm.PrefundedEwoqPrivate)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fundEVMTimeout)
+	defer cancel()
+	ux.Logger.PrintToUser("Issuing transfer on %s", subnetName)
+	txHash, err := evm.Transfer(ctx, rpcURL, ewoqKey, to, evm.LUXToWei(amountFlt))
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Tx hash: %s", txHash.Hex())
+	return nil
+}
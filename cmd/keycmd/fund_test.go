@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package keycmd
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUTXO(txID ids.ID, index uint32, assetID ids.ID, amount uint64, owner ids.ShortID) *lux.UTXO {
+	return &lux.UTXO{
+		UTXOID: lux.UTXOID{TxID: txID, OutputIndex: index},
+		Asset:  lux.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}
+}
+
+func TestCheckFundableNetwork(t *testing.T) {
+	tests := []struct {
+		name      string
+		networkID uint32
+		expectErr bool
+	}{
+		{name: "local", networkID: models.LocalNetwork.ID},
+		{name: "devnet", networkID: models.DevnetNetwork.ID},
+		{name: "fuji", networkID: models.FujiNetwork.ID, expectErr: true},
+		{name: "mainnet", networkID: models.MainnetNetwork.ID, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":{"networkID":"%d"},"id":1}`, tt.networkID)
+			}))
+			defer server.Close()
+			// the endpoint of a devnet may point to a public network
+			network := models.DevnetNetwork
+			network.Endpoint = server.URL
+			err := checkFundableNetwork(network)
+			if tt.expectErr {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+			}
+		})
+	}
+}
+
+func TestNewPChainImportTx(t *testing.T) {
+	require := require.New(t)
+
+	const fee = 1000
+	luxAssetID := ids.GenerateTestID()
+	ewoqAddr := ids.GenerateTestShortID()
+	exportTxID := ids.GenerateTestID()
+	utxos := []*lux.UTXO{
+		newTestUTXO(exportTxID, 0, luxAssetID, 5*fee, ewoqAddr),
+		// exported earlier, or by someone else
+		newTestUTXO(ids.GenerateTestID(), 0, luxAssetID, 7*fee, ewoqAddr),
+		// not LUX
+		newTestUTXO(exportTxID, 1, ids.GenerateTestID(), 3*fee, ewoqAddr),
+		// not owned by the ewoq key
+		newTestUTXO(exportTxID, 2, luxAssetID, 9*fee, ids.GenerateTestShortID()),
+	}
+	txUTXOs := getTxUTXOs(utxos, exportTxID)
+	require.Len(txUTXOs, 3)
+
+	to := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{ids.GenerateTestShortID()}}
+	utx, err := newPChainImportTx(12345, luxAssetID, ids.GenerateTestID(), txUTXOs, set.Of(ewoqAddr), to, fee)
+	require.NoError(err)
+	require.Len(utx.ImportedInputs, 1)
+	require.Equal(exportTxID, utx.ImportedInputs[0].TxID)
+	require.Len(utx.Outs, 1)
+	require.Equal(uint64(4*fee), utx.Outs[0].Out.Amount())
+	require.Equal(*to, utx.Outs[0].Out.(*secp256k1fx.TransferOutput).OutputOwners)
+
+	// the imported funds must cover the fee
+	_, err = newPChainImportTx(12345, luxAssetID, ids.GenerateTestID(), txUTXOs, set.Of(ewoqAddr), to, 5*fee)
+	require.Error(err)
+	_, err = newPChainImportTx(12345, luxAssetID, ids.GenerateTestID(), txUTXOs, set.Of(ids.GenerateTestShortID()), to, fee)
+	require.ErrorIs(err, errNoImportableUTXOs)
+}
+
+func TestNewCChainImportTx(t *testing.T) {
+	require := require.New(t)
+
+	const amount = 1_000_000_000
+	baseFee := big.NewInt(25_000_000_000)
+	luxAssetID := ids.GenerateTestID()
+	ewoqAddr := ids.GenerateTestShortID()
+	to := ethcommon.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+
+	fee, err := estimateCChainImportFee(baseFee)
+	require.NoError(err)
+	require.NotZero(fee)
+	// the fee is gas based, not the fixed tx fee of the P and X chains
+	doubleFee, err := estimateCChainImportFee(new(big.Int).Mul(baseFee, big.NewInt(2)))
+	require.NoError(err)
+	require.Equal(2*fee, doubleFee)
+
+	// funding with amount+fee credits exactly amount
+	utxos := []*lux.UTXO{newTestUTXO(ids.GenerateTestID(), 0, luxAssetID, amount+fee, ewoqAddr)}
+	utx, err := newCChainImportTx(12345, ids.GenerateTestID(), luxAssetID, ids.GenerateTestID(), utxos, set.Of(ewoqAddr), to, baseFee)
+	require.NoError(err)
+	require.Len(utx.Outs, 1)
+	require.Equal(to, utx.Outs[0].Address)
+	require.Equal(uint64(amount), utx.Outs[0].Amount)
+	burned, err := utx.Burned(luxAssetID)
+	require.NoError(err)
+	require.Equal(fee, burned)
+
+	utxos = []*lux.UTXO{newTestUTXO(ids.GenerateTestID(), 0, luxAssetID, fee, ewoqAddr)}
+	_, err = newCChainImportTx(12345, ids.GenerateTestID(), luxAssetID, ids.GenerateTestID(), utxos, set.Of(ewoqAddr), to, baseFee)
+	require.Error(err)
+}
@@ -46,5 +46,11 @@ To get started, use the key create command.`,
 	// lux key transfer
 	cmd.AddCommand(newTransferCmd())
 
+	// lux key encrypt
+	cmd.AddCommand(newEncryptCmd())
+
+	// lux key fund
+	cmd.AddCommand(newFundCmd())
+
 	return cmd
 }
@@ -12,7 +12,9 @@ import (
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/key"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/ids"
 	ledger "github.com/luxdefi/node/utils/crypto/ledger"
 	"github.com/luxdefi/node/utils/formatting/address"
@@ -162,7 +164,7 @@ func listKeys(*cobra.Command, []string) error {
 			[]string{models.Mainnet.String(), models.Fuji.String(), models.Local.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--local, --fuji/--testnet, --mainnet or --"+allFlag)
 		}
 		network := models.NetworkFromString(networkStr)
 		networks = append(networks, network)
@@ -190,6 +192,9 @@ func listKeys(*cobra.Command, []string) error {
 			return err
 		}
 	}
+	if app.OutputFormat.IsStructured() {
+		return printStructuredAddrInfos(addrInfos)
+	}
 	printAddrInfos(addrInfos)
 	return nil
 }
@@ -231,21 +236,24 @@ func getStoredKeyInfo(
 	addrInfos := []addressInfo{}
 	for _, network := range networks {
 		keyName := strings.TrimSuffix(filepath.Base(keyPath), constants.KeySuffix)
-		sk, err := key.LoadSoft(network.ID, keyPath)
+		accounts, err := key.LoadAddresses(network.ID, keyPath)
 		if err != nil {
 			return nil, err
 		}
-		if cchain {
-			cChainAddr := sk.C()
-			addrInfo, err := getCChainAddrInfo(cClients, network, cChainAddr, "stored", keyName)
-			if err != nil {
-				return nil, err
+		for i, account := range accounts {
+			// accounts other than the default one are referenced as keyName/index
+			accountName := keyName
+			if i > 0 {
+				accountName = fmt.Sprintf("%s/%d", keyName, i)
 			}
-			addrInfos = append(addrInfos, addrInfo)
-		}
-		pChainAddrs := sk.P()
-		for _, pChainAddr := range pChainAddrs {
-			addrInfo, err := getPChainAddrInfo(pClients, network, pChainAddr, "stored", keyName)
+			if cchain {
+				addrInfo, err := getCChainAddrInfo(cClients, network, account.C, "stored", accountName)
+				if err != nil {
+					return nil, err
+				}
+				addrInfos = append(addrInfos, addrInfo)
+			}
+			addrInfo, err := getPChainAddrInfo(pClients, network, account.P, "stored", accountName)
 			if err != nil {
 				return nil, err
 			}
@@ -377,6 +385,31 @@ func printAddrInfos(addrInfos []addressInfo) {
 	table.Render()
 }
 
+// addressInfoEntry is the structured (json/yaml) output of key list
+type addressInfoEntry struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Chain   string `json:"chain"`
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+	Network string `json:"network"`
+}
+
+func printStructuredAddrInfos(addrInfos []addressInfo) error {
+	entries := []addressInfoEntry{}
+	for _, addrInfo := range addrInfos {
+		entries = append(entries, addressInfoEntry{
+			Kind:    addrInfo.kind,
+			Name:    addrInfo.name,
+			Chain:   addrInfo.chain,
+			Address: addrInfo.address,
+			Balance: strings.TrimSpace(addrInfo.balance),
+			Network: addrInfo.network,
+		})
+	}
+	return ux.PrintDocument(os.Stdout, app.OutputFormat, "KeyList", entries)
+}
+
 func getCChainBalanceStr(cClient ethclient.Client, addrStr string) (string, error) {
 	addr := common.HexToAddress(addrStr)
 	ctx, cancel := utils.GetAPIContext()
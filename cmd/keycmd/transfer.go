@@ -4,9 +4,11 @@ package keycmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/luxdefi/cli/pkg/key"
 	"github.com/luxdefi/cli/pkg/models"
 	"github.com/luxdefi/cli/pkg/prompts"
@@ -38,6 +40,8 @@ const (
 	amountFlag              = "amount"
 	wrongLedgerIndexVal     = 32768
 	receiveRecoveryStepFlag = "receive-recovery-step"
+	senderChainFlag         = "sender-chain"
+	receiverChainFlag       = "receiver-chain"
 )
 
 var (
@@ -49,13 +53,21 @@ var (
 	receiverAddrStr     string
 	amountFlt           float64
 	receiveRecoveryStep uint64
+	senderChain         string
+	receiverChain       string
 )
 
 func newTransferCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "transfer [options]",
-		Short:        "Fund a ledger address or stored key from another one",
-		Long:         `The key transfer command allows to transfer funds between stored keys or ledger addresses.`,
+		Use:   "transfer [options]",
+		Short: "Fund a ledger address or stored key from another one",
+		Long: `The key transfer command allows to transfer funds between stored keys or ledger addresses.
+
+By default funds are transferred between P-Chain addresses. With --sender-chain and
+--receiver-chain, funds can also be moved from the C-Chain to the P-Chain or X-Chain, and
+the reverse, by exporting them from the sender chain and importing them into the receiver
+chain. C-Chain transfers need stored keys, and the C-Chain address of the receiver key is
+the one funded.`,
 		RunE:         transferF,
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
@@ -144,6 +156,18 @@ func newTransferCmd() *cobra.Command {
 		0,
 		"amount to send or receive (LUX units)",
 	)
+	cmd.Flags().StringVar(
+		&senderChain,
+		senderChainFlag,
+		pChain,
+		"chain of the sender address: P, X or C",
+	)
+	cmd.Flags().StringVar(
+		&receiverChain,
+		receiverChainFlag,
+		pChain,
+		"chain of the receiver address: P, X or C",
+	)
 	return cmd
 }
 
@@ -156,6 +180,11 @@ func transferF(*cobra.Command, []string) error {
 		return fmt.Errorf("only one between a keyname or a ledger index must be given")
 	}
 
+	if err := validateTransferChains(senderChain, receiverChain); err != nil {
+		return err
+	}
+	atomicTransfer := isAtomicTransfer(senderChain, receiverChain)
+
 	var network models.Network
 	switch {
 	case local:
@@ -170,7 +199,7 @@ func transferF(*cobra.Command, []string) error {
 			[]string{models.Mainnet.String(), models.Fuji.String(), models.Local.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--local, --fuji/--testnet or --mainnet")
 		}
 		network = models.NetworkFromString(networkStr)
 	}
@@ -183,7 +212,7 @@ func transferF(*cobra.Command, []string) error {
 			[]string{"Send", "Receive"},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+sendFlag+" or --"+receiveFlag)
 		}
 		if option == "Send" {
 			send = true
@@ -207,7 +236,7 @@ func transferF(*cobra.Command, []string) error {
 		if useLedger {
 			ledgerIndex, err = app.Prompt.CaptureUint32("Ledger index to use")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--"+ledgerIndexFlag)
 			}
 		}
 	}
@@ -226,22 +255,31 @@ func transferF(*cobra.Command, []string) error {
 			return nil
 		})
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+amountFlag)
 		}
 	}
 	amount := uint64(amountFlt * float64(units.Lux))
 
 	fee := network.GenesisParams().TxFee
 
-	var kc keychain.Keychain
+	var (
+		kc          keychain.Keychain
+		ethKeychain = secp256k1fx.NewKeychain()
+		cChainAddr  ethcommon.Address
+	)
 	if keyName != "" {
-		keyPath := app.GetKeyPath(keyName)
-		sk, err := key.LoadSoft(network.ID, keyPath)
+		sk, err := app.LoadKey(network.ID, keyName)
 		if err != nil {
 			return err
 		}
 		kc = sk.KeyChain()
+		// the C-Chain key of a stored key may differ from its P/X key
+		ethKeychain = secp256k1fx.NewKeychain(sk.CKey())
+		cChainAddr = ethcommon.HexToAddress(sk.C())
 	} else {
+		if atomicTransfer {
+			return errors.New("C-Chain transfers are not supported with ledger addresses")
+		}
 		ledgerDevice, err := ledger.New()
 		if err != nil {
 			return err
@@ -258,7 +296,7 @@ func transferF(*cobra.Command, []string) error {
 		if receiverAddrStr == "" {
 			receiverAddrStr, err = app.Prompt.CapturePChainAddress("Receiver address", network)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--"+receiverAddrFlag)
 			}
 		}
 		receiverAddr, err = address.ParseToID(receiverAddrStr)
@@ -267,7 +305,7 @@ func transferF(*cobra.Command, []string) error {
 		}
 	} else {
 		receiverAddr = kc.Addresses().List()[0]
-		receiverAddrStr, err = address.Format("P", key.GetHRP(network.ID), receiverAddr[:])
+		receiverAddrStr, err = address.Format(receiverChain, key.GetHRP(network.ID), receiverAddr[:])
 		if err != nil {
 			return err
 		}
@@ -277,17 +315,29 @@ func transferF(*cobra.Command, []string) error {
 	ux.Logger.PrintToUser("this operation is going to:")
 	if send {
 		addr := kc.Addresses().List()[0]
-		addrStr, err := address.Format("P", key.GetHRP(network.ID), addr[:])
+		addrStr, err := address.Format(senderChain, key.GetHRP(network.ID), addr[:])
 		if err != nil {
 			return err
 		}
 		if addr == receiverAddr {
 			return fmt.Errorf("sender addr is the same as receiver addr")
 		}
-		ux.Logger.PrintToUser("- send %.9f LUX from %s to target address %s", float64(amount)/float64(units.Lux), addrStr, receiverAddrStr)
-		ux.Logger.PrintToUser("- take a fee of %.9f LUX from source address %s", float64(4*fee)/float64(units.Lux), addrStr)
+		if atomicTransfer {
+			if senderChain == cChain {
+				addrStr = cChainAddr.Hex()
+			}
+			ux.Logger.PrintToUser("- send %.9f LUX from %s-Chain address %s to %s-Chain target address %s",
+				float64(amount)/float64(units.Lux), senderChain, addrStr, receiverChain, receiverAddrStr)
+			ux.Logger.PrintToUser("- take the %s-Chain export fee and the %s-Chain import fee from source address %s", senderChain, receiverChain, addrStr)
+		} else {
+			ux.Logger.PrintToUser("- send %.9f LUX from %s to target address %s", float64(amount)/float64(units.Lux), addrStr, receiverAddrStr)
+			ux.Logger.PrintToUser("- take a fee of %.9f LUX from source address %s", float64(4*fee)/float64(units.Lux), addrStr)
+		}
 	} else {
-		ux.Logger.PrintToUser("- receive %.9f LUX at target address %s", float64(amount)/float64(units.Lux), receiverAddrStr)
+		if receiverChain == cChain {
+			receiverAddrStr = cChainAddr.Hex()
+		}
+		ux.Logger.PrintToUser("- receive %.9f LUX at %s-Chain target address %s", float64(amount)/float64(units.Lux), receiverChain, receiverAddrStr)
 	}
 	ux.Logger.PrintToUser("")
 
@@ -295,7 +345,7 @@ func transferF(*cobra.Command, []string) error {
 		confStr := "Confirm transfer"
 		conf, err := app.Prompt.CaptureNoYes(confStr)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+forceFlag)
 		}
 		if !conf {
 			ux.Logger.PrintToUser("Cancelled")
@@ -308,6 +358,13 @@ func transferF(*cobra.Command, []string) error {
 		Addrs:     []ids.ShortID{receiverAddr},
 	}
 
+	if atomicTransfer {
+		if send {
+			return sendAtomicTransfer(network, kc, ethKeychain, senderChain, receiverChain, amount, to)
+		}
+		return receiveAtomicTransfer(network, kc, senderChain, receiverChain, to, cChainAddr)
+	}
+
 	if send {
 		wallet, err := primary.MakeWallet(
 			context.Background(),
@@ -0,0 +1,57 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package manifestcmd
+
+import (
+	"github.com/luxdefi/cli/cmd/networkcmd"
+	"github.com/luxdefi/cli/cmd/subnetcmd"
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/manifest"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var clean bool
+
+// lux down
+func NewDownCmd(injectedApp *application.Lux) *cobra.Command {
+	app = injectedApp
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Tear down the local environment described by a manifest",
+		Long: `The down command stops the local network, saving its state into the snapshot of the
+manifest, so that lux up resumes the environment where it was left.
+
+With --clean, the local network and its snapshots are removed instead, together with the
+configurations of the manifest Subnets. Keys are kept.`,
+		RunE:         down,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&manifestPath, "file", "f", manifest.DefaultFileName, "manifest file")
+	cmd.Flags().BoolVar(&clean, "clean", false, "remove the network state and the Subnet configurations")
+	return cmd
+}
+
+func down(*cobra.Command, []string) error {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	if !clean {
+		return networkcmd.CallStop(m.Network.Snapshot)
+	}
+	if err := networkcmd.CallClean(false); err != nil {
+		return err
+	}
+	for _, s := range m.Subnets {
+		if !app.SubnetConfigExists(s.Name) {
+			continue
+		}
+		if err := subnetcmd.CallDeleteSubnet(s.Name); err != nil {
+			return err
+		}
+	}
+	ux.Logger.PrintToUser("Environment of %s removed", manifestPath)
+	return nil
+}
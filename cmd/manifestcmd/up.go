@@ -0,0 +1,283 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package manifestcmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/luxdefi/cli/cmd/networkcmd"
+	"github.com/luxdefi/cli/cmd/subnetcmd"
+	"github.com/luxdefi/cli/cmd/subnetcmd/upgradecmd"
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/evm"
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/manifest"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/node/ids"
+	"github.com/spf13/cobra"
+)
+
+// time to fund a key on a chain
+const fundTimeout = time.Minute
+
+var (
+	app          *application.Lux
+	manifestPath string
+)
+
+// lux up
+func NewUpCmd(injectedApp *application.Lux) *cobra.Command {
+	app = injectedApp
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Bring up the local environment described by a manifest",
+		Long: `The up command reconciles the local environment with a manifest file, lux.yaml by
+default. It starts the local network, creates and deploys the manifest Subnets, applies
+their upgrade bytes, and creates and funds the manifest keys.
+
+The command is idempotent: existing Subnet configurations and keys are kept, deployed
+Subnets are not deployed again, upgrade bytes already applied are skipped, and keys are
+only funded up to the given amount. Run it again after editing the manifest to apply the
+changes.
+
+Example manifest:
+
+  network:
+    numNodes: 5            # nodes of a fresh network, the snapshot size if not given
+    nodeVersion: latest
+    nodeConfig: node.json  # luxd flags for all nodes
+    snapshot: myenv        # started from if it exists, saved into by lux down
+  subnets:
+    - name: alpha
+      genesis: ./alpha.json
+      vm: subnet-evm       # or custom, with customVMRepoURL, customVMBranch and customVMBuildScript
+      vmVersion: latest
+      validators: [node1, node2=2000]
+      upgrade: ./alpha-upgrade.json
+  keys:
+    - name: alice
+      fund:
+        - chain: alpha     # C or a manifest subnet
+          amount: 1000
+
+Keys are funded from the prefunded ewoq address, so the genesis of the Subnets must
+fund it. Relative paths are relative to the manifest directory.`,
+		RunE:         up,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&manifestPath, "file", "f", manifest.DefaultFileName, "manifest file")
+	return cmd
+}
+
+func up(cmd *cobra.Command, _ []string) error {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := upNetwork(m.Network); err != nil {
+		return err
+	}
+	for _, s := range m.Subnets {
+		if err := upSubnetConfig(cmd, s); err != nil {
+			return err
+		}
+	}
+	deployed, err := subnet.GetLocallyDeployedSubnets()
+	if err != nil {
+		return err
+	}
+	for _, s := range m.Subnets {
+		if _, ok := deployed[s.Name]; ok {
+			ux.Logger.PrintToUser("Subnet %s already deployed", s.Name)
+			continue
+		}
+		if err := subnetcmd.CallDeployLocal(cmd, s.Name, s.Validators); err != nil {
+			return fmt.Errorf("failed to deploy subnet %s: %w", s.Name, err)
+		}
+	}
+	for _, s := range m.Subnets {
+		if s.Upgrade == "" {
+			continue
+		}
+		if err := upUpgrade(s); err != nil {
+			return fmt.Errorf("failed to apply upgrade bytes of subnet %s: %w", s.Name, err)
+		}
+	}
+	for _, k := range m.Keys {
+		if err := upKey(k); err != nil {
+			return err
+		}
+	}
+	ux.Logger.PrintToUser("Environment of %s is up", manifestPath)
+	return nil
+}
+
+// starts the network from the manifest snapshot, or a fresh network of
+// [Network.NumNodes] if the snapshot has no subnets yet
+func upNetwork(n manifest.Network) error {
+	fresh := false
+	if n.NumNodes > 0 {
+		if n.Snapshot == constants.DefaultSnapshotName {
+			// the default snapshot always exists, it has the bootstrap network
+			// until a subnet is deployed
+			deployedSubnets, err := subnet.GetLocallyDeployedSubnetsFromFile(app)
+			if err != nil {
+				return err
+			}
+			fresh = len(deployedSubnets) == 0
+		} else {
+			fresh = !subnet.SnapshotExists(app.GetSnapshotsDir(), n.Snapshot)
+		}
+	}
+	if fresh {
+		return networkcmd.CallStart(n.NodeVersion, constants.DefaultSnapshotName, n.NumNodes, n.NodeConfig)
+	}
+	snapshotName := n.Snapshot
+	if !subnet.SnapshotExists(app.GetSnapshotsDir(), snapshotName) {
+		snapshotName = constants.DefaultSnapshotName
+	}
+	return networkcmd.CallStart(n.NodeVersion, snapshotName, 0, n.NodeConfig)
+}
+
+// creates the subnet configuration, keeping an existing one
+func upSubnetConfig(cmd *cobra.Command, s manifest.Subnet) error {
+	if app.SubnetConfigExists(s.Name) {
+		genesis, err := app.LoadRawGenesis(s.Name)
+		if err != nil {
+			return err
+		}
+		manifestGenesis, err := os.ReadFile(s.Genesis)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(genesis, manifestGenesis) {
+			ux.Logger.PrintToUser("Warning: the genesis of subnet %s differs from %s, use lux down --clean to recreate it",
+				s.Name, s.Genesis)
+		}
+		return nil
+	}
+	ux.Logger.PrintToUser("Creating subnet %s", s.Name)
+	return subnetcmd.CallCreate(
+		cmd,
+		s.Name,
+		false,
+		s.Genesis,
+		s.VM == models.SubnetEvm,
+		s.VM == models.CustomVM,
+		s.VMVersion,
+		false,
+		s.CustomVMRepoURL,
+		s.CustomVMBranch,
+		s.CustomVMBuildScript,
+	)
+}
+
+// installs and applies the upgrade bytes of the subnet, unless they have
+// already been applied
+func upUpgrade(s manifest.Subnet) error {
+	upgradeBytes, err := os.ReadFile(s.Upgrade)
+	if err != nil {
+		return err
+	}
+	installed, err := app.ReadUpgradeFile(s.Name)
+	if err == nil && bytes.Equal(installed, upgradeBytes) {
+		// the lock file is written once the upgrade is applied
+		if _, err := app.ReadLockUpgradeFile(s.Name); err == nil {
+			ux.Logger.PrintToUser("Upgrade bytes of subnet %s already applied", s.Name)
+			return nil
+		}
+	}
+	if err := app.WriteUpgradeFile(s.Name, upgradeBytes); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Applying upgrade bytes of subnet %s", s.Name)
+	// timestamps in the past are expected when the environment is recreated
+	return upgradecmd.CallApplyLocal(s.Name, true)
+}
+
+// creates the key if it doesn't exist, and tops up its balances
+func upKey(k manifest.Key) error {
+	if !app.KeyExists(k.Name) {
+		sk, err := key.NewSoft(0)
+		if err != nil {
+			return err
+		}
+		if err := sk.Save(app.GetKeyPath(k.Name)); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Key %s created", k.Name)
+	}
+	if len(k.Fund) == 0 {
+		return nil
+	}
+	sk, err := app.LoadKey(models.LocalNetwork.ID, k.Name)
+	if err != nil {
+		return err
+	}
+	address := common.HexToAddress(sk.C())
+	ewoqKey, err := crypto.HexToECDSA(vm.PrefundedEwoqPrivate)
+	if err != nil {
+		return err
+	}
+	for _, fund := range k.Fund {
+		rpcURL, err := getChainRPCEndpoint(fund.Chain)
+		if err != nil {
+			return err
+		}
+		if err := topUp(rpcURL, ewoqKey, address, fund); err != nil {
+			return fmt.Errorf("failed to fund key %s on %s: %w", k.Name, fund.Chain, err)
+		}
+	}
+	return nil
+}
+
+// transfers from the ewoq key the difference between the balance of [address]
+// and the amount of [fund]
+func topUp(rpcURL string, ewoqKey *ecdsa.PrivateKey, address common.Address, fund manifest.Fund) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fundTimeout)
+	defer cancel()
+	balance, err := evm.GetBalance(ctx, rpcURL, address)
+	if err != nil {
+		return err
+	}
+	target := evm.LUXToWei(fund.Amount)
+	if balance.Cmp(target) >= 0 {
+		ux.Logger.PrintToUser("Key %s already has %.9f on %s", address.Hex(), evm.WeiToLUX(balance), fund.Chain)
+		return nil
+	}
+	amount := new(big.Int).Sub(target, balance)
+	if _, err := evm.Transfer(ctx, rpcURL, ewoqKey, address, amount); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Funded %s with %.9f on %s", address.Hex(), evm.WeiToLUX(amount), fund.Chain)
+	return nil
+}
+
+// returns the local RPC endpoint of the C-Chain, or of a deployed subnet
+func getChainRPCEndpoint(chain string) (string, error) {
+	if chain == manifest.CChain {
+		return models.LocalNetwork.CChainEndpoint(), nil
+	}
+	sc, err := app.LoadSidecar(chain)
+	if err != nil {
+		return "", err
+	}
+	blockchainID := sc.Networks[models.Local.String()].BlockchainID
+	if blockchainID == ids.Empty {
+		return "", fmt.Errorf("subnet %s is not deployed locally", chain)
+	}
+	return fmt.Sprintf("%s/ext/bc/%s/rpc", models.LocalNetwork.Endpoint, blockchainID), nil
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/binutils"
+	"github.com/luxdefi/cli/pkg/chaos"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/netrunner/client"
+	anrutils "github.com/luxdefi/netrunner/utils"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	chaosSeed      int64
+	chaosNodes     []string
+	chaosRandom    int
+	chaosDuration  time.Duration
+	chaosVMBinary  string
+	chaosSubnet    string
+	chaosChains    []string
+	chaosDeadline  time.Duration
+	chaosNoTraffic bool
+)
+
+// lux network chaos
+func newChaosCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chaos",
+		Short: "Inject faults into the local network to test Subnet resilience",
+		Long: `The network chaos command suite injects faults into the running local network: it
+kills, pauses or restarts nodes, optionally with a new VM binary, and then checks that the
+custom chains produce new blocks within a deadline. A pass/fail report is printed at the
+end, and the command fails if any chain didn't recover.
+
+EVM chains only build blocks when they have transactions, so zero value transfers of the
+prefunded ewoq address are sent to the chains during the checks, unless --no-traffic
+is given.
+
+Nodes chosen at random are validators of the checked chains, excluding the node that
+serves the local API endpoint. Use --seed to repeat the choices of a previous run.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	// network chaos run
+	cmd.AddCommand(newChaosRunCmd())
+	// network chaos kill
+	cmd.AddCommand(newChaosStepCmd(
+		chaos.KillAction,
+		"Remove nodes from the local network and check the chains keep producing blocks",
+	))
+	// network chaos pause
+	cmd.AddCommand(newChaosStepCmd(
+		chaos.PauseAction,
+		"Pause nodes for a while and check the chains recover after resuming them",
+	))
+	// network chaos restart
+	cmd.AddCommand(newChaosStepCmd(
+		chaos.RestartAction,
+		"Restart nodes, optionally with a new VM binary, and check the chains recover",
+	))
+	return cmd
+}
+
+// lux network chaos run
+func newChaosRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [scenarioFile]",
+		Short: "Run a chaos scenario from a YAML file",
+		Long: `The network chaos run command runs the steps of a YAML scenario file in order,
+checking after each step that the chains produce a new block within the deadline.
+
+Example scenario:
+
+  name: subnet-evm upgrade rehearsal
+  chains: [mychain]      # all custom chains if not given
+  deadline: 1m           # time to produce a new block after each step
+  steps:
+    - action: kill       # kill, pause, restart or wait
+      random: 1          # number of random validators, or nodes: [node2, node3]
+    - action: pause
+      nodes: [node3]
+      duration: 30s
+    - action: restart
+      nodes: [node2, node3]
+      vmBinary: ./subnet-evm-v0.5.4
+      subnet: mychain
+      deadline: 2m       # overrides the scenario deadline
+    - action: wait
+      duration: 1m`,
+		RunE:         runChaosScenarioFile,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().Int64Var(&chaosSeed, "seed", 0, "seed for the random choice of nodes (default random)")
+	return cmd
+}
+
+func newChaosStepCmd(action chaos.Action, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   string(action),
+		Short: short,
+		Long: fmt.Sprintf(`The network chaos %s command runs a one step chaos scenario. Nodes are given with
+--nodes, or chosen at random with --random. A random validator is chosen if neither
+is given.`, action),
+		RunE: func(*cobra.Command, []string) error {
+			return runChaosStep(action)
+		},
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&chaosNodes, "nodes", nil, "nodes to act on")
+	cmd.Flags().IntVar(&chaosRandom, "random", 0, "number of validators to act on, chosen at random")
+	cmd.Flags().StringSliceVar(&chaosChains, "chains", nil, "custom chains to check (default all)")
+	cmd.Flags().DurationVar(&chaosDeadline, "deadline", chaos.DefaultDeadline, "time for the chains to produce a new block")
+	cmd.Flags().BoolVar(&chaosNoTraffic, "no-traffic", false, "do not send probe transactions to the chains")
+	cmd.Flags().Int64Var(&chaosSeed, "seed", 0, "seed for the random choice of nodes (default random)")
+	switch action {
+	case chaos.PauseAction:
+		cmd.Flags().DurationVar(&chaosDuration, "duration", 30*time.Second, "time the nodes stay paused")
+	case chaos.RestartAction:
+		cmd.Flags().StringVar(&chaosVMBinary, "vm-binary", "", "install this VM binary for --subnet before restarting")
+		cmd.Flags().StringVar(&chaosSubnet, "subnet", "", "subnet whose VM binary is replaced")
+	}
+	return cmd
+}
+
+func runChaosScenarioFile(_ *cobra.Command, args []string) error {
+	scenario, err := chaos.LoadScenario(args[0])
+	if err != nil {
+		return err
+	}
+	return runChaosScenario(scenario)
+}
+
+func runChaosStep(action chaos.Action) error {
+	step := chaos.Step{
+		Action:   action,
+		Nodes:    chaosNodes,
+		Random:   chaosRandom,
+		VMBinary: chaosVMBinary,
+		Subnet:   chaosSubnet,
+	}
+	if action == chaos.PauseAction {
+		step.Duration = chaosDuration
+	}
+	if len(step.Nodes) == 0 && step.Random == 0 {
+		step.Random = 1
+	}
+	scenario := &chaos.Scenario{
+		Name:      string(action),
+		Chains:    chaosChains,
+		Deadline:  chaosDeadline,
+		NoTraffic: chaosNoTraffic,
+		Steps:     []chaos.Step{step},
+	}
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+	return runChaosScenario(scenario)
+}
+
+func runChaosScenario(scenario *chaos.Scenario) error {
+	ctx, cancel := utils.GetAPIContext()
+	cli, _, err := getRunningNetwork(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	chainClient, err := chaos.NewEVMChainClient()
+	if err != nil {
+		return err
+	}
+	seed := chaosSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	runner := chaos.NewRunner(cli, chainClient, upgradeChaosVM, seed, client.WithPluginDir(app.GetPluginsDir()))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	go func() {
+		select {
+		case <-sigc:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ux.Logger.PrintToUser("Running chaos scenario %s with seed %d", scenario.Name, seed)
+	report, err := runner.Run(ctx, scenario)
+	if err != nil {
+		return err
+	}
+	if app.OutputFormat.IsStructured() {
+		if err := ux.PrintDocument(os.Stdout, app.OutputFormat, "ChaosReport", report); err != nil {
+			return err
+		}
+	} else {
+		printChaosReport(report)
+	}
+	if !report.Passed {
+		return fmt.Errorf("chaos scenario %s failed, use --seed %d to repeat it", scenario.Name, seed)
+	}
+	return nil
+}
+
+// installs [vmBinary] in the plugins dir as the VM of [subnetName], and
+// updates its local RPC version, as subnet upgrade vm does
+func upgradeChaosVM(subnetName string, vmBinary string) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("failed to load sidecar of subnet %s: %w", subnetName, err)
+	}
+	vmID, err := anrutils.VMID(sc.Name)
+	if err != nil {
+		return err
+	}
+	rpcVersion, err := vm.GetVMBinaryProtocolVersion(vmBinary)
+	if err != nil {
+		return fmt.Errorf("unable to get RPC version: %w", err)
+	}
+	if err := binutils.UpgradeVM(app, vmID.String(), vmBinary); err != nil {
+		return err
+	}
+	if err := binutils.UpdateLocalSidecarRPC(app, sc, rpcVersion); err != nil {
+		return fmt.Errorf("unable to set RPC version: %w", err)
+	}
+	return nil
+}
+
+func printChaosReport(report *chaos.Report) {
+	fmt.Println()
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Step", "Action", "Nodes", "Chain", "Height Before", "Height After", "Recovery", "Result"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1, 2})
+	table.SetRowLine(true)
+	for _, step := range report.Steps {
+		stepName := strconv.Itoa(step.Index)
+		nodes := strings.Join(step.Nodes, ", ")
+		if step.Error != "" {
+			table.Append([]string{stepName, string(step.Action), nodes, "", "", "", "", "FAIL: " + step.Error})
+			continue
+		}
+		for _, chainResult := range step.Chains {
+			result := "PASS"
+			recovery := fmt.Sprintf("%.1fs", chainResult.RecoverySeconds)
+			if !chainResult.Passed {
+				result = "FAIL: " + chainResult.Error
+				recovery = ""
+			}
+			table.Append([]string{
+				stepName,
+				string(step.Action),
+				nodes,
+				chainResult.ChainName,
+				strconv.FormatUint(chainResult.HeightBefore, 10),
+				strconv.FormatUint(chainResult.HeightAfter, 10),
+				recovery,
+				result,
+			})
+		}
+	}
+	table.Render()
+	result := "PASSED"
+	if !report.Passed {
+		result = "FAILED"
+	}
+	ux.Logger.PrintToUser("Scenario %s %s in %s", report.Scenario, result,
+		(time.Duration(report.DurationSeconds * float64(time.Second))).Round(time.Second))
+}
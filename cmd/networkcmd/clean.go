@@ -43,6 +43,12 @@ configuration.`,
 	return cmd
 }
 
+// CallClean stops the local network and deletes its state, as network clean does
+func CallClean(hardParam bool) error {
+	hard = hardParam
+	return clean(nil, nil)
+}
+
 func clean(*cobra.Command, []string) error {
 	app.Log.Info("killing gRPC server process...")
 
@@ -0,0 +1,180 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/spf13/cobra"
+)
+
+const (
+	logsPollInterval = 500 * time.Millisecond
+	mainLogName      = "main"
+)
+
+var (
+	logNodes   []string
+	logChain   string
+	followLogs bool
+	logGrep    string
+	logSince   time.Duration
+
+	primaryChainAliases = []string{"P", "C", "X"}
+)
+
+// lux network logs
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print the logs of the local network nodes",
+		Long: `The network logs command prints the logs of the running local network, merging the
+logs of all nodes by timestamp and prefixing each line with its node name.
+
+By default the main log of every node is printed. Use --node to select some nodes, and
+--chain to print the logs of a chain instead, given by name or blockchain ID. P, C and
+X select the primary network chains. With --follow, new lines are printed as they are
+logged, until interrupted.`,
+		RunE:         printLogs,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&logNodes, "node", nil, "print the logs of these nodes only")
+	cmd.Flags().StringVar(&logChain, "chain", "", "print the logs of this chain, given by name or blockchain ID")
+	cmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "keep printing new log lines")
+	cmd.Flags().StringVar(&logGrep, "grep", "", "print only the lines matching this regular expression")
+	cmd.Flags().DurationVar(&logSince, "since", 0, "print only the lines logged in this period, eg 5m")
+	return cmd
+}
+
+func printLogs(*cobra.Command, []string) error {
+	var re *regexp.Regexp
+	if logGrep != "" {
+		var err error
+		re, err = regexp.Compile(logGrep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep expression: %w", err)
+		}
+	}
+	var since time.Time
+	if logSince > 0 {
+		since = time.Now().Add(-logSince)
+	}
+
+	ctx, cancel := utils.GetAPIContext()
+	_, clusterInfo, err := getRunningNetwork(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	sources, err := getLogSources(clusterInfo, logNodes, logChain)
+	if err != nil {
+		return err
+	}
+	prefixLen := 0
+	for _, source := range sources {
+		if len(source.Prefix) > prefixLen {
+			prefixLen = len(source.Prefix)
+		}
+	}
+
+	tailer := utils.NewLogTailer(sources)
+	tailer.SetFilter(re, since)
+	lines, err := tailer.Read(true)
+	if err != nil {
+		return err
+	}
+	printLogLines(lines, prefixLen)
+	if !followLogs {
+		return nil
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sigc:
+			return nil
+		case <-ticker.C:
+			lines, err := tailer.Read(true)
+			if err != nil {
+				return err
+			}
+			printLogLines(lines, prefixLen)
+		}
+	}
+}
+
+// returns the log files of [chain] for the given nodes, or of all nodes if
+// [nodeNames] is empty
+func getLogSources(clusterInfo *rpcpb.ClusterInfo, nodeNames []string, chain string) ([]utils.LogSource, error) {
+	if len(nodeNames) == 0 {
+		nodeNames = clusterInfo.NodeNames
+	}
+	logName, err := getChainLogName(clusterInfo, chain)
+	if err != nil {
+		return nil, err
+	}
+	sources := []utils.LogSource{}
+	for _, nodeName := range nodeNames {
+		nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+		if !ok {
+			return nil, fmt.Errorf("node %s is not part of the local network, available nodes: %s",
+				nodeName, strings.Join(clusterInfo.NodeNames, ", "))
+		}
+		sources = append(sources, utils.LogSource{
+			Prefix: nodeName,
+			Path:   filepath.Join(nodeInfo.LogDir, logName+".log"),
+		})
+	}
+	return sources, nil
+}
+
+// nodes log each chain into a file named after its blockchain ID, or alias
+// for the primary network chains
+func getChainLogName(clusterInfo *rpcpb.ClusterInfo, chain string) (string, error) {
+	if chain == "" {
+		return mainLogName, nil
+	}
+	for _, alias := range primaryChainAliases {
+		if strings.EqualFold(chain, alias) {
+			return alias, nil
+		}
+	}
+	if _, ok := clusterInfo.CustomChains[chain]; ok {
+		return chain, nil
+	}
+	chainNames := []string{}
+	for blockchainID, chainInfo := range clusterInfo.CustomChains {
+		if chainInfo.ChainName == chain {
+			return blockchainID, nil
+		}
+		chainNames = append(chainNames, chainInfo.ChainName)
+	}
+	sort.Strings(chainNames)
+	available := append(append([]string{}, primaryChainAliases...), chainNames...)
+	return "", fmt.Errorf("chain %s not found in the local network, available chains: %s",
+		chain, strings.Join(available, ", "))
+}
+
+func printLogLines(lines []utils.LogLine, prefixLen int) {
+	for _, line := range lines {
+		prefix := fmt.Sprintf("[%s]%s ", line.Prefix, strings.Repeat(" ", prefixLen-len(line.Prefix)))
+		for _, text := range strings.Split(line.Text, "\n") {
+			fmt.Println(prefix + text)
+		}
+	}
+}
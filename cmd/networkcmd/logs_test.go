@@ -0,0 +1,40 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package networkcmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLogSources(t *testing.T) {
+	require := require.New(t)
+
+	clusterInfo := newTestClusterInfo()
+	for _, nodeInfo := range clusterInfo.NodeInfos {
+		nodeInfo.LogDir = filepath.Join("logs", nodeInfo.Name)
+	}
+
+	sources, err := getLogSources(clusterInfo, nil, "")
+	require.NoError(err)
+	require.Len(sources, 3)
+	require.Equal("node1", sources[0].Prefix)
+	require.Equal(filepath.Join("logs", "node1", "main.log"), sources[0].Path)
+
+	sources, err = getLogSources(clusterInfo, []string{"node3"}, "test")
+	require.NoError(err)
+	require.Len(sources, 1)
+	require.Equal(filepath.Join("logs", "node3", "chainID.log"), sources[0].Path)
+
+	sources, err = getLogSources(clusterInfo, []string{"node2"}, "c")
+	require.NoError(err)
+	require.Equal(filepath.Join("logs", "node2", "C.log"), sources[0].Path)
+
+	_, err = getLogSources(clusterInfo, []string{"node9"}, "")
+	require.ErrorContains(err, "node9")
+	_, err = getLogSources(clusterInfo, nil, "unknown")
+	require.ErrorContains(err, "available chains: P, C, X, test")
+}
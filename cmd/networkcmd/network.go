@@ -21,7 +21,7 @@ deployments.
 
 When you deploy a Subnet locally, it runs on a local, multi-node Lux network. The
 subnet deploy command starts this network in the background. This command suite allows you
-to shutdown, restart, and clear that network.
+to shutdown, restart, snapshot, and clear that network.
 
 This network currently supports multiple, concurrently deployed Subnets.`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -40,5 +40,13 @@ This network currently supports multiple, concurrently deployed Subnets.`,
 	cmd.AddCommand(newCleanCmd())
 	// network status
 	cmd.AddCommand(newStatusCmd())
+	// network snapshot
+	cmd.AddCommand(newSnapshotCmd())
+	// network node
+	cmd.AddCommand(newNodeCmd())
+	// network logs
+	cmd.AddCommand(newLogsCmd())
+	// network chaos
+	cmd.AddCommand(newChaosCmd())
 	return cmd
 }
@@ -0,0 +1,283 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/binutils"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/client"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/luxdefi/netrunner/server"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+const trackSubnetsKey = "track-subnets"
+
+var (
+	forceNodeOp          bool
+	addNodeConfigPath    string
+	errNetworkNotRunning = errors.New("no local network running")
+)
+
+// lux network node
+func newNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Control the nodes of the local network",
+		Long: `The network node command suite controls single nodes of the running local network,
+to test validator churn, node restarts and Subnet liveness.
+
+Node names can be obtained with network status. Removed and added nodes are kept in the
+snapshot saved by network stop.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	// network node add
+	cmd.AddCommand(newNodeAddCmd())
+	// network node remove
+	cmd.AddCommand(newNodeOpCmd(
+		"remove",
+		"Remove a node from the local network",
+		`The network node remove command stops the given node and removes it from the local
+network. Its Subnet validations are kept on the P-Chain, so removing validators reduces
+the connected stake of their Subnets.`,
+		removeNode,
+	))
+	// network node restart
+	cmd.AddCommand(newNodeOpCmd(
+		"restart",
+		"Restart a node of the local network",
+		`The network node restart command stops the given node and starts it again, keeping its
+database and configuration.`,
+		restartNode,
+	))
+	// network node pause
+	cmd.AddCommand(newNodeOpCmd(
+		"pause",
+		"Pause a node of the local network",
+		`The network node pause command stops the process of the given node, keeping it part of
+the local network so it can be resumed later.`,
+		pauseNode,
+	))
+	// network node resume
+	cmd.AddCommand(newNodeOpCmd(
+		"resume",
+		"Resume a paused node of the local network",
+		`The network node resume command starts again a node paused with network node pause.`,
+		resumeNode,
+	))
+	return cmd
+}
+
+type nodeOp func(ctx context.Context, cli client.Client, clusterInfo *rpcpb.ClusterInfo, nodeName string) error
+
+func newNodeOpCmd(use string, short string, long string, op nodeOp) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [nodeName]",
+		Short: short,
+		Long:  long,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runNodeOp(args[0], op)
+		},
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	if use == "remove" || use == "pause" {
+		cmd.Flags().BoolVarP(&forceNodeOp, "force", "f", false, "allow stopping the node that serves the local API endpoint")
+	}
+	return cmd
+}
+
+func getRunningNetwork(ctx context.Context) (client.Client, *rpcpb.ClusterInfo, error) {
+	cli, err := binutils.NewGRPCClient(binutils.WithDialTimeout(constants.FastGRPCDialTimeout))
+	if err != nil {
+		if errors.Is(err, binutils.ErrGRPCTimeout) {
+			return nil, nil, errNetworkNotRunning
+		}
+		return nil, nil, err
+	}
+	status, err := cli.Status(ctx)
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return nil, nil, errNetworkNotRunning
+		}
+		return nil, nil, err
+	}
+	return cli, status.ClusterInfo, nil
+}
+
+func runNodeOp(nodeName string, op nodeOp) error {
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+	cli, clusterInfo, err := getRunningNetwork(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := clusterInfo.NodeInfos[nodeName]; !ok {
+		return fmt.Errorf("node %s is not part of the local network, available nodes: %s",
+			nodeName, strings.Join(clusterInfo.NodeNames, ", "))
+	}
+	return op(ctx, cli, clusterInfo, nodeName)
+}
+
+// the CLI talks to the local network through the node at constants.LocalAPIEndpoint,
+// so stopping it breaks local deploys and key operations until it is back
+func checkServesLocalAPI(clusterInfo *rpcpb.ClusterInfo, nodeName string) error {
+	if !forceNodeOp && clusterInfo.NodeInfos[nodeName].Uri == constants.LocalAPIEndpoint {
+		return fmt.Errorf("node %s serves the local API endpoint %s used by the CLI, use --force to stop it anyway",
+			nodeName, constants.LocalAPIEndpoint)
+	}
+	return nil
+}
+
+func removeNode(ctx context.Context, cli client.Client, clusterInfo *rpcpb.ClusterInfo, nodeName string) error {
+	if err := checkServesLocalAPI(clusterInfo, nodeName); err != nil {
+		return err
+	}
+	if len(clusterInfo.NodeNames) == 1 {
+		return errors.New("can't remove the last node of the local network, use network clean instead")
+	}
+	if _, err := cli.RemoveNode(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to remove node %s: %w", nodeName, err)
+	}
+	ux.Logger.PrintToUser("Node %s removed", nodeName)
+	return nil
+}
+
+func restartNode(ctx context.Context, cli client.Client, _ *rpcpb.ClusterInfo, nodeName string) error {
+	ux.Logger.PrintToUser("Restarting node %s. Wait until healthy...", nodeName)
+	if _, err := cli.RestartNode(ctx, nodeName, client.WithPluginDir(app.GetPluginsDir())); err != nil {
+		return fmt.Errorf("failed to restart node %s: %w", nodeName, err)
+	}
+	if _, err := subnet.WaitForHealthy(ctx, cli); err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	ux.Logger.PrintToUser("Node %s restarted", nodeName)
+	return nil
+}
+
+func pauseNode(ctx context.Context, cli client.Client, clusterInfo *rpcpb.ClusterInfo, nodeName string) error {
+	if clusterInfo.NodeInfos[nodeName].Paused {
+		return fmt.Errorf("node %s is already paused", nodeName)
+	}
+	if err := checkServesLocalAPI(clusterInfo, nodeName); err != nil {
+		return err
+	}
+	if _, err := cli.PauseNode(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to pause node %s: %w", nodeName, err)
+	}
+	ux.Logger.PrintToUser("Node %s paused. Resume it with network node resume %s", nodeName, nodeName)
+	return nil
+}
+
+func resumeNode(ctx context.Context, cli client.Client, clusterInfo *rpcpb.ClusterInfo, nodeName string) error {
+	if !clusterInfo.NodeInfos[nodeName].Paused {
+		return fmt.Errorf("node %s is not paused", nodeName)
+	}
+	ux.Logger.PrintToUser("Resuming node %s. Wait until healthy...", nodeName)
+	if _, err := cli.ResumeNode(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to resume node %s: %w", nodeName, err)
+	}
+	if _, err := subnet.WaitForHealthy(ctx, cli); err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	ux.Logger.PrintToUser("Node %s resumed", nodeName)
+	return nil
+}
+
+// lux network node add
+func newNodeAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [nodeName]",
+		Short: "Add a new node to the local network",
+		Long: `The network node add command adds a new, non validator, node to the local network.
+
+The node runs the same luxd binary as the other nodes, so the RPC version of the
+deployed Subnets stays valid, and it tracks all the Subnets of the network. Additional
+luxd flags can be given in a JSON file with --node-config.`,
+		RunE:         addNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&addNodeConfigPath, "node-config", "", "JSON file with luxd flags for the new node")
+	return cmd
+}
+
+func addNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+	cli, clusterInfo, err := getRunningNetwork(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := clusterInfo.NodeInfos[nodeName]; ok {
+		return fmt.Errorf("node %s is already part of the local network", nodeName)
+	}
+	if len(clusterInfo.NodeNames) == 0 {
+		return errors.New("the local network has no nodes")
+	}
+	execPath := clusterInfo.NodeInfos[clusterInfo.NodeNames[0]].ExecPath
+
+	configStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return err
+	}
+	nodeConfig := map[string]interface{}{}
+	if addNodeConfigPath != "" {
+		nodeConfig, err = subnet.LoadNodeConfigFile(addNodeConfigPath)
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := nodeConfig[trackSubnetsKey]; !ok {
+		if trackedSubnets := getTrackedSubnets(clusterInfo); trackedSubnets != "" {
+			nodeConfig[trackSubnetsKey] = trackedSubnets
+		}
+	}
+	configStr, err = subnet.MergeNodeConfigs(configStr, nodeConfig)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Adding node %s. Wait until healthy...", nodeName)
+	opts := []client.OpOption{client.WithPluginDir(app.GetPluginsDir())}
+	if configStr != "" {
+		opts = append(opts, client.WithGlobalNodeConfig(configStr))
+	}
+	if _, err := cli.AddNode(ctx, nodeName, execPath, opts...); err != nil {
+		return fmt.Errorf("failed to add node %s: %w", nodeName, err)
+	}
+	clusterInfo, err = subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+	if !ok {
+		return fmt.Errorf("node %s not found after being added", nodeName)
+	}
+	ux.Logger.PrintToUser("Node %s added with ID %s and endpoint %s", nodeName, nodeInfo.Id, nodeInfo.Uri)
+	return nil
+}
+
+// returns the subnets of the network, in luxd track-subnets format
+func getTrackedSubnets(clusterInfo *rpcpb.ClusterInfo) string {
+	subnetIDs := maps.Keys(clusterInfo.Subnets)
+	sort.Strings(subnetIDs)
+	return strings.Join(subnetIDs, ",")
+}
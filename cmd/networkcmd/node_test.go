@@ -0,0 +1,60 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package networkcmd
+
+import (
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClusterInfo() *rpcpb.ClusterInfo {
+	return &rpcpb.ClusterInfo{
+		NodeNames: []string{"node1", "node2", "node3"},
+		NodeInfos: map[string]*rpcpb.NodeInfo{
+			"node1": {Name: "node1", Uri: constants.LocalAPIEndpoint},
+			"node2": {Name: "node2", Uri: "http://127.0.0.1:9652", Paused: true},
+			"node3": {Name: "node3", Uri: "http://127.0.0.1:9654"},
+		},
+		CustomChains: map[string]*rpcpb.CustomChainInfo{
+			"chainID": {ChainName: "test", SubnetId: "subnet2"},
+		},
+		Subnets: map[string]*rpcpb.SubnetInfo{
+			"subnet2": {},
+			"subnet1": {},
+		},
+	}
+}
+
+func TestNetworkStatusPausedNodes(t *testing.T) {
+	require := require.New(t)
+
+	clusterInfo := newTestClusterInfo()
+	require.Equal([]string{"node2"}, getPausedNodes(clusterInfo))
+
+	doc := newNetworkStatusDocument(clusterInfo)
+	require.Len(doc.Nodes, 3)
+	require.True(doc.Nodes[1].Paused)
+	require.Len(doc.CustomChains, 1)
+	require.Equal([]string{
+		constants.LocalAPIEndpoint + "/ext/bc/chainID/rpc",
+		"http://127.0.0.1:9654/ext/bc/chainID/rpc",
+	}, doc.CustomChains[0].Endpoints)
+}
+
+func TestNodeOpChecks(t *testing.T) {
+	require := require.New(t)
+
+	clusterInfo := newTestClusterInfo()
+	require.Equal("subnet1,subnet2", getTrackedSubnets(clusterInfo))
+
+	forceNodeOp = false
+	require.Error(checkServesLocalAPI(clusterInfo, "node1"))
+	require.NoError(checkServesLocalAPI(clusterInfo, "node3"))
+	forceNodeOp = true
+	defer func() { forceNodeOp = false }()
+	require.NoError(checkServesLocalAPI(clusterInfo, "node1"))
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var forceSnapshot bool
+
+// lux network snapshot
+func newSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage local network snapshots",
+		Long: `The network snapshot command suite manages the snapshots of the local network.
+
+A snapshot holds the state of all the nodes of the local network, including the deployed
+Subnets. Snapshots can be saved from the running network, loaded into it, and shared
+with other machines as archives, eg to give teammates a network with your Subnets already
+deployed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	// network snapshot save
+	cmd.AddCommand(newSnapshotSaveCmd())
+	// network snapshot load
+	cmd.AddCommand(newSnapshotLoadCmd())
+	// network snapshot list
+	cmd.AddCommand(newSnapshotListCmd())
+	// network snapshot delete
+	cmd.AddCommand(newSnapshotDeleteCmd())
+	// network snapshot export
+	cmd.AddCommand(newSnapshotExportCmd())
+	// network snapshot import
+	cmd.AddCommand(newSnapshotImportCmd())
+	return cmd
+}
+
+// lux network snapshot list
+func newSnapshotListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        "List the saved local network snapshots",
+		Long:         `The network snapshot list command prints the snapshots saved on this machine.`,
+		RunE:         listSnapshots,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func listSnapshots(*cobra.Command, []string) error {
+	snapshots, err := subnet.ListSnapshots(app.GetSnapshotsDir())
+	if err != nil {
+		return err
+	}
+	if app.OutputFormat.IsStructured() {
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "SnapshotList", snapshots)
+	}
+	if len(snapshots) == 0 {
+		ux.Logger.PrintToUser("No snapshots found")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Size", "Modified"})
+	for _, snapshot := range snapshots {
+		name := snapshot.Name
+		if name == constants.DefaultSnapshotName {
+			name += " (default)"
+		}
+		table.Append([]string{
+			name,
+			fmt.Sprintf("%.1f MiB", float64(snapshot.Size)/(1<<20)),
+			snapshot.ModTime.Format(time.RFC3339),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+// lux network snapshot delete
+func newSnapshotDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [snapshotName]",
+		Short: "Delete a saved local network snapshot",
+		Long: `The network snapshot delete command removes a saved snapshot from this machine.
+
+The default snapshot, used by network start and network stop, can only be deleted with
+--force. It is restored to the bootstrap snapshot on the next Subnet deploy.`,
+		RunE:         deleteSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVarP(&forceSnapshot, "force", "f", false, "allow deleting the default snapshot")
+	return cmd
+}
+
+func deleteSnapshot(_ *cobra.Command, args []string) error {
+	name := args[0]
+	if name == constants.DefaultSnapshotName && !forceSnapshot {
+		return fmt.Errorf("%s is the default snapshot, use --force to delete it", name)
+	}
+	if err := subnet.DeleteSnapshot(app.GetSnapshotsDir(), name); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s deleted", name)
+	return nil
+}
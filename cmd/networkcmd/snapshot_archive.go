@@ -0,0 +1,89 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importSnapshotName string
+	importSHA256       string
+)
+
+// lux network snapshot export
+func newSnapshotExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [snapshotName] [archivePath]",
+		Short: "Export a snapshot into an archive that can be loaded on other machines",
+		Long: `The network snapshot export command writes the given snapshot into a tar.gz archive,
+together with the installed VM binaries, so the Subnets deployed in the snapshot can run
+on other machines. The sha256 sum of the archive is printed and written to
+<archivePath>.sha256.
+
+To bake a custom bootstrap snapshot with your Subnets already deployed, deploy them to
+the local network, save it with network snapshot save, and export the saved snapshot.
+Teammates can then run
+  lux network snapshot import <archivePath>
+  lux network start --snapshot-name <snapshotName>`,
+		RunE:         exportSnapshot,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVarP(&forceSnapshot, "force", "f", false, "overwrite the archive if it exists")
+	return cmd
+}
+
+func exportSnapshot(_ *cobra.Command, args []string) error {
+	name := args[0]
+	archivePath := args[1]
+	sum, err := subnet.ExportSnapshot(app.GetSnapshotsDir(), app.GetPluginsDir(), name, archivePath, forceSnapshot)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s exported to %s", name, archivePath)
+	ux.Logger.PrintToUser("sha256: %s", sum)
+	return nil
+}
+
+// lux network snapshot import
+func newSnapshotImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [archivePath]",
+		Short: "Import a snapshot archive exported with network snapshot export",
+		Long: `The network snapshot import command installs a snapshot archive created by network
+snapshot export, together with its VM binaries. Already installed VM binaries are kept,
+unless --force is given.
+
+The archive is checked against the sha256 given with --sha256, or else against the
+<archivePath>.sha256 file if present. The snapshot keeps its original name, unless
+--snapshot-name is given. Load it with network start --snapshot-name <snapshotName>.`,
+		RunE:         importSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&importSnapshotName, "snapshot-name", "", "name to import the snapshot as")
+	cmd.Flags().StringVar(&importSHA256, "sha256", "", "expected sha256 sum of the archive")
+	cmd.Flags().BoolVarP(&forceSnapshot, "force", "f", false, "overwrite the snapshot and VM binaries if they exist")
+	return cmd
+}
+
+func importSnapshot(_ *cobra.Command, args []string) error {
+	archivePath := args[0]
+	name, sum, err := subnet.ImportSnapshot(
+		app.GetSnapshotsDir(),
+		app.GetPluginsDir(),
+		archivePath,
+		importSnapshotName,
+		importSHA256,
+		forceSnapshot,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s imported from %s (sha256 %s)", name, archivePath, sum)
+	ux.Logger.PrintToUser("Start the network from it with network start --snapshot-name %s", name)
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/luxdefi/cli/pkg/binutils"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/client"
+	"github.com/luxdefi/netrunner/server"
+	anrutils "github.com/luxdefi/netrunner/utils"
+	"github.com/spf13/cobra"
+)
+
+// suffix of the name a snapshot is saved under while it replaces an existing one
+const savingSnapshotSuffix = "-saving"
+
+var stopAfterSave bool
+
+// lux network snapshot save
+func newSnapshotSaveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save [snapshotName]",
+		Short: "Save the state of the running local network into a snapshot",
+		Long: `The network snapshot save command saves the state of the running local network into
+the given snapshot.
+
+Saving a snapshot requires stopping the nodes, so the network is restarted from the new
+snapshot afterwards, unless --stop is given.`,
+		RunE:         saveSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVarP(&forceSnapshot, "force", "f", false, "overwrite the snapshot if it exists")
+	cmd.Flags().BoolVar(&stopAfterSave, "stop", false, "leave the network stopped after saving")
+	return cmd
+}
+
+func saveSnapshot(_ *cobra.Command, args []string) error {
+	name := args[0]
+	if err := subnet.ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	if subnet.SnapshotExists(app.GetSnapshotsDir(), name) && !forceSnapshot {
+		return fmt.Errorf("snapshot %s already exists, use --force to overwrite it", name)
+	}
+
+	cli, err := binutils.NewGRPCClient(
+		binutils.WithAvoidRPCVersionCheck(true),
+		binutils.WithDialTimeout(constants.FastGRPCDialTimeout),
+	)
+	if err != nil {
+		if errors.Is(err, binutils.ErrGRPCTimeout) {
+			return errNetworkNotRunning
+		}
+		return err
+	}
+
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+
+	// an existing snapshot is only replaced once the new one is saved
+	saveName := name
+	if subnet.SnapshotExists(app.GetSnapshotsDir(), name) {
+		saveName = name + savingSnapshotSuffix
+		if subnet.SnapshotExists(app.GetSnapshotsDir(), saveName) {
+			if err := subnet.DeleteSnapshot(app.GetSnapshotsDir(), saveName); err != nil {
+				return fmt.Errorf("failed removing snapshot %s left by a previous save: %w", saveName, err)
+			}
+		}
+	}
+
+	ux.Logger.PrintToUser("Saving snapshot %s...", name)
+	if _, err := cli.SaveSnapshot(ctx, saveName); err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return errNetworkNotRunning
+		}
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	if saveName != name {
+		if err := subnet.ReplaceSnapshot(app.GetSnapshotsDir(), saveName, name); err != nil {
+			return fmt.Errorf("failed replacing snapshot %s, the new state is saved as snapshot %s: %w", name, saveName, err)
+		}
+	}
+	ux.Logger.PrintToUser("Snapshot %s saved.", name)
+
+	if stopAfterSave {
+		ux.Logger.PrintToUser("Network stopped. Restart it with network start --snapshot-name %s", name)
+		return nil
+	}
+	return restartFromSnapshot(ctx, cli, name)
+}
+
+// restarts the network stopped by a snapshot save, from the saved snapshot
+func restartFromSnapshot(ctx context.Context, cli client.Client, name string) error {
+	outputDir, err := anrutils.MkDirWithTimestamp(path.Join(app.GetRunDir(), "network"))
+	if err != nil {
+		return err
+	}
+	loadSnapshotOpts := []client.OpOption{
+		client.WithRootDataDir(outputDir),
+		client.WithReassignPortsIfUsed(true),
+		client.WithPluginDir(app.GetPluginsDir()),
+	}
+	configStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return err
+	}
+	if configStr != "" {
+		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
+	}
+
+	ux.Logger.PrintToUser("Restarting Network. Wait until healthy...")
+	if _, err := cli.LoadSnapshot(ctx, name, loadSnapshotOpts...); err != nil {
+		return fmt.Errorf("failed to restart network from snapshot %s: %w", name, err)
+	}
+	clusterInfo, err := subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	ux.Logger.PrintToUser("Network ready to use.")
+	if subnet.HasEndpoints(clusterInfo) {
+		fmt.Println()
+		ux.Logger.PrintToUser("Local network node endpoints:")
+		ux.PrintTableEndpoints(clusterInfo)
+	}
+	return nil
+}
+
+// lux network snapshot load
+func newSnapshotLoadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load [snapshotName]",
+		Short: "Start the local network from a saved snapshot",
+		Long: `The network snapshot load command starts the local network from the given snapshot.
+It is the same as network start --snapshot-name [snapshotName].
+
+The command fails if the local network is already running. Stop it first with
+network stop, which saves its state into the default snapshot.`,
+		RunE:         loadSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&userProvidedLuxdVersion, "node-version", latest, "use this version of node (ex: v1.17.12)")
+	return cmd
+}
+
+func loadSnapshot(cmd *cobra.Command, args []string) error {
+	if err := subnet.ValidateSnapshotName(args[0]); err != nil {
+		return err
+	}
+	if !subnet.SnapshotExists(app.GetSnapshotsDir(), args[0]) {
+		return fmt.Errorf("%w: %s", subnet.ErrSnapshotNotFound, args[0])
+	}
+	snapshotName = args[0]
+	numNodes = 0
+	perNodeConfigPath = ""
+	return StartNetwork(cmd, nil)
+}
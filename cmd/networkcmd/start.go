@@ -4,6 +4,7 @@ package networkcmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
 
@@ -22,7 +23,10 @@ import (
 
 var (
 	userProvidedLuxdVersion string
-	snapshotName             string
+	snapshotName            string
+	numNodes                uint32
+	nodeConfigPath          string
+	perNodeConfigPath       string
 )
 
 const latest = "latest"
@@ -35,7 +39,15 @@ func newStartCmd() *cobra.Command {
 
 By default, the command loads the default snapshot. If you provide the --snapshot-name
 flag, the network loads that snapshot instead. The command fails if the local network is
-already running.`,
+already running.
+
+To test subnets with a different number of validators, --num-nodes starts a fresh network
+with the given number of nodes, named node1 to node<n>, instead of loading a snapshot.
+Stopping it saves the new topology into the snapshot, as usual.
+
+--node-config takes a JSON file with luxd flags for all nodes, eg staking or network
+settings, which are applied over the CLI node config. --per-node-config takes a JSON file
+mapping node names to the luxd flags applied only to that node, and requires --num-nodes.`,
 
 		RunE:         StartNetwork,
 		Args:         cobra.ExactArgs(0),
@@ -44,11 +56,32 @@ already running.`,
 
 	cmd.Flags().StringVar(&userProvidedLuxdVersion, "node-version", latest, "use this version of node (ex: v1.17.12)")
 	cmd.Flags().StringVar(&snapshotName, "snapshot-name", constants.DefaultSnapshotName, "name of snapshot to use to start the network from")
+	cmd.Flags().Uint32Var(&numNodes, "num-nodes", 0, "start a fresh network with this number of nodes, instead of loading a snapshot")
+	cmd.Flags().StringVar(&nodeConfigPath, "node-config", "", "JSON file with luxd flags to apply to all nodes")
+	cmd.Flags().StringVar(&perNodeConfigPath, "per-node-config", "", "JSON file with luxd flags to apply to specific nodes, eg {\"node1\": {...}}")
 
 	return cmd
 }
 
+// CallStart starts the local network as network start does, from [snapshotNameParam],
+// or fresh with [numNodesParam] nodes if it is not zero
+func CallStart(luxdVersion string, snapshotNameParam string, numNodesParam uint32, nodeConfigPathParam string) error {
+	userProvidedLuxdVersion = luxdVersion
+	snapshotName = snapshotNameParam
+	numNodes = numNodesParam
+	nodeConfigPath = nodeConfigPathParam
+	perNodeConfigPath = ""
+	return StartNetwork(nil, nil)
+}
+
 func StartNetwork(*cobra.Command, []string) error {
+	if numNodes > 0 && snapshotName != constants.DefaultSnapshotName {
+		return errors.New("--num-nodes and --snapshot-name are mutually exclusive")
+	}
+	if perNodeConfigPath != "" && numNodes == 0 {
+		return errors.New("--per-node-config requires --num-nodes")
+	}
+
 	luxdVersion, err := determineLuxdVersion(userProvidedLuxdVersion)
 	if err != nil {
 		return err
@@ -83,13 +116,21 @@ func StartNetwork(*cobra.Command, []string) error {
 		return nil
 	}
 
-	var startMsg string
-	if snapshotName == constants.DefaultSnapshotName {
-		startMsg = "Starting previously deployed and stopped snapshot"
-	} else {
-		startMsg = fmt.Sprintf("Starting previously deployed and stopped snapshot %s...", snapshotName)
+	// load global node configs if they exist
+	configStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return err
+	}
+	if nodeConfigPath != "" {
+		nodeConfig, err := subnet.LoadNodeConfigFile(nodeConfigPath)
+		if err != nil {
+			return err
+		}
+		configStr, err = subnet.MergeNodeConfigs(configStr, nodeConfig)
+		if err != nil {
+			return err
+		}
 	}
-	ux.Logger.PrintToUser(startMsg)
 
 	outputDirPrefix := path.Join(app.GetRunDir(), "network")
 	outputDir, err := anrutils.MkDirWithTimestamp(outputDirPrefix)
@@ -97,6 +138,35 @@ func StartNetwork(*cobra.Command, []string) error {
 		return err
 	}
 
+	if numNodes > 0 {
+		customNodeConfigs := map[string]string{}
+		if perNodeConfigPath != "" {
+			customNodeConfigs, err = subnet.LoadPerNodeConfigFile(perNodeConfigPath, numNodes)
+			if err != nil {
+				return err
+			}
+		}
+		clusterInfo, err := sd.StartNewNetwork(ctx, cli, luxdBinPath, outputDir, numNodes, configStr, customNodeConfigs)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		ux.Logger.PrintToUser("Local network nodes:")
+		for _, nodeName := range clusterInfo.NodeNames {
+			nodeInfo := clusterInfo.NodeInfos[nodeName]
+			ux.Logger.PrintToUser("  %s: %s %s", nodeName, nodeInfo.Id, nodeInfo.Uri)
+		}
+		return nil
+	}
+
+	var startMsg string
+	if snapshotName == constants.DefaultSnapshotName {
+		startMsg = "Starting previously deployed and stopped snapshot"
+	} else {
+		startMsg = fmt.Sprintf("Starting previously deployed and stopped snapshot %s...", snapshotName)
+	}
+	ux.Logger.PrintToUser(startMsg)
+
 	pluginDir := app.GetPluginsDir()
 
 	loadSnapshotOpts := []client.OpOption{
@@ -106,11 +176,6 @@ func StartNetwork(*cobra.Command, []string) error {
 		client.WithPluginDir(pluginDir),
 	}
 
-	// load global node configs if they exist
-	configStr, err := app.Conf.LoadNodeConfig()
-	if err != nil {
-		return err
-	}
 	if configStr != "" {
 		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
 	}
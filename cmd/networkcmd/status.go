@@ -3,27 +3,128 @@
 package networkcmd
 
 import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/luxdefi/cli/pkg/binutils"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/rpcpb"
 	"github.com/luxdefi/netrunner/server"
 	"github.com/spf13/cobra"
 )
 
+var (
+	watchStatus       bool
+	watchInterval     time.Duration
+	watchMaxLag       uint64
+	watchStallTimeout time.Duration
+)
+
 func newStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Prints the status of the local network",
 		Long: `The network status command prints whether or not a local Lux
-network is running and some basic stats about the network.`,
+network is running and some basic stats about the network.
+
+With --watch, the command refreshes a dashboard until interrupted. It shows the health,
+bootstrapped state, peer count and uptime of each node, and the latest block height,
+block time and pending tx count of each custom chain on each node. Nodes more than
+--max-lag blocks behind the chain are flagged, as well as chains that didn't produce
+blocks for --stall-timeout while having pending txs.`,
 
 		RunE:         networkStatus,
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
 	}
+	cmd.Flags().BoolVarP(&watchStatus, "watch", "w", false, "keep refreshing the network status")
+	cmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "refresh interval of --watch")
+	cmd.Flags().Uint64Var(&watchMaxLag, "max-lag", 2, "number of blocks a node can be behind a chain before being flagged")
+	cmd.Flags().DurationVar(&watchStallTimeout, "stall-timeout", 30*time.Second, "time without new blocks, with pending txs, to flag a chain as stalled")
+	return cmd
+}
+
+// networkStatusDocument is the structured (json/yaml) output of network status
+type networkStatusDocument struct {
+	Running             bool                `json:"running"`
+	Healthy             bool                `json:"healthy"`
+	CustomChainsHealthy bool                `json:"customChainsHealthy"`
+	NetworkID           uint32              `json:"networkID,omitempty"`
+	Nodes               []networkNodeEntry  `json:"nodes"`
+	CustomChains        []networkChainEntry `json:"customChains"`
+}
+
+type networkNodeEntry struct {
+	Name   string `json:"name"`
+	NodeID string `json:"nodeID"`
+	URI    string `json:"uri"`
+	Paused bool   `json:"paused"`
+}
+
+type networkChainEntry struct {
+	BlockchainID string   `json:"blockchainID"`
+	ChainName    string   `json:"chainName"`
+	VMID         string   `json:"vmID"`
+	SubnetID     string   `json:"subnetID"`
+	Endpoints    []string `json:"endpoints"`
+}
+
+func newNetworkStatusDocument(clusterInfo *rpcpb.ClusterInfo) networkStatusDocument {
+	doc := networkStatusDocument{
+		Nodes:        []networkNodeEntry{},
+		CustomChains: []networkChainEntry{},
+	}
+	if clusterInfo == nil {
+		return doc
+	}
+	doc.Running = true
+	doc.Healthy = clusterInfo.Healthy
+	doc.CustomChainsHealthy = clusterInfo.CustomChainsHealthy
+	doc.NetworkID = clusterInfo.NetworkId
+	for _, nodeName := range clusterInfo.NodeNames {
+		nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+		if !ok {
+			continue
+		}
+		doc.Nodes = append(doc.Nodes, networkNodeEntry{
+			Name:   nodeInfo.Name,
+			NodeID: nodeInfo.Id,
+			URI:    nodeInfo.Uri,
+			Paused: nodeInfo.Paused,
+		})
+	}
+	for blockchainID, chainInfo := range clusterInfo.CustomChains {
+		chain := networkChainEntry{
+			BlockchainID: blockchainID,
+			ChainName:    chainInfo.ChainName,
+			VMID:         chainInfo.VmId,
+			SubnetID:     chainInfo.SubnetId,
+			Endpoints:    []string{},
+		}
+		for _, node := range doc.Nodes {
+			// paused nodes don't serve requests
+			if node.Paused {
+				continue
+			}
+			chain.Endpoints = append(chain.Endpoints, fmt.Sprintf("%s/ext/bc/%s/rpc", node.URI, blockchainID))
+		}
+		doc.CustomChains = append(doc.CustomChains, chain)
+	}
+	sort.Slice(doc.CustomChains, func(i, j int) bool {
+		return doc.CustomChains[i].ChainName < doc.CustomChains[j].ChainName
+	})
+	return doc
 }
 
 func networkStatus(*cobra.Command, []string) error {
+	if watchStatus && watchInterval <= 0 {
+		return fmt.Errorf("invalid --interval %s", watchInterval)
+	}
+
 	ux.Logger.PrintToUser("Requesting network status...")
 
 	cli, err := binutils.NewGRPCClient()
@@ -36,12 +137,27 @@ func networkStatus(*cobra.Command, []string) error {
 	status, err := cli.Status(ctx)
 	if err != nil {
 		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			if app.OutputFormat.IsStructured() {
+				return ux.PrintDocument(os.Stdout, app.OutputFormat, "NetworkStatus", newNetworkStatusDocument(nil))
+			}
 			ux.Logger.PrintToUser("No local network running")
 			return nil
 		}
 		return err
 	}
 
+	if watchStatus {
+		return watchNetworkStatus(cli)
+	}
+
+	if app.OutputFormat.IsStructured() {
+		var clusterInfo *rpcpb.ClusterInfo
+		if status != nil {
+			clusterInfo = status.ClusterInfo
+		}
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "NetworkStatus", newNetworkStatusDocument(clusterInfo))
+	}
+
 	// TODO: This layout may break some screens, is there a "failsafe" way?
 	if status != nil && status.ClusterInfo != nil {
 		ux.Logger.PrintToUser("Network is Up. Network information:")
@@ -49,13 +165,23 @@ func networkStatus(*cobra.Command, []string) error {
 		ux.Logger.PrintToUser("Healthy: %t", status.ClusterInfo.Healthy)
 		ux.Logger.PrintToUser("Custom VMs healthy: %t", status.ClusterInfo.CustomChainsHealthy)
 		ux.Logger.PrintToUser("Number of nodes: %d", len(status.ClusterInfo.NodeNames))
+		if pausedNodes := getPausedNodes(status.ClusterInfo); len(pausedNodes) > 0 {
+			ux.Logger.PrintToUser("Paused nodes: %s", strings.Join(pausedNodes, ", "))
+		}
 		ux.Logger.PrintToUser("Number of custom VMs: %d", len(status.ClusterInfo.CustomChains))
 		ux.Logger.PrintToUser("======================================== Node information ========================================")
 		for n, nodeInfo := range status.ClusterInfo.NodeInfos {
+			if nodeInfo.Paused {
+				ux.Logger.PrintToUser("%s has ID %s and endpoint %s (paused)", n, nodeInfo.Id, nodeInfo.Uri)
+				continue
+			}
 			ux.Logger.PrintToUser("%s has ID %s and endpoint %s ", n, nodeInfo.Id, nodeInfo.Uri)
 		}
 		ux.Logger.PrintToUser("==================================== Custom VM information =======================================")
 		for _, nodeInfo := range status.ClusterInfo.NodeInfos {
+			if nodeInfo.Paused {
+				continue
+			}
 			for blockchainID := range status.ClusterInfo.CustomChains {
 				ux.Logger.PrintToUser("Endpoint at %s for blockchain %q: %s/ext/bc/%s/rpc", nodeInfo.Name, blockchainID, nodeInfo.GetUri(), blockchainID)
 			}
@@ -69,3 +195,15 @@ func networkStatus(*cobra.Command, []string) error {
 
 	return nil
 }
+
+// returns the names of the paused nodes, sorted
+func getPausedNodes(clusterInfo *rpcpb.ClusterInfo) []string {
+	pausedNodes := []string{}
+	for _, nodeName := range clusterInfo.NodeNames {
+		if nodeInfo, ok := clusterInfo.NodeInfos[nodeName]; ok && nodeInfo.Paused {
+			pausedNodes = append(pausedNodes, nodeName)
+		}
+	}
+	sort.Strings(pausedNodes)
+	return pausedNodes
+}
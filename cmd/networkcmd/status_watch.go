@@ -0,0 +1,315 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/client"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/luxdefi/node/api/health"
+	"github.com/luxdefi/node/api/info"
+	"github.com/luxdefi/node/ids"
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	// clears the terminal and moves the cursor to its top left corner
+	clearScreen  = "\033[H\033[2J"
+	pausedStatus = "paused"
+)
+
+var primaryChainsToBootstrap = []string{"P", "X", "C"}
+
+type nodeWatchStats struct {
+	Name         string  `json:"name"`
+	NodeID       string  `json:"nodeID"`
+	Paused       bool    `json:"paused"`
+	Healthy      bool    `json:"healthy"`
+	Bootstrapped bool    `json:"bootstrapped"`
+	Peers        int     `json:"peers"`
+	Uptime       float64 `json:"uptime"`
+	Error        string  `json:"error,omitempty"`
+}
+
+type chainNodeWatchStats struct {
+	Node      string    `json:"node"`
+	Height    uint64    `json:"height"`
+	BlockTime time.Time `json:"blockTime"`
+	Pending   uint64    `json:"pending"`
+	Behind    bool      `json:"behind"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type chainWatchStats struct {
+	ChainName    string                `json:"chainName"`
+	BlockchainID string                `json:"blockchainID"`
+	Height       uint64                `json:"height"`
+	Stalled      bool                  `json:"stalled"`
+	Nodes        []chainNodeWatchStats `json:"nodes"`
+}
+
+// networkWatchDocument is the structured (json/yaml) output of each network status --watch refresh
+type networkWatchDocument struct {
+	Time   time.Time         `json:"time"`
+	Nodes  []nodeWatchStats  `json:"nodes"`
+	Chains []chainWatchStats `json:"chains"`
+}
+
+// keeps the last height change of each chain, to detect halts
+type chainProgress struct {
+	height    uint64
+	changedAt time.Time
+}
+
+func watchNetworkStatus(cli client.Client) error {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	progress := map[string]*chainProgress{}
+	for {
+		doc, err := collectNetworkWatchStats(cli, progress, time.Now())
+		if err != nil {
+			return err
+		}
+		if app.OutputFormat.IsStructured() {
+			if err := ux.PrintDocument(os.Stdout, app.OutputFormat, "NetworkStatusWatch", doc); err != nil {
+				return err
+			}
+		} else {
+			printNetworkWatchStats(doc)
+		}
+		select {
+		case <-sigc:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func collectNetworkWatchStats(cli client.Client, progress map[string]*chainProgress, now time.Time) (networkWatchDocument, error) {
+	doc := networkWatchDocument{
+		Time:   now,
+		Nodes:  []nodeWatchStats{},
+		Chains: []chainWatchStats{},
+	}
+	ctx, cancel := utils.GetAPIContext()
+	status, err := cli.Status(ctx)
+	cancel()
+	if err != nil {
+		return doc, err
+	}
+	clusterInfo := status.ClusterInfo
+
+	nodeInfos := []*rpcpb.NodeInfo{}
+	for _, nodeName := range clusterInfo.NodeNames {
+		if nodeInfo, ok := clusterInfo.NodeInfos[nodeName]; ok {
+			nodeInfos = append(nodeInfos, nodeInfo)
+		}
+	}
+	blockchainIDs := []string{}
+	for blockchainID := range clusterInfo.CustomChains {
+		blockchainIDs = append(blockchainIDs, blockchainID)
+	}
+	sort.Slice(blockchainIDs, func(i, j int) bool {
+		return clusterInfo.CustomChains[blockchainIDs[i]].ChainName < clusterInfo.CustomChains[blockchainIDs[j]].ChainName
+	})
+
+	doc.Nodes = make([]nodeWatchStats, len(nodeInfos))
+	for _, blockchainID := range blockchainIDs {
+		doc.Chains = append(doc.Chains, chainWatchStats{
+			ChainName:    clusterInfo.CustomChains[blockchainID].ChainName,
+			BlockchainID: blockchainID,
+			Nodes:        make([]chainNodeWatchStats, len(nodeInfos)),
+		})
+	}
+	// all nodes are queried at the same time, so a stuck node doesn't delay the others
+	wg := sync.WaitGroup{}
+	for i, nodeInfo := range nodeInfos {
+		wg.Add(1)
+		go func(i int, nodeInfo *rpcpb.NodeInfo) {
+			defer wg.Done()
+			doc.Nodes[i] = getNodeWatchStats(nodeInfo)
+			for j, blockchainID := range blockchainIDs {
+				doc.Chains[j].Nodes[i] = getChainNodeWatchStats(nodeInfo, blockchainID)
+			}
+		}(i, nodeInfo)
+	}
+	wg.Wait()
+
+	for i := range doc.Chains {
+		updateChainProgress(&doc.Chains[i], progress, now)
+	}
+	return doc, nil
+}
+
+func getNodeWatchStats(nodeInfo *rpcpb.NodeInfo) nodeWatchStats {
+	stats := nodeWatchStats{
+		Name:   nodeInfo.Name,
+		NodeID: nodeInfo.Id,
+		Paused: nodeInfo.Paused,
+	}
+	if nodeInfo.Paused {
+		return stats
+	}
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	healthReply, err := health.NewClient(nodeInfo.Uri).Health(ctx, nil)
+	if err != nil {
+		stats.Error = err.Error()
+		return stats
+	}
+	stats.Healthy = healthReply.Healthy
+	infoClient := info.NewClient(nodeInfo.Uri)
+	stats.Bootstrapped = true
+	for _, chain := range primaryChainsToBootstrap {
+		bootstrapped, err := infoClient.IsBootstrapped(ctx, chain)
+		if err != nil {
+			stats.Error = err.Error()
+			return stats
+		}
+		stats.Bootstrapped = stats.Bootstrapped && bootstrapped
+	}
+	peers, err := infoClient.Peers(ctx)
+	if err != nil {
+		stats.Error = err.Error()
+		return stats
+	}
+	stats.Peers = len(peers)
+	// only validators have uptime
+	if uptime, err := infoClient.Uptime(ctx, ids.Empty); err == nil {
+		stats.Uptime = float64(uptime.WeightedAveragePercentage)
+	}
+	return stats
+}
+
+// queries the EVM RPC of [blockchainID] at the node
+func getChainNodeWatchStats(nodeInfo *rpcpb.NodeInfo, blockchainID string) chainNodeWatchStats {
+	stats := chainNodeWatchStats{Node: nodeInfo.Name}
+	if nodeInfo.Paused {
+		stats.Error = pausedStatus
+		return stats
+	}
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	rpcClient, err := rpc.DialContext(ctx, fmt.Sprintf("%s/ext/bc/%s/rpc", nodeInfo.Uri, blockchainID))
+	if err != nil {
+		stats.Error = err.Error()
+		return stats
+	}
+	defer rpcClient.Close()
+	var block struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", "latest", false); err != nil {
+		stats.Error = err.Error()
+		return stats
+	}
+	stats.Height = uint64(block.Number)
+	stats.BlockTime = time.Unix(int64(block.Timestamp), 0)
+	var txPool struct {
+		Pending hexutil.Uint64 `json:"pending"`
+	}
+	// the txpool API may not be enabled
+	if err := rpcClient.CallContext(ctx, &txPool, "txpool_status"); err == nil {
+		stats.Pending = uint64(txPool.Pending)
+	}
+	return stats
+}
+
+// marks the nodes that fall behind the chain height, and the chain as stalled
+// if its height didn't change for a while with pending txs
+func updateChainProgress(chain *chainWatchStats, progress map[string]*chainProgress, now time.Time) {
+	pending := false
+	for _, nodeStats := range chain.Nodes {
+		if nodeStats.Error == "" && nodeStats.Height > chain.Height {
+			chain.Height = nodeStats.Height
+		}
+		pending = pending || nodeStats.Pending > 0
+	}
+	for i := range chain.Nodes {
+		nodeStats := &chain.Nodes[i]
+		nodeStats.Behind = nodeStats.Error == "" && chain.Height-nodeStats.Height > watchMaxLag
+	}
+	last, ok := progress[chain.BlockchainID]
+	if !ok || last.height != chain.Height {
+		progress[chain.BlockchainID] = &chainProgress{height: chain.Height, changedAt: now}
+		return
+	}
+	chain.Stalled = pending && now.Sub(last.changedAt) >= watchStallTimeout
+}
+
+func printNetworkWatchStats(doc networkWatchDocument) {
+	fmt.Print(clearScreen)
+	ux.Logger.PrintToUser("Local network status at %s (Ctrl+C to exit)", doc.Time.Format(time.RFC3339))
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Node", "Node ID", "Healthy", "Bootstrapped", "Peers", "Uptime"})
+	for _, node := range doc.Nodes {
+		switch {
+		case node.Paused:
+			table.Append([]string{node.Name, node.NodeID, pausedStatus, "", "", ""})
+		case node.Error != "":
+			table.Append([]string{node.Name, node.NodeID, "unreachable", "", "", ""})
+		default:
+			table.Append([]string{
+				node.Name,
+				node.NodeID,
+				strconv.FormatBool(node.Healthy),
+				strconv.FormatBool(node.Bootstrapped),
+				strconv.Itoa(node.Peers),
+				fmt.Sprintf("%.1f%%", node.Uptime),
+			})
+		}
+	}
+	table.Render()
+
+	for _, chain := range doc.Chains {
+		fmt.Println()
+		title := fmt.Sprintf("Chain %s (%s) height %d", chain.ChainName, chain.BlockchainID, chain.Height)
+		if chain.Stalled {
+			title += fmt.Sprintf(" - STALLED for more than %s with pending txs", watchStallTimeout)
+		}
+		ux.Logger.PrintToUser(title)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Node", "Height", "Block Time", "Pending Txs", "Status"})
+		for _, nodeStats := range chain.Nodes {
+			if nodeStats.Error != "" {
+				status := "unreachable"
+				if nodeStats.Error == pausedStatus {
+					status = pausedStatus
+				}
+				table.Append([]string{nodeStats.Node, "", "", "", status})
+				continue
+			}
+			status := "ok"
+			if nodeStats.Behind {
+				status = fmt.Sprintf("BEHIND by %d blocks", chain.Height-nodeStats.Height)
+			}
+			table.Append([]string{
+				nodeStats.Node,
+				strconv.FormatUint(nodeStats.Height, 10),
+				nodeStats.BlockTime.Format(time.TimeOnly),
+				strconv.FormatUint(nodeStats.Pending, 10),
+				status,
+			})
+		}
+		table.Render()
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package networkcmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateChainProgress(t *testing.T) {
+	require := require.New(t)
+
+	watchMaxLag = 2
+	watchStallTimeout = 30 * time.Second
+	progress := map[string]*chainProgress{}
+	now := time.Now()
+
+	newChain := func(heights ...uint64) *chainWatchStats {
+		chain := &chainWatchStats{BlockchainID: "chainID"}
+		for _, height := range heights {
+			chain.Nodes = append(chain.Nodes, chainNodeWatchStats{Height: height, Pending: 1})
+		}
+		chain.Nodes = append(chain.Nodes, chainNodeWatchStats{Error: pausedStatus})
+		return chain
+	}
+
+	chain := newChain(10, 9, 7)
+	updateChainProgress(chain, progress, now)
+	require.Equal(uint64(10), chain.Height)
+	require.False(chain.Nodes[0].Behind)
+	require.False(chain.Nodes[1].Behind)
+	require.True(chain.Nodes[2].Behind)
+	require.False(chain.Nodes[3].Behind)
+	require.False(chain.Stalled)
+
+	// same height with pending txs, before and after the stall timeout
+	chain = newChain(10, 10, 10)
+	updateChainProgress(chain, progress, now.Add(10*time.Second))
+	require.False(chain.Stalled)
+	chain = newChain(10, 10, 10)
+	updateChainProgress(chain, progress, now.Add(40*time.Second))
+	require.True(chain.Stalled)
+
+	// new blocks reset the stall detection
+	chain = newChain(11, 11, 11)
+	updateChainProgress(chain, progress, now.Add(50*time.Second))
+	require.False(chain.Stalled)
+}
@@ -36,6 +36,12 @@ default snapshot with network start.`,
 	return cmd
 }
 
+// CallStop stops the local network, saving its state into [snapshotNameParam]
+func CallStop(snapshotNameParam string) error {
+	snapshotName = snapshotNameParam
+	return StopNetwork(nil, nil)
+}
+
 func StopNetwork(*cobra.Command, []string) error {
 	if err := saveNetwork(); errors.Is(err, binutils.ErrGRPCTimeout) {
 		// no server to kill
@@ -31,6 +31,7 @@ import (
 
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
@@ -523,7 +524,7 @@ func getLuxdVersion() (string, error) {
 		case luxdReferenceChoiceCustom:
 			customVersion, err := app.Prompt.CaptureVersion("Which version of Luxd would you like to install? (Use format v1.10.13)")
 			if err != nil {
-				return "", err
+				return "", prompts.WithHint(err, "--latest-node-version or --node-version-from-subnet")
 			}
 			version = customVersion
 		case luxdReferenceChoiceSubnet:
@@ -561,7 +562,7 @@ func promptLuxdReferenceChoice() (string, string, error) {
 	versionOptions := []string{defaultVersion, "Use the deployed Subnet's VM version that the node will be validating", "Custom"}
 	versionOption, err := app.Prompt.CaptureList(txt, versionOptions)
 	if err != nil {
-		return "", "", err
+		return "", "", prompts.WithHint(err, "--latest-node-version or --node-version-from-subnet")
 	}
 
 	switch versionOption {
@@ -573,7 +574,7 @@ func promptLuxdReferenceChoice() (string, string, error) {
 		for {
 			subnetName, err := app.Prompt.CaptureString("Which Subnet would you like to use to choose the lux go version?")
 			if err != nil {
-				return "", "", err
+				return "", "", prompts.WithHint(err, "--node-version-from-subnet")
 			}
 			_, err = subnetcmd.ValidateSubnetNameAndGetChains([]string{subnetName})
 			if err == nil {
@@ -595,7 +596,7 @@ func setCloudService() (string, error) {
 	cloudOptions := []string{constants.AWSCloudService, constants.GCPCloudService}
 	chosenCloudService, err := app.Prompt.CaptureList(txt, cloudOptions)
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--aws or --gcp")
 	}
 	return chosenCloudService, nil
 }
@@ -631,14 +632,14 @@ func setCloudInstanceType(cloudService string) (string, error) {
 		)
 		if err != nil {
 			ux.Logger.PrintToUser("Failed to capture node type with error: %s", err.Error())
-			return "", err
+			return "", prompts.WithHint(err, "--node-type")
 		}
 		nodeTypeStr = strings.ReplaceAll(nodeTypeStr, defaultStr, "") // remove (default) if any
 		if nodeTypeStr == customNodeType {
 			nodeTypeStr, err = app.Prompt.CaptureString("What instance type would you like to use? Please refer to https://docs.lux.network/nodes/run/node-manually#hardware-and-os-requirements for minimum hardware requirements")
 			if err != nil {
 				ux.Logger.PrintToUser("Failed to capture custom node type with error: %s", err.Error())
-				return "", err
+				return "", prompts.WithHint(err, "--node-type")
 			}
 		}
 		return strings.Trim(nodeTypeStr, " "), nil
@@ -703,7 +704,7 @@ func requestCloudAuth(cloudName string) error {
 	ux.Logger.PrintToUser("- Delete Cloud instance(s) and other components (such as elastic IPs) previously created by Lux-CLI")
 	yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("I authorize Lux-CLI to access my %s account", cloudName))
 	if err != nil {
-		return err
+		return prompts.WithHint(err, "--authorize-access")
 	}
 	if err := app.Conf.SetConfigValue(constants.ConfigAutorizeCloudAccessKey, yes); err != nil {
 		return err
@@ -750,17 +751,17 @@ func getRegionsNodeNum(cloudName string) (
 			append(supportedClouds[cloudName].defaultLocations, awsCustomRegion),
 		)
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--region and --num-nodes")
 		}
 		if userRegion == awsCustomRegion {
 			userRegion, err = app.Prompt.CaptureString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName))
 			if err != nil {
-				return nil, err
+				return nil, prompts.WithHint(err, "--region and --num-nodes")
 			}
 		}
 		numNodes, err := app.Prompt.CaptureUint32(fmt.Sprintf("How many nodes do you want to set up in %s %s?", userRegion, supportedClouds[cloudName].locationName))
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--region and --num-nodes")
 		}
 		if numNodes > uint32(math.MaxInt32) {
 			return nil, fmt.Errorf("number of nodes exceeds the range of a signed 32-bit integer")
@@ -771,7 +772,7 @@ func getRegionsNodeNum(cloudName string) (
 		ux.Logger.PrintToUser("Current selection: " + strings.Join(currentInput, " "))
 		yes, err := app.Prompt.CaptureNoYes(additionalRegionPrompt)
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--region and --num-nodes")
 		}
 		if !yes {
 			return nodes, nil
@@ -11,6 +11,7 @@ import (
 
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"golang.org/x/exp/maps"
@@ -42,7 +43,7 @@ func getNewKeyPairName(ec2Svc *ec2.EC2) (string, error) {
 		var err error
 		newKeyPairName, err = app.Prompt.CaptureString("Key Pair Name")
 		if err != nil {
-			return "", err
+			return "", prompts.WithHint(err, "--alternative-key-pair-name")
 		}
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 
 	gcpAPI "github.com/luxdefi/cli/pkg/gcp"
 	"github.com/luxdefi/cli/pkg/terraform"
@@ -43,12 +44,12 @@ func getServiceAccountKeyFilepath() (string, error) {
 		[]string{constants.GCPDefaultAuthKeyPath, customAuthKeyPath},
 	)
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--gcp-credentials")
 	}
 	if credJSONFilePath == customAuthKeyPath {
 		credJSONFilePath, err = app.Prompt.CaptureString("What is the custom filepath to the credentials JSON file?")
 		if err != nil {
-			return "", err
+			return "", prompts.WithHint(err, "--gcp-credentials")
 		}
 	}
 	return utils.GetRealFilePath(credJSONFilePath), err
@@ -78,7 +79,7 @@ func getGCPCloudCredentials() (*compute.Service, string, string, error) {
 		} else {
 			gcpProjectName, err = app.Prompt.CaptureString("What is the name of your Google Cloud project?")
 			if err != nil {
-				return nil, "", "", err
+				return nil, "", "", prompts.WithHint(err, "--gcp-project")
 			}
 		}
 	}
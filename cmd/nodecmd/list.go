@@ -4,6 +4,8 @@ package nodecmd
 
 import (
 	"fmt"
+	"os"
+	"sort"
 
 	"github.com/luxdefi/cli/pkg/ansible"
 	"github.com/luxdefi/cli/pkg/models"
@@ -27,41 +29,84 @@ The node list command lists all clusters together with their nodes.`,
 	return cmd
 }
 
-func list(_ *cobra.Command, _ []string) error {
+// clusterEntry is the structured (json/yaml) output of node list
+type clusterEntry struct {
+	Name    string      `json:"name"`
+	Network string      `json:"network"`
+	Nodes   []nodeEntry `json:"nodes"`
+}
+
+type nodeEntry struct {
+	CloudID string `json:"cloudID"`
+	NodeID  string `json:"nodeID"`
+	IP      string `json:"ip"`
+}
+
+func getClusterEntries() ([]clusterEntry, error) {
 	var err error
 	clustersConfig := models.ClustersConfig{}
 	if app.ClustersConfigExists() {
 		clustersConfig, err = app.LoadClustersConfig()
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	if len(clustersConfig.Clusters) == 0 {
-		ux.Logger.PrintToUser("There are no clusters defined.")
-	}
+	clusters := []clusterEntry{}
 	for clusterName, clusterConf := range clustersConfig.Clusters {
-		ux.Logger.PrintToUser("Cluster %q (%s)", clusterName, clusterConf.Network.Name())
 		if err := checkCluster(clusterName); err != nil {
-			return err
+			return nil, err
+		}
+		cluster := clusterEntry{
+			Name:    clusterName,
+			Network: clusterConf.Network.Name(),
+			Nodes:   []nodeEntry{},
 		}
 		ansibleHostIDs, err := ansible.GetAnsibleHostsFromInventory(app.GetAnsibleInventoryDirPath(clusterName))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ansibleHosts, err := ansible.GetHostMapfromAnsibleInventory(app.GetAnsibleInventoryDirPath(clusterName))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		for _, ansibleHostID := range ansibleHostIDs {
 			_, cloudHostID, err := models.HostAnsibleIDToCloudID(ansibleHostID)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			nodeID, err := getNodeID(app.GetNodeInstanceDirPath(cloudHostID))
 			if err != nil {
-				return err
+				return nil, err
 			}
-			ux.Logger.PrintToUser(fmt.Sprintf("  Node %s (%s) %s", cloudHostID, nodeID.String(), ansibleHosts[ansibleHostID].IP))
+			cluster.Nodes = append(cluster.Nodes, nodeEntry{
+				CloudID: cloudHostID,
+				NodeID:  nodeID.String(),
+				IP:      ansibleHosts[ansibleHostID].IP,
+			})
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters, nil
+}
+
+func list(_ *cobra.Command, _ []string) error {
+	clusters, err := getClusterEntries()
+	if err != nil {
+		return err
+	}
+	if app.OutputFormat.IsStructured() {
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "ClusterList", clusters)
+	}
+	if len(clusters) == 0 {
+		ux.Logger.PrintToUser("There are no clusters defined.")
+	}
+	for _, cluster := range clusters {
+		ux.Logger.PrintToUser("Cluster %q (%s)", cluster.Name, cluster.Network)
+		for _, node := range cluster.Nodes {
+			ux.Logger.PrintToUser(fmt.Sprintf("  Node %s (%s) %s", node.CloudID, node.NodeID, node.IP))
 		}
 	}
 	return nil
@@ -189,6 +189,22 @@ func statusNode(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if app.OutputFormat.IsStructured() {
+		return printStructuredOutput(
+			clustersConfig,
+			hostIDs,
+			ansibleHostIDs,
+			ansibleHosts,
+			nodeIDs,
+			nodeVersionForNode,
+			notHealthyNodes,
+			notBootstrappedNodes,
+			subnetSyncedNodes,
+			subnetValidatingNodes,
+			clusterName,
+			subnetName,
+		)
+	}
 	printOutput(
 		clustersConfig,
 		hostIDs,
@@ -207,6 +223,68 @@ func statusNode(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// clusterStatus is the structured (json/yaml) output of node status
+type clusterStatus struct {
+	Cluster string            `json:"cluster"`
+	Network string            `json:"network"`
+	Subnet  string            `json:"subnet,omitempty"`
+	Nodes   []nodeStatusEntry `json:"nodes"`
+}
+
+type nodeStatusEntry struct {
+	CloudID      string `json:"cloudID"`
+	NodeID       string `json:"nodeID"`
+	IP           string `json:"ip"`
+	LuxdVersion  string `json:"luxdVersion"`
+	Bootstrapped bool   `json:"bootstrapped"`
+	Healthy      bool   `json:"healthy"`
+	// one of NOT_BOOTSTRAPPED, SYNCED or VALIDATING, only set if a subnet was given
+	SubnetStatus string `json:"subnetStatus,omitempty"`
+}
+
+func printStructuredOutput(
+	clustersConfig models.ClustersConfig,
+	hostIDs []string,
+	ansibleHostIDs []string,
+	ansibleHosts map[string]*models.Host,
+	nodeIDs []string,
+	luxdVersions map[string]string,
+	notHealthyHosts []string,
+	notBootstrappedHosts []string,
+	subnetSyncedHosts []string,
+	subnetValidatingHosts []string,
+	clusterName string,
+	subnetName string,
+) error {
+	status := clusterStatus{
+		Cluster: clusterName,
+		Network: clustersConfig.Clusters[clusterName].Network.Name(),
+		Subnet:  subnetName,
+		Nodes:   []nodeStatusEntry{},
+	}
+	for i, ansibleHostID := range ansibleHostIDs {
+		node := nodeStatusEntry{
+			CloudID:      hostIDs[i],
+			NodeID:       nodeIDs[i],
+			IP:           ansibleHosts[ansibleHostID].IP,
+			LuxdVersion:  luxdVersions[ansibleHostID],
+			Bootstrapped: !slices.Contains(notBootstrappedHosts, ansibleHostID),
+			Healthy:      !slices.Contains(notHealthyHosts, ansibleHostID),
+		}
+		if subnetName != "" {
+			node.SubnetStatus = "NOT_BOOTSTRAPPED"
+			if slices.Contains(subnetSyncedHosts, ansibleHostID) {
+				node.SubnetStatus = "SYNCED"
+			}
+			if slices.Contains(subnetValidatingHosts, ansibleHostID) {
+				node.SubnetStatus = "VALIDATING"
+			}
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+	return ux.PrintDocument(os.Stdout, app.OutputFormat, "ClusterStatus", status)
+}
+
 func printOutput(
 	clustersConfig models.ClustersConfig,
 	hostIDs []string,
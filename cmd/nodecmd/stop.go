@@ -18,6 +18,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 
 	"github.com/spf13/cobra"
@@ -76,7 +77,7 @@ func getDeleteConfigConfirmation() error {
 		fmt.Sprintf("Stored files can be found at %s", app.GetNodesDir())
 	yes, err := app.Prompt.CaptureYesNo(confirm)
 	if err != nil {
-		return err
+		return prompts.WithHint(err, "--authorize-remove")
 	}
 	if !yes {
 		return errors.New("abort lux stop node command")
@@ -23,6 +23,7 @@ import (
 	subnetcmd "github.com/luxdefi/cli/cmd/subnetcmd"
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
@@ -112,7 +113,7 @@ func joinAsPrimaryNetworkValidator(
 	if weight == 0 {
 		weight, err = PromptWeightPrimaryNetwork(network)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--stake-amount")
 		}
 	}
 	if weight < minValStake {
@@ -191,6 +192,11 @@ func GetTimeParametersPrimaryNetwork(network models.Network, nodeIndex int, vali
 	if useCustomDuration && validationDuration != 0 {
 		return start, duration, nil
 	}
+	// given with --staking-period, used for every node
+	if validationDuration != 0 && nodeIndex == 0 {
+		useCustomDuration = true
+		return start, validationDuration, nil
+	}
 	if validationDuration != 0 {
 		duration, err = getDefaultValidationTime(start, network, nodeIndex)
 		if err != nil {
@@ -202,7 +208,7 @@ func GetTimeParametersPrimaryNetwork(network models.Network, nodeIndex int, vali
 	durationOptions := []string{defaultDurationOption, custom}
 	durationOption, err := app.Prompt.CaptureList(msg, durationOptions)
 	if err != nil {
-		return time.Time{}, 0, err
+		return time.Time{}, 0, prompts.WithHint(err, "--staking-period")
 	}
 	switch durationOption {
 	case defaultDurationOption:
@@ -241,7 +247,7 @@ func getDefaultValidationTime(start time.Time, network models.Network, nodeIndex
 		confirm := fmt.Sprintf("Your validator will finish staking by %s", end.Format(constants.TimeParseLayout))
 		yes, err := app.Prompt.CaptureYesNo(confirm)
 		if err != nil {
-			return 0, err
+			return 0, prompts.WithHint(err, "--staking-period")
 		}
 		if !yes {
 			return 0, errors.New("you have to confirm staking duration")
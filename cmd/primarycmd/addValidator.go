@@ -37,6 +37,7 @@ var (
 	duration                     time.Duration
 	publicKey                    string
 	pop                          string
+	dryRun                       bool
 	ErrMutuallyExlusiveKeyLedger = errors.New("--key and --ledger,--ledger-addrs are mutually exclusive")
 	ErrStoredKeyOnMainnet        = errors.New("--key is not available for mainnet operations")
 )
@@ -52,7 +53,10 @@ func newAddValidatorCmd() *cobra.Command {
 		Use:   "addValidator",
 		Short: "Add a validator to Primary Network",
 		Long: `The primary addValidator command adds a node as a validator 
-in the Primary Network`,
+in the Primary Network.
+
+Use --dry-run to print the transaction, its fee and the funding addresses
+balances, without issuing it.`,
 		SilenceUsage: true,
 		RunE:         addValidator,
 		Args:         cobra.ExactArgs(0),
@@ -70,6 +74,7 @@ in the Primary Network`,
 	cmd.Flags().StringVar(&publicKey, "public-key", "", "set the BLS public key of the validator to add")
 	cmd.Flags().StringVar(&pop, "proof-of-possession", "", "set the BLS proof of possession of the validator to add")
 	cmd.Flags().Uint32Var(&delegationFee, "delegation-fee", 0, "set the delegation fee (20 000 is equivalent to 2%)")
+	subnetcmd.AddDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -98,14 +103,14 @@ func promptProofOfPossession() (jsonProofOfPossession, error) {
 		txt := "What is the public key of the node's BLS?"
 		publicKey, err = app.Prompt.CaptureValidatedString(txt, prompts.ValidateHexa)
 		if err != nil {
-			return jsonProofOfPossession{}, err
+			return jsonProofOfPossession{}, prompts.WithHint(err, "--public-key")
 		}
 	}
 	if pop == "" {
 		txt := "What is the proof of possession of the node's BLS?"
 		pop, err = app.Prompt.CaptureValidatedString(txt, prompts.ValidateHexa)
 		if err != nil {
-			return jsonProofOfPossession{}, err
+			return jsonProofOfPossession{}, prompts.WithHint(err, "--proof-of-possession")
 		}
 	}
 	return jsonProofOfPossession{PublicKey: publicKey, ProofOfPossession: pop}, nil
@@ -130,7 +135,7 @@ func addValidator(_ *cobra.Command, _ []string) error {
 			[]string{models.Fuji.String(), models.Mainnet.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--fuji/--testnet or --mainnet")
 		}
 		network = models.NetworkFromString(networkStr)
 	}
@@ -179,7 +184,7 @@ func addValidator(_ *cobra.Command, _ []string) error {
 	if weight == 0 {
 		weight, err = nodecmd.PromptWeightPrimaryNetwork(network)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--weight")
 		}
 	}
 	if weight < minValStake {
@@ -207,6 +212,7 @@ func addValidator(_ *cobra.Command, _ []string) error {
 		return err
 	}
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
 	nodecmd.PrintNodeJoinPrimaryNetworkOutput(nodeID, weight, network, start)
 	recipientAddr := kc.Addresses().List()[0]
 	if delegationFee == 0 {
@@ -221,7 +227,13 @@ func addValidator(_ *cobra.Command, _ []string) error {
 		}
 	}
 	_, err = deployer.AddPermissionlessValidator(ids.Empty, ids.Empty, nodeID, weight, uint64(start.Unix()), uint64(start.Add(duration).Unix()), recipientAddr, delegationFee, popBytes, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		subnetcmd.PrintDryRunDone()
+	}
+	return nil
 }
 
 func getDelegationFeeOption(app *application.Lux, network models.Network) (uint32, error) {
@@ -234,7 +246,7 @@ func getDelegationFeeOption(app *application.Lux, network models.Network) (uint3
 		[]string{defaultOption, "Custom"},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--delegation-fee")
 	}
 	if feeOption != defaultOption {
 		ux.Logger.PrintToUser("Note that 20 000 is equivalent to 2%%")
@@ -249,7 +261,7 @@ func getDelegationFeeOption(app *application.Lux, network models.Network) (uint3
 			},
 		)
 		if err != nil {
-			return 0, err
+			return 0, prompts.WithHint(err, "--delegation-fee")
 		}
 		if delegationFee > 0 && delegationFee <= math.MaxUint32 {
 			return uint32(delegationFee), nil
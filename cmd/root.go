@@ -19,6 +19,7 @@ import (
 
 	"github.com/luxdefi/cli/cmd/backendcmd"
 	"github.com/luxdefi/cli/cmd/keycmd"
+	"github.com/luxdefi/cli/cmd/manifestcmd"
 	"github.com/luxdefi/cli/cmd/networkcmd"
 	"github.com/luxdefi/cli/cmd/subnetcmd"
 	"github.com/luxdefi/cli/cmd/transactioncmd"
@@ -29,6 +30,7 @@ import (
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/metrics"
 	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/cli/pkg/state"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/utils/logging"
@@ -40,10 +42,13 @@ import (
 var (
 	app *application.Lux
 
-	logLevel  string
-	Version   = ""
-	cfgFile   string
-	skipCheck bool
+	logLevel       string
+	Version        = ""
+	cfgFile        string
+	skipCheck      bool
+	outputFormat   string
+	nonInteractive bool
+	baseDirFlag    string
 )
 
 func NewRootCmd() *cobra.Command {
@@ -55,7 +60,15 @@ everything Lux. This release specializes in helping developers
 build and test Subnets.
 
 To get started, look at the documentation for the subcommands or jump right
-in with lux subnet create myNewSubnet.`,
+in with lux subnet create myNewSubnet.
+
+Subnet configurations are kept in the closest .lux workspace directory found
+from the current directory, so that they can be committed with a project.
+Without a workspace, and for binaries, snapshots, logs and keys, the base dir
+$HOME/.cli is used, which can be changed with --base-dir or LUX_CLI_HOME.
+
+To share subnet configurations and node clusters with a team, set a shared
+state backend with lux config stateBackend.`,
 		PersistentPreRunE: createApp,
 		Version:           Version,
 		PersistentPostRun: handleTracking,
@@ -67,6 +80,9 @@ in with lux subnet create myNewSubnet.`,
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.cli/config.json)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "ERROR", "log level for the application")
 	rootCmd.PersistentFlags().BoolVar(&skipCheck, constants.SkipUpdateFlag, false, "skip check for new versions")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, constants.NonInteractiveFlag, false, "never prompt for input, fail naming the missing flag instead")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, constants.OutputFormatFlag, string(ux.TableOutput), "output format for listing and describe commands (table, json or yaml)")
+	rootCmd.PersistentFlags().StringVar(&baseDirFlag, constants.BaseDirFlag, "", "base dir for binaries, snapshots, logs and keys (default is $LUX_CLI_HOME or $HOME/.cli)")
 
 	// add sub commands
 	rootCmd.AddCommand(subnetcmd.NewCmd(app))
@@ -74,6 +90,10 @@ in with lux subnet create myNewSubnet.`,
 	rootCmd.AddCommand(networkcmd.NewCmd(app))
 	rootCmd.AddCommand(keycmd.NewCmd(app))
 
+	// add manifest commands
+	rootCmd.AddCommand(manifestcmd.NewUpCmd(app))
+	rootCmd.AddCommand(manifestcmd.NewDownCmd(app))
+
 	// add hidden backend command
 	rootCmd.AddCommand(backendcmd.NewCmd(app))
 
@@ -92,23 +112,37 @@ in with lux subnet create myNewSubnet.`,
 }
 
 func createApp(cmd *cobra.Command, _ []string) error {
-	baseDir, err := setupEnv()
+	format, err := ux.ParseOutputFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+	baseDir, workspaceDir, err := setupEnv()
 	if err != nil {
 		return err
 	}
-	log, err := setupLogging(baseDir)
+	log, err := setupLogging(baseDir, format)
 	if err != nil {
 		return err
 	}
 	cf := config.New()
-	app.Setup(baseDir, log, cf, prompts.NewPrompter(), application.NewDownloader())
+	prompter := prompts.NewPrompter()
+	if nonInteractive {
+		prompter = prompts.NewNonInteractivePrompter()
+	}
+	app.Setup(baseDir, log, cf, prompter, application.NewDownloader())
+	app.SetWorkspaceDir(workspaceDir)
+	app.OutputFormat = format
+	if workspaceDir != "" {
+		app.Log.Debug("using workspace", zap.String("dir", workspaceDir))
+	}
 
 	initConfig()
 
 	if err := migrations.RunMigrations(app); err != nil {
 		return err
 	}
-	if os.Getenv("RUN_E2E") == "" && !app.Conf.ConfigFileExists() && !utils.FileExists(utils.UserHomePath(constants.OldMetricsConfigFileName)) {
+	// metrics preference is asked again on the next interactive run
+	if os.Getenv("RUN_E2E") == "" && !nonInteractive && !app.Conf.ConfigFileExists() && !utils.FileExists(utils.UserHomePath(constants.OldMetricsConfigFileName)) {
 		err = metrics.HandleUserMetricsPreference(app)
 		if err != nil {
 			return err
@@ -117,10 +151,45 @@ func createApp(cmd *cobra.Command, _ []string) error {
 	if err := checkForUpdates(cmd, app); err != nil {
 		return err
 	}
+	if err := setupStateBackend(cmd); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// setupStateBackend pulls the subnet and node state shared through the backend
+// set with lux config stateBackend, if any. The config, backend and update
+// commands don't use it, so that an unreachable backend can still be changed
+func setupStateBackend(cmd *cobra.Command) error {
+	backendURL := app.Conf.GetConfigStringValue(constants.ConfigStateBackendKey)
+	if backendURL == "" {
+		return nil
+	}
+	topCmd := cmd
+	for topCmd.HasParent() && topCmd.Parent() != cmd.Root() {
+		topCmd = topCmd.Parent()
+	}
+	switch topCmd.Name() {
+	case "config", constants.BackendCmd, "update":
+		return nil
+	}
+	backend, err := state.New(state.Config{
+		URL:      backendURL,
+		Endpoint: app.Conf.GetConfigStringValue(constants.ConfigStateEndpointKey),
+		Region:   app.Conf.GetConfigStringValue(constants.ConfigStateRegionKey),
+	})
+	if err != nil {
+		return err
+	}
+	app.SetStateBackend(backend)
+	app.Log.Debug("using state backend", zap.String("url", backend.URL()))
+	if err := app.PullState(); err != nil {
+		return fmt.Errorf("failed to pull the shared state from %s: %w", backend.URL(), err)
+	}
+	return nil
+}
+
 // checkForUpdates evaluates first if the user is maybe wanting to skip the update check
 // if there's no skip, it runs the update check
 func checkForUpdates(cmd *cobra.Command, app *application.Lux) error {
@@ -192,22 +261,38 @@ func handleTracking(cmd *cobra.Command, _ []string) {
 	metrics.HandleTracking(cmd, app, nil)
 }
 
-func setupEnv() (string, error) {
-	// Set base dir
+// returns the base dir given by --base-dir or LUX_CLI_HOME, or else the
+// default one in the user home
+func getBaseDir() (string, error) {
+	baseDir := baseDirFlag
+	if baseDir == "" {
+		baseDir = os.Getenv(constants.BaseDirEnvVarName)
+	}
+	if baseDir != "" {
+		return filepath.Abs(baseDir)
+	}
 	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("unable to get system user %w", err)
+	}
+	return filepath.Join(usr.HomeDir, constants.BaseDirName), nil
+}
+
+func setupEnv() (string, string, error) {
+	// Set base dir
+	baseDir, err := getBaseDir()
 	if err != nil {
 		// no logger here yet
-		fmt.Printf("unable to get system user %s\n", err)
-		return "", err
+		fmt.Println(err)
+		return "", "", err
 	}
-	baseDir := filepath.Join(usr.HomeDir, constants.BaseDirName)
 
 	// Create base dir if it doesn't exist
 	err = os.MkdirAll(baseDir, os.ModePerm)
 	if err != nil {
 		// no logger here yet
 		fmt.Printf("failed creating the basedir %s: %s\n", baseDir, err)
-		return "", err
+		return "", "", err
 	}
 
 	// Create snapshots dir if it doesn't exist
@@ -251,10 +336,29 @@ func setupEnv() (string, error) {
 		os.Exit(1)
 	}
 
-	return baseDir, nil
+	// Subnet configurations go to the project workspace, if any
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("unable to get the current directory: %s\n", err)
+		return "", "", err
+	}
+	workspaceDir, err := application.FindWorkspace(cwd)
+	if err != nil {
+		fmt.Printf("failed looking for a %s workspace: %s\n", constants.WorkspaceDirName, err)
+		return "", "", err
+	}
+	if workspaceDir != "" {
+		workspaceSubnetDir := filepath.Join(workspaceDir, constants.SubnetDir)
+		if err = os.MkdirAll(workspaceSubnetDir, os.ModePerm); err != nil {
+			fmt.Printf("failed creating the workspace subnet dir %s: %s\n", workspaceSubnetDir, err)
+			os.Exit(1)
+		}
+	}
+
+	return baseDir, workspaceDir, nil
 }
 
-func setupLogging(baseDir string) (logging.Logger, error) {
+func setupLogging(baseDir string, format ux.OutputFormat) (logging.Logger, error) {
 	var err error
 
 	config := logging.Config{}
@@ -280,8 +384,13 @@ func setupLogging(baseDir string) (logging.Logger, error) {
 		factory.Close()
 		return nil, fmt.Errorf("failed setting up logging, exiting: %w", err)
 	}
-	// create the user facing logger as a global var
-	ux.NewUserLog(log, os.Stdout)
+	// create the user facing logger as a global var.
+	// with structured output, stdout is reserved for the json/yaml document
+	userWriter := os.Stdout
+	if format.IsStructured() {
+		userWriter = os.Stderr
+	}
+	ux.NewUserLog(log, userWriter)
 	return log, nil
 }
 
@@ -311,6 +420,11 @@ func Execute() {
 	app = application.New()
 	rootCmd := NewRootCmd()
 	err := rootCmd.Execute()
+	// also after a failure, as for the nodes created before it
+	if pushErr := app.PushState(); pushErr != nil {
+		ux.Logger.PrintToUser("Failed to push the shared state: %s", pushErr)
+		err = pushErr
+	}
 	if err != nil {
 		os.Exit(1)
 	}
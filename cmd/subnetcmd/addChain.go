@@ -0,0 +1,91 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// lux subnet add-chain
+func newAddChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-chain [subnetName] [chainName]",
+		Short: "Add a new blockchain configuration to a subnet",
+		Long: `The subnet add-chain command creates the configuration of a new blockchain,
+and adds it to an existing Subnet, so that the Subnet runs more than one
+blockchain. The blockchain is configured the same way as with subnet create,
+with its own VM and genesis.
+
+The chains of a Subnet are deployed together with subnet deploy. If the
+Subnet is already deployed to a network, the next deploy creates the new
+chains into the existing Subnet. Validators added with subnet addValidator
+validate all the chains of the Subnet.`,
+		SilenceUsage:      true,
+		Args:              cobra.ExactArgs(2),
+		RunE:              addChain,
+		PersistentPostRun: handlePostRun,
+	}
+	cmd.Flags().StringVar(&genesisFile, "genesis", "", "file path of genesis to use")
+	cmd.Flags().StringVar(&specFile, fromSpecFlag, "", "file path of a Subnet-EVM spec to build the genesis from")
+	cmd.Flags().BoolVar(&useSubnetEvm, "evm", false, "use the Subnet-EVM as the base template")
+	cmd.Flags().StringVar(&evmVersion, "vm-version", "", "version of Subnet-Evm template to use")
+	cmd.Flags().BoolVar(&useCustom, "custom", false, "use a custom VM template")
+	cmd.Flags().BoolVar(&useLatestEvmVersion, latest, false, "use latest Subnet-Evm version, takes precedence over --vm-version")
+	cmd.Flags().BoolVarP(&forceCreate, forceFlag, "f", false, "overwrite the existing chain configuration if one exists")
+	cmd.Flags().StringVar(&customVMRepoURL, "custom-vm-repo-url", "", "custom vm repository url")
+	cmd.Flags().StringVar(&customVMBranch, "custom-vm-branch", "", "custom vm branch")
+	cmd.Flags().StringVar(&customVMBuildScript, "custom-vm-build-script", "", "custom vm build-script")
+	return cmd
+}
+
+func addChain(cmd *cobra.Command, args []string) error {
+	subnetName := args[0]
+	chainName := args[1]
+
+	if subnetName == chainName {
+		return errors.New("the chain name must be different from the subnet name")
+	}
+	if !app.SidecarExists(subnetName) {
+		return fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+	subnetSidecar, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if subnetSidecar.IsChildChain() {
+		return fmt.Errorf("%s is a chain of subnet %s, use the subnet name", subnetName, subnetSidecar.Subnet)
+	}
+	if subnetSidecar.ImportedFromLPM {
+		return errors.New("unable to add chains to subnets imported from a repo")
+	}
+	if app.SidecarExists(chainName) && !forceCreate {
+		sc, err := app.LoadSidecar(chainName)
+		if err != nil {
+			return err
+		}
+		if sc.Subnet != subnetName {
+			return fmt.Errorf("%s already exists as a subnet or a chain of subnet %s", chainName, sc.Subnet)
+		}
+	}
+
+	if err := createSubnetConfig(cmd, []string{chainName}); err != nil {
+		return err
+	}
+
+	sc, err := app.LoadSidecar(chainName)
+	if err != nil {
+		return err
+	}
+	sc.Subnet = subnetName
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Chain %s added to subnet %s", chainName, subnetName)
+	ux.Logger.PrintToUser("Deploy it with: lux subnet deploy %s", subnetName)
+	return nil
+}
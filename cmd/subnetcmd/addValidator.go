@@ -45,7 +45,8 @@ for the validation start time, duration, and stake weight. You can bypass
 these prompts by providing the values with flags.
 
 This command currently only works on Subnets deployed to either the Fuji
-Testnet or Mainnet.`,
+Testnet or Mainnet. Use --dry-run to print the transaction, its fee and the
+control key signatures it requires, without issuing it.`,
 		SilenceUsage: true,
 		RunE:         addValidator,
 		Args:         cobra.ExactArgs(1),
@@ -68,6 +69,7 @@ Testnet or Mainnet.`,
 	cmd.Flags().BoolVarP(&useEwoq, "ewoq", "e", false, "use ewoq key [fuji/devnet only]")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji/devnet)")
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	AddDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -84,6 +86,9 @@ func addValidator(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := CheckDryRun(dryRun, network); err != nil {
+		return err
+	}
 	fee := network.GenesisParams().AddSubnetValidatorFee
 	kc, err := keychain.GetKeychainFromCmdLineFlags(
 		app,
@@ -202,10 +207,16 @@ func CallAddValidator(
 	ux.Logger.PrintToUser("Inputs complete, issuing transaction to add the provided validator information...")
 
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
+	deployer.SetJournal(subnetName)
 	isFullySigned, tx, remainingSubnetAuthKeys, err := deployer.AddValidator(controlKeys, subnetAuthKeys, subnetID, nodeID, weight, start, duration)
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		PrintDryRunDone()
+		return nil
+	}
 	if !isFullySigned {
 		if err := SaveNotFullySignedTx(
 			"Add Validator",
@@ -234,13 +245,13 @@ func PromptDuration(start time.Time, network models.Network) (time.Duration, err
 			d, err = app.Prompt.CaptureMainnetDuration(txt)
 		}
 		if err != nil {
-			return 0, err
+			return 0, prompts.WithHint(err, "--staking-period")
 		}
 		end := start.Add(d)
 		confirm := fmt.Sprintf("Your validator will finish staking by %s", end.Format(constants.TimeParseLayout))
 		yes, err := app.Prompt.CaptureYesNo(confirm)
 		if err != nil {
-			return 0, err
+			return 0, prompts.WithHint(err, "--staking-period")
 		}
 		if yes {
 			return d, nil
@@ -302,7 +313,7 @@ func getTimeParameters(network models.Network, nodeID ids.NodeID, isValidator bo
 		startTimeOptions := []string{defaultStartOption, custom}
 		startTimeOption, err := app.Prompt.CaptureList("Start time", startTimeOptions)
 		if err != nil {
-			return time.Time{}, 0, err
+			return time.Time{}, 0, prompts.WithHint(err, "--start-time")
 		}
 
 		switch startTimeOption {
@@ -332,7 +343,7 @@ func getTimeParameters(network models.Network, nodeID ids.NodeID, isValidator bo
 		durationOptions := []string{defaultDurationOption, custom}
 		durationOption, err := app.Prompt.CaptureList(msg, durationOptions)
 		if err != nil {
-			return time.Time{}, 0, err
+			return time.Time{}, 0, prompts.WithHint(err, "--staking-period")
 		}
 
 		switch durationOption {
@@ -353,7 +364,8 @@ func getTimeParameters(network models.Network, nodeID ids.NodeID, isValidator bo
 
 func promptStart() (time.Time, error) {
 	txt := "When should the validator start validating? Enter a UTC datetime in 'YYYY-MM-DD HH:MM:SS' format"
-	return app.Prompt.CaptureDate(txt)
+	start, err := app.Prompt.CaptureDate(txt)
+	return start, prompts.WithHint(err, "--start-time")
 }
 
 func PromptNodeID() (ids.NodeID, error) {
@@ -363,7 +375,8 @@ func PromptNodeID() (ids.NodeID, error) {
 	ux.Logger.PrintToUser("(Edit host IP address and port to match your deployment, if needed).")
 
 	txt := "What is the NodeID of the validator you'd like to whitelist?"
-	return app.Prompt.CaptureNodeID(txt)
+	nodeID, err := app.Prompt.CaptureNodeID(txt)
+	return nodeID, prompts.WithHint(err, "--nodeID")
 }
 
 func PromptWeight() (uint64, error) {
@@ -376,13 +389,14 @@ func PromptWeight() (uint64, error) {
 
 	weightOption, err := app.Prompt.CaptureList(txt, weightOptions)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--weight or --default-validator-params")
 	}
 
 	switch weightOption {
 	case defaultWeight:
 		return constants.DefaultStakeWeight, nil
 	default:
-		return app.Prompt.CaptureWeight(txt)
+		weight, err := app.Prompt.CaptureWeight(txt)
+		return weight, prompts.WithHint(err, "--weight")
 	}
 }
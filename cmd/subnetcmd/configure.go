@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
@@ -85,14 +86,15 @@ func configure(_ *cobra.Command, args []string) error {
 
 	// no flags provided
 	if len(configsToLoad) == 0 {
+		const configFlags = "--node-config, --chain-config, --subnet-config or --per-node-chain-config"
 		options := []string{nodeLabel, chainLabel, subnetLabel, perNodeChainLabel}
 		selected, err := app.Prompt.CaptureList("Which configuration file would you like to provide?", options)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, configFlags)
 		}
 		configsToLoad[selected], err = app.Prompt.CaptureExistingFilepath("Enter the path to your configuration file")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, configFlags)
 		}
 		var other string
 		if selected == chainLabel || selected == perNodeChainLabel {
@@ -102,12 +104,12 @@ func configure(_ *cobra.Command, args []string) error {
 		}
 		yes, err := app.Prompt.CaptureNoYes(fmt.Sprintf("Would you like to provide the %s file as well?", other))
 		if err != nil {
-			return err
+			return prompts.WithHint(err, configFlags)
 		}
 		if yes {
 			configsToLoad[other], err = app.Prompt.CaptureExistingFilepath("Enter the path to your configuration file")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, configFlags)
 			}
 		}
 	}
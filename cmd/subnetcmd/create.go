@@ -14,6 +14,7 @@ import (
 	"github.com/luxdefi/cli/pkg/metrics"
 
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/cli/pkg/vm"
 	"github.com/spf13/cobra"
@@ -21,14 +22,16 @@ import (
 )
 
 const (
-	forceFlag = "force"
-	latest    = "latest"
+	forceFlag    = "force"
+	fromSpecFlag = "from-spec"
+	latest       = "latest"
 )
 
 var (
 	forceCreate         bool
 	useSubnetEvm        bool
 	genesisFile         string
+	specFile            string
 	vmFile              string
 	useCustom           bool
 	evmVersion          string
@@ -51,6 +54,11 @@ The tool supports deploying Subnet-EVM, and custom VMs. You
 can create a custom, user-generated genesis with a custom VM by providing
 the path to your genesis and VM binaries with the --genesis and --vm flags.
 
+To create a Subnet-EVM subnet without going through the wizard, describe it
+in a yaml or json spec file and pass it with the --from-spec flag. The spec
+sets the chain ID, token name, VM version, fee config, allocations and
+precompile configs, so the same file always produces the same genesis.
+
 By default, running the command with a subnetName that already exists
 causes the command to fail. If you’d like to overwrite an existing
 configuration, pass the -f flag.`,
@@ -60,6 +68,7 @@ configuration, pass the -f flag.`,
 		PersistentPostRun: handlePostRun,
 	}
 	cmd.Flags().StringVar(&genesisFile, "genesis", "", "file path of genesis to use")
+	cmd.Flags().StringVar(&specFile, fromSpecFlag, "", "file path of a Subnet-EVM spec to build the genesis from")
 	cmd.Flags().BoolVar(&useSubnetEvm, "evm", false, "use the Subnet-EVM as the base template")
 	cmd.Flags().StringVar(&evmVersion, "vm-version", "", "version of Subnet-Evm template to use")
 	cmd.Flags().BoolVar(&useCustom, "custom", false, "use a custom VM template")
@@ -137,6 +146,16 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 		return errors.New("too many VMs selected. Provide at most one VM selection flag")
 	}
 
+	if specFile != "" {
+		if useCustom {
+			return errors.New("--" + fromSpecFlag + " only supports Subnet-EVM, it can't be used with --custom")
+		}
+		if genesisFile != "" {
+			return errors.New("--" + fromSpecFlag + " and --genesis are mutually exclusive")
+		}
+		useSubnetEvm = true
+	}
+
 	subnetType := getVMFromFlag()
 
 	if subnetType == "" {
@@ -145,7 +164,7 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 			[]string{models.SubnetEvm, models.CustomVM},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--evm or --custom")
 		}
 		subnetType = models.VMTypeFromString(subnetTypeStr)
 	}
@@ -166,9 +185,20 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 
 	switch subnetType {
 	case models.SubnetEvm:
+		if specFile != "" {
+			spec, err := vm.LoadEvmSpec(specFile)
+			if err != nil {
+				return err
+			}
+			genesisBytes, sc, err = vm.CreateEvmSubnetConfigFromSpec(app, subnetName, spec, evmVersion)
+			if err != nil {
+				return err
+			}
+			break
+		}
 		genesisBytes, sc, err = vm.CreateEvmSubnetConfig(app, subnetName, genesisFile, evmVersion)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--genesis or --"+fromSpecFlag)
 		}
 	case models.CustomVM:
 		genesisBytes, sc, err = vm.CreateCustomSubnetConfig(
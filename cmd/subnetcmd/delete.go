@@ -4,11 +4,14 @@ package subnetcmd
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +26,11 @@ func newDeleteCmd() *cobra.Command {
 	}
 }
 
+// CallDeleteSubnet deletes the configuration of [subnetName]
+func CallDeleteSubnet(subnetName string) error {
+	return deleteSubnet(nil, []string{subnetName})
+}
+
 func deleteSubnet(_ *cobra.Command, args []string) error {
 	// TODO sanitize this input
 	subnetName := args[0]
@@ -35,6 +43,17 @@ func deleteSubnet(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	if !sidecar.IsChildChain() {
+		chains, err := subnet.GetSubnetChains(app, subnetName)
+		if err != nil {
+			return err
+		}
+		if len(chains) > 1 {
+			return fmt.Errorf("subnet %s has other chains: %s. Delete them first",
+				subnetName, strings.Join(chains[1:], ", "))
+		}
+	}
+
 	if sidecar.VM == models.CustomVM {
 		if _, err := os.Stat(customVMPath); err != nil {
 			if !errors.Is(err, fs.ErrNotExist) {
@@ -56,6 +75,11 @@ func deleteSubnet(_ *cobra.Command, args []string) error {
 	// but only if no other subnet is using it.
 	// More info: https://github.com/luxdefi/cli/issues/246
 
+	// the lock of the applied upgrades stays in the base dir for subnets in a workspace
+	if err := os.Remove(app.GetUpgradeBytesLockFilePath(subnetName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
 	if _, err := os.Stat(subnetDir); err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
 			return err
@@ -52,6 +52,8 @@ var (
 	subnetIDStr              string
 	mainnetChainID           uint32
 	skipCreatePrompt         bool
+	localValidators          []string
+	resumeDeploy             bool
 
 	errMutuallyExlusiveNetworks = errors.New("--local, --fuji/--testnet, --mainnet are mutually exclusive")
 
@@ -75,7 +77,15 @@ attempts to deploy the same Subnet to the same network (local, Fuji, Mainnet) ar
 allowed. If you'd like to redeploy a Subnet locally for testing, you must first call
 lux network clean to reset all deployed chain state. Subsequent local deploys
 redeploy the chain with fresh state. You can deploy the same Subnet to multiple networks,
-so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
+so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.
+
+On public networks, --dry-run builds and signs the deploy transactions without
+issuing them, and prints their fees, the funding addresses balances and the
+control key signatures they require.
+
+Every transaction issued by a public deploy is recorded in the Subnet journal. If
+a deploy fails or is interrupted after creating the Subnet, run it again with
+--resume to continue from its last confirmed step instead of creating a new Subnet.`,
 		SilenceUsage:      true,
 		RunE:              deploySubnet,
 		PersistentPostRun: handlePostRun,
@@ -99,6 +109,9 @@ so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
 	cmd.Flags().StringVarP(&subnetIDStr, "subnet-id", "u", "", "deploy into given subnet id")
 	cmd.Flags().Uint32Var(&mainnetChainID, "mainnet-chain-id", 0, "use given ChainID for mainnet deployment")
+	cmd.Flags().StringSliceVar(&localValidators, "local-validators", nil, "local nodes that validate the subnet, as <nodeName> or <nodeName>=<weight> [local deploy only]")
+	AddDryRunFlag(cmd, &dryRun)
+	cmd.Flags().BoolVar(&resumeDeploy, "resume", false, "continue a failed or interrupted public deploy from its last confirmed step")
 	return cmd
 }
 
@@ -127,37 +140,16 @@ func CallDeploy(
 	return deploySubnet(cmd, []string{subnetName})
 }
 
-func getChainsInSubnet(subnetName string) ([]string, error) {
-	subnets, err := os.ReadDir(app.GetSubnetDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to read baseDir: %w", err)
-	}
-
-	chains := []string{}
-
-	for _, s := range subnets {
-		if !s.IsDir() {
-			continue
-		}
-		sidecarFile := filepath.Join(app.GetSubnetDir(), s.Name(), constants.SidecarFileName)
-		if _, err := os.Stat(sidecarFile); err == nil {
-			// read in sidecar file
-			jsonBytes, err := os.ReadFile(sidecarFile)
-			if err != nil {
-				return nil, fmt.Errorf("failed reading file %s: %w", sidecarFile, err)
-			}
+// CallDeployLocal deploys [subnetName] to the running local network, validated by
+// [localValidatorsParam], or by all the local nodes if empty
+func CallDeployLocal(cmd *cobra.Command, subnetName string, localValidatorsParam []string) error {
+	userProvidedLuxdVersion = "latest"
+	localValidators = localValidatorsParam
+	return CallDeploy(cmd, subnetName, true, false, false, false, "", "", false, false, false)
+}
 
-			var sc models.Sidecar
-			err = json.Unmarshal(jsonBytes, &sc)
-			if err != nil {
-				return nil, fmt.Errorf("failed unmarshaling file %s: %w", sidecarFile, err)
-			}
-			if sc.Subnet == subnetName {
-				chains = append(chains, sc.Name)
-			}
-		}
-	}
-	return chains, nil
+func getChainsInSubnet(subnetName string) ([]string, error) {
+	return subnet.GetSubnetChains(app, subnetName)
 }
 
 func checkSubnetEVMDefaultAddressNotInAlloc(network models.Network, chain string) error {
@@ -229,7 +221,7 @@ func getSubnetEVMMainnetChainID(sc *models.Sidecar, subnetName string) error {
 		)
 		decision, err = app.Prompt.CaptureList(newChainIDPrompt, listOptions)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--mainnet-chain-id")
 		}
 		if decision == useSameChainID {
 			sc.SubnetEVMMainnetChainID = uint(originalChainID)
@@ -251,7 +243,7 @@ func getSubnetEVMMainnetChainID(sc *models.Sidecar, subnetName string) error {
 				},
 			)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--mainnet-chain-id")
 			}
 			sc.SubnetEVMMainnetChainID = uint(newChainID)
 		}
@@ -268,6 +260,9 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 		}
 		if !skipCreatePrompt {
 			yes, promptErr := app.Prompt.CaptureNoYes(fmt.Sprintf("Subnet %s is not found. Do you want to create it first?", args[0]))
+			if errors.Is(promptErr, prompts.ErrNonInteractive) {
+				return fmt.Errorf("%w, create it first with lux subnet create", err)
+			}
 			if promptErr != nil {
 				return promptErr
 			}
@@ -316,83 +311,67 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	isEVMGenesis, err := hasSubnetEVMGenesis(chain)
-	if err != nil {
-		return err
-	}
-	if sidecar.VM == models.SubnetEvm && !isEVMGenesis {
-		return fmt.Errorf("failed to validate SubnetEVM genesis format")
+	if len(localValidators) > 0 && network.Kind != models.Local {
+		return errors.New("--local-validators is only supported on local deploys")
 	}
 
-	chainGenesis, err := app.LoadRawGenesis(chain)
-	if err != nil {
+	if err := CheckDryRun(dryRun, network); err != nil {
 		return err
 	}
 
-	if isEVMGenesis {
-		// is is a subnet evm or a custom vm based on subnet evm
-		if network.Kind == models.Mainnet {
-			err = getSubnetEVMMainnetChainID(&sidecar, chain)
-			if err != nil {
-				return err
-			}
-			chainGenesis, err = updateSubnetEVMGenesisChainID(chainGenesis, sidecar.SubnetEVMMainnetChainID)
-			if err != nil {
-				return err
-			}
+	if resumeDeploy {
+		if network.Kind == models.Local {
+			return errors.New("--resume is only supported on public networks")
 		}
-		err = checkSubnetEVMDefaultAddressNotInAlloc(network, chain)
-		if err != nil {
-			return err
+		if subnetIDStr != "" {
+			return errors.New("--resume and --subnet-id are mutually exclusive")
 		}
 	}
 
+	chainGenesis, err := prepareChainGenesis(network, &sidecar, chain)
+	if err != nil {
+		return err
+	}
+
 	ux.Logger.PrintToUser("Deploying %s to %s", chains, network.Name())
 
 	if network.Kind == models.Local {
 		app.Log.Debug("Deploy local")
 
-		genesisPath := app.GetGenesisPath(chain)
-
-		// copy vm binary to the expected location, first downloading it if necessary
-		var vmBin string
-		switch sidecar.VM {
-		case models.SubnetEvm:
-			vmBin, err = binutils.SetupSubnetEVM(app, sidecar.VMVersion)
-			if err != nil {
-				return fmt.Errorf("failed to install subnet-evm: %w", err)
-			}
-		case models.CustomVM:
-			vmBin = binutils.SetupCustomBin(app, chain)
-		default:
-			return fmt.Errorf("unknown vm: %s", sidecar.VM)
+		validators, err := subnet.ParseLocalValidators(localValidators)
+		if err != nil {
+			return err
 		}
 
-		// check if selected version matches what is currently running
-		nc := localnetworkinterface.NewStatusChecker()
-		userProvidedLuxdVersion, err = CheckForInvalidDeployAndGetLuxdVersion(nc, sidecar.RPCVersion)
+		sidecars, geneses, err := prepareChains(network, chain, sidecar, chainGenesis, chains)
 		if err != nil {
 			return err
 		}
-
-		deployer := subnet.NewLocalDeployer(app, userProvidedLuxdVersion, vmBin)
-		subnetID, blockchainID, err := deployer.DeployToLocalNetwork(chain, chainGenesis, genesisPath)
+		subnetID, err := deployLocalChain(sidecar, chain, chainGenesis, validators, ids.Empty)
 		if err != nil {
-			if deployer.BackendStartedHere() {
-				if innerErr := binutils.KillgRPCServerProcess(app); innerErr != nil {
-					app.Log.Warn("tried to kill the gRPC server process but it failed", zap.Error(innerErr))
-				}
-			}
 			return err
 		}
+		// chains added with subnet add-chain are deployed into the same subnet
+		for _, childChain := range chains[1:] {
+			if _, err := deployLocalChain(sidecars[childChain], childChain, geneses[childChain], nil, subnetID); err != nil {
+				return fmt.Errorf("failed to deploy chain %s: %w", childChain, err)
+			}
+		}
 		flags := make(map[string]string)
 		flags[constants.Network] = network.Name()
 		metrics.HandleTracking(cmd, app, flags)
-		return app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID)
+		return nil
 	}
 
 	// from here on we are assuming a public deploy
 
+	// chains already deployed to the network are skipped, so that chains added
+	// with subnet add-chain are deployed into the existing subnet
+	deployChains, err := getChainsToDeploy(network, chains)
+	if err != nil {
+		return err
+	}
+
 	createSubnet := true
 	var subnetID ids.ID
 	if subnetIDStr != "" {
@@ -404,14 +383,31 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 	} else if sidecar.Networks != nil {
 		model, ok := sidecar.Networks[network.Name()]
 		if ok {
-			if model.SubnetID != ids.Empty && model.BlockchainID == ids.Empty {
+			if model.SubnetID != ids.Empty && (model.BlockchainID == ids.Empty || len(deployChains) < len(chains)) {
 				subnetID = model.SubnetID
 				createSubnet = false
 			}
 		}
 	}
 
-	fee := network.GenesisParams().CreateBlockchainTxFee
+	if resumeDeploy {
+		subnetID, deployChains, err = getChainsToResume(network, chains)
+		if err != nil {
+			return err
+		}
+		if len(deployChains) == 0 {
+			ux.Logger.PrintToUser("All the chains of subnet %s are already deployed to %s, nothing to resume", chain, network.Name())
+			return nil
+		}
+		createSubnet = false
+	}
+
+	sidecars, geneses, err := prepareChains(network, chain, sidecar, chainGenesis, deployChains)
+	if err != nil {
+		return err
+	}
+
+	fee := network.GenesisParams().CreateBlockchainTxFee * uint64(len(deployChains))
 	if createSubnet {
 		fee += network.GenesisParams().CreateSubnetTxFee
 	}
@@ -508,6 +504,8 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 
 	// deploy to public network
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
+	deployer.SetJournal(chain)
 
 	if createSubnet {
 		subnetID, err = deployer.DeploySubnet(controlKeys, threshold)
@@ -515,19 +513,255 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		// get the control keys in the same order as the tx
-		controlKeys, threshold, err = txutils.GetOwners(network, subnetID)
+		controlKeys, threshold, err = deployer.GetOwners(subnetID)
 		if err != nil {
 			return err
 		}
 	}
 
+	for i, c := range deployChains {
+		sc, genesis := sidecars[c], geneses[c]
+		txPath := outputTxPath
+		if i > 0 && txPath != "" {
+			ext := filepath.Ext(txPath)
+			txPath = fmt.Sprintf("%s_%s%s", strings.TrimSuffix(txPath, ext), c, ext)
+		}
+		if err := deployPublicChain(deployer, &sc, c, genesis, network, subnetID, controlKeys, subnetAuthKeys, txPath); err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		PrintDryRunDone()
+		return nil
+	}
+
+	flags := make(map[string]string)
+	flags[constants.Network] = network.Name()
+	metrics.HandleTracking(cmd, app, flags)
+	return nil
+}
+
+// loads the sidecars of [chains] and prepares their geneses for [network], so that
+// all of them are validated before any chain is deployed. The already prepared
+// sidecar and genesis of [chain] are reused
+func prepareChains(
+	network models.Network,
+	chain string,
+	sidecar models.Sidecar,
+	chainGenesis []byte,
+	chains []string,
+) (map[string]models.Sidecar, map[string][]byte, error) {
+	// the mainnet chain ID flag only applies to [chain], already prepared, as
+	// each chain of the subnet needs an EVM chain ID of its own
+	mainnetChainID = 0
+	sidecars := map[string]models.Sidecar{chain: sidecar}
+	geneses := map[string][]byte{chain: chainGenesis}
+	for _, c := range chains {
+		if c == chain {
+			continue
+		}
+		sc, err := app.LoadSidecar(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		genesis, err := prepareChainGenesis(network, &sc, c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to prepare the genesis of chain %s: %w", c, err)
+		}
+		sidecars[c] = sc
+		geneses[c] = genesis
+	}
+	return sidecars, geneses, nil
+}
+
+// validates the genesis of [chain], and returns it as it is deployed to [network]
+func prepareChainGenesis(network models.Network, sc *models.Sidecar, chain string) ([]byte, error) {
+	isEVMGenesis, err := hasSubnetEVMGenesis(chain)
+	if err != nil {
+		return nil, err
+	}
+	if sc.VM == models.SubnetEvm && !isEVMGenesis {
+		return nil, fmt.Errorf("failed to validate SubnetEVM genesis format")
+	}
+
+	chainGenesis, err := app.LoadRawGenesis(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEVMGenesis {
+		// is is a subnet evm or a custom vm based on subnet evm
+		if network.Kind == models.Mainnet {
+			err = getSubnetEVMMainnetChainID(sc, chain)
+			if err != nil {
+				return nil, err
+			}
+			chainGenesis, err = updateSubnetEVMGenesisChainID(chainGenesis, sc.SubnetEVMMainnetChainID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = checkSubnetEVMDefaultAddressNotInAlloc(network, chain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chainGenesis, nil
+}
+
+// deploys [chain] to the local network, into [subnetID] if given, or else into a new
+// subnet validated by [validators]. Returns the subnet ID of the chain
+func deployLocalChain(
+	sc models.Sidecar,
+	chain string,
+	chainGenesis []byte,
+	validators []subnet.LocalValidator,
+	subnetID ids.ID,
+) (ids.ID, error) {
+	genesisPath := app.GetGenesisPath(chain)
+
+	// copy vm binary to the expected location, first downloading it if necessary
+	var (
+		vmBin string
+		err   error
+	)
+	switch sc.VM {
+	case models.SubnetEvm:
+		vmBin, err = binutils.SetupSubnetEVM(app, sc.VMVersion)
+		if err != nil {
+			return ids.Empty, fmt.Errorf("failed to install subnet-evm: %w", err)
+		}
+	case models.CustomVM:
+		vmBin = binutils.SetupCustomBin(app, chain)
+	default:
+		return ids.Empty, fmt.Errorf("unknown vm: %s", sc.VM)
+	}
+
+	// check if selected version matches what is currently running
+	nc := localnetworkinterface.NewStatusChecker()
+	userProvidedLuxdVersion, err = CheckForInvalidDeployAndGetLuxdVersion(nc, sc.RPCVersion)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	deployer := subnet.NewLocalDeployer(app, userProvidedLuxdVersion, vmBin)
+	deployer.SetValidators(validators)
+	deployer.SetSubnetID(subnetID)
+	deployedSubnetID, blockchainID, err := deployer.DeployToLocalNetwork(chain, chainGenesis, genesisPath)
+	if err != nil {
+		if deployer.BackendStartedHere() {
+			if innerErr := binutils.KillgRPCServerProcess(app); innerErr != nil {
+				app.Log.Warn("tried to kill the gRPC server process but it failed", zap.Error(innerErr))
+			}
+		}
+		return ids.Empty, err
+	}
+	if blockchainID == ids.Empty {
+		// already deployed, keep the deploy info of the sidecar
+		deployedSubnetID = sc.Networks[models.Local.String()].SubnetID
+		if deployedSubnetID == ids.Empty {
+			return ids.Empty, fmt.Errorf("chain %s is deployed, but its subnet ID is not known. Run lux network clean to redeploy it", chain)
+		}
+		return deployedSubnetID, nil
+	}
+	return deployedSubnetID, app.UpdateSidecarNetworks(&sc, models.LocalNetwork, deployedSubnetID, blockchainID)
+}
+
+// returns the chains of the subnet not yet deployed to [network], or all of them
+// if none is deployed or all are
+func getChainsToDeploy(network models.Network, chains []string) ([]string, error) {
+	deployChains := []string{}
+	for _, c := range chains {
+		sc, err := app.LoadSidecar(c)
+		if err != nil {
+			return nil, err
+		}
+		if sc.Networks[network.Name()].BlockchainID == ids.Empty {
+			deployChains = append(deployChains, c)
+		}
+	}
+	if len(deployChains) == 0 {
+		return chains, nil
+	}
+	return deployChains, nil
+}
+
+// returns the subnet of the previous deploys of [chains] to [network], and the
+// chains not yet created into it, according to the subnet journal. The chains
+// already created are saved into their sidecars, as the deploy that created
+// them may have stopped before doing so
+func getChainsToResume(network models.Network, chains []string) (ids.ID, []string, error) {
+	progress, err := subnet.GetDeployProgress(app, network, chains[0])
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	if progress.SubnetID == ids.Empty {
+		return ids.Empty, nil, fmt.Errorf("no confirmed deploy of subnet %s to %s found in its journal, deploy it without --resume", chains[0], network.Name())
+	}
+	ux.Logger.PrintToUser("Resuming deploy into subnet ID %s", progress.SubnetID)
+	// chains committed with transaction commit are only recorded in their sidecar
+	sidecars := []models.Sidecar{}
+	for _, c := range chains {
+		if _, ok := progress.Blockchains[c]; ok {
+			continue
+		}
+		sc, err := app.LoadSidecar(c)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		sidecars = append(sidecars, sc)
+	}
+	progress.AddSidecarBlockchains(network, sidecars)
+	deployChains := []string{}
+	for _, c := range chains {
+		blockchainID, ok := progress.Blockchains[c]
+		if !ok {
+			deployChains = append(deployChains, c)
+			continue
+		}
+		ux.Logger.PrintToUser("Chain %s was already created with blockchain ID %s", c, blockchainID)
+		if dryRun {
+			continue
+		}
+		sc, err := app.LoadSidecar(c)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		if sc.Networks[network.Name()].BlockchainID != blockchainID {
+			if err := app.UpdateSidecarNetworks(&sc, network, progress.SubnetID, blockchainID); err != nil {
+				return ids.Empty, nil, err
+			}
+		}
+	}
+	return progress.SubnetID, deployChains, nil
+}
+
+// issues the CreateChainTx of [chain] into [subnetID], and saves the result
+// in its sidecar
+func deployPublicChain(
+	deployer *subnet.PublicDeployer,
+	sc *models.Sidecar,
+	chain string,
+	chainGenesis []byte,
+	network models.Network,
+	subnetID ids.ID,
+	controlKeys []string,
+	subnetAuthKeys []string,
+	txPath string,
+) error {
 	isFullySigned, blockchainID, tx, remainingSubnetAuthKeys, err := deployer.DeployBlockchain(controlKeys, subnetAuthKeys, subnetID, chain, chainGenesis)
 	if err != nil {
 		ux.Logger.PrintToUser(logging.Red.Wrap(
-			fmt.Sprintf("error deploying blockchain: %s. fix the issue and try again with a new deploy cmd", err),
+			fmt.Sprintf("error deploying blockchain: %s. fix the issue and continue the deploy with --resume", err),
 		))
 	}
 
+	// nothing was deployed, so there is nothing to save
+	if dryRun {
+		return err
+	}
+
 	savePartialTx := !isFullySigned && err == nil
 
 	if err := PrintDeployResults(chain, subnetID, blockchainID); err != nil {
@@ -541,20 +775,16 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 			chain,
 			subnetAuthKeys,
 			remainingSubnetAuthKeys,
-			outputTxPath,
+			txPath,
 			false,
 		); err != nil {
 			return err
 		}
 	}
 
-	flags := make(map[string]string)
-	flags[constants.Network] = network.Name()
-	metrics.HandleTracking(cmd, app, flags)
-
 	// update sidecar
 	// TODO: need to do something for backwards compatibility?
-	return app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID)
+	return app.UpdateSidecarNetworks(sc, network, subnetID, blockchainID)
 }
 
 func getControlKeys(kc *keychain.Keychain) ([]string, bool, error) {
@@ -585,7 +815,7 @@ func getControlKeys(kc *keychain.Keychain) ([]string, bool, error) {
 
 	listDecision, err := app.Prompt.CaptureList(moreKeysPrompt, listOptions)
 	if err != nil {
-		return nil, false, err
+		return nil, false, prompts.WithHint(err, "--control-keys or --same-control-key")
 	}
 
 	var (
@@ -635,12 +865,14 @@ func useAllKeys(network models.Network) ([]string, error) {
 	}
 
 	for _, kp := range keyPaths {
-		k, err := key.LoadSoft(network.ID, kp)
+		accounts, err := key.LoadAddresses(network.ID, kp)
 		if err != nil {
 			return nil, err
 		}
 
-		existing = append(existing, k.P()...)
+		for _, account := range accounts {
+			existing = append(existing, account.P)
+		}
 	}
 
 	return existing, nil
@@ -670,7 +902,7 @@ func controlKeysLoop(controlKeysPrompt string, network models.Network) ([]string
 	info := "Control keys are P-Chain addresses which have admin rights on the subnet.\n" +
 		"Only private keys which control such addresses are allowed to make changes on the subnet"
 	addressPrompt := "Enter P-Chain address (Example: P-...)"
-	return prompts.CaptureListDecision(
+	controlKeys, cancelled, err := prompts.CaptureListDecision(
 		// we need this to be able to mock test
 		app.Prompt,
 		// the main prompt for entering address keys
@@ -684,6 +916,7 @@ func controlKeysLoop(controlKeysPrompt string, network models.Network) ([]string
 		// optional parameter to allow the user to print the info string for more information
 		info,
 	)
+	return controlKeys, cancelled, prompts.WithHint(err, "--control-keys")
 }
 
 // getThreshold prompts for the threshold of addresses as a number
@@ -699,7 +932,7 @@ func getThreshold(maxLen int) (uint32, error) {
 	}
 	threshold, err := app.Prompt.CaptureList("Select required number of control key signatures to make a subnet change", indexList)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--threshold")
 	}
 	intTh, err := strconv.ParseUint(threshold, 0, 32)
 	if err != nil {
@@ -726,6 +959,12 @@ func ValidateSubnetNameAndGetChains(args []string) ([]string, error) {
 	}
 
 	if len(chains) == 0 {
+		if app.SidecarExists(args[0]) {
+			sc, err := app.LoadSidecar(args[0])
+			if err == nil && sc.IsChildChain() {
+				return nil, fmt.Errorf("%s is a chain of subnet %s, use the subnet name", args[0], sc.Subnet)
+			}
+		}
 		return nil, errors.New("Invalid subnet " + args[0])
 	}
 
@@ -759,7 +998,7 @@ func SaveNotFullySignedTx(
 			outputTxPath, err = app.Prompt.CaptureNewFilepath("Path to export partially signed tx to")
 		}
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--output-tx-path")
 		}
 	}
 	if forceOverwrite {
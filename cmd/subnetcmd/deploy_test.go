@@ -4,11 +4,16 @@ package subnetcmd
 
 import (
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/luxdefi/cli/cmd/flags"
 	"github.com/luxdefi/cli/internal/mocks"
 	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/config"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -210,3 +215,75 @@ func TestCheckForInvalidDeployAndSetLuxdVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestPrepareChains(t *testing.T) {
+	require := require.New(t)
+
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewNonInteractivePrompter(), application.NewDownloader())
+	defer func() {
+		app = nil
+	}()
+	chains := []string{"chain1", "chain2", "chain3"}
+	for _, c := range chains {
+		require.NoError(app.WriteGenesisFile(c, []byte("{}")))
+	}
+	sidecar := models.Sidecar{Name: chains[0], VM: models.CustomVM, Subnet: chains[0]}
+	require.NoError(app.CreateSidecar(&sidecar))
+	require.NoError(app.CreateSidecar(&models.Sidecar{Name: chains[1], VM: models.CustomVM, Subnet: chains[0]}))
+
+	// chain3 has no sidecar, so nothing is deployed
+	_, _, err := prepareChains(models.FujiNetwork, chains[0], sidecar, []byte("{}"), chains)
+	require.Error(err)
+
+	// a chain with an invalid genesis fails the whole deploy
+	require.NoError(app.CreateSidecar(&models.Sidecar{Name: chains[2], VM: models.SubnetEvm, Subnet: chains[0]}))
+	_, _, err = prepareChains(models.FujiNetwork, chains[0], sidecar, []byte("{}"), chains)
+	require.ErrorContains(err, chains[2])
+
+	sidecars, geneses, err := prepareChains(models.FujiNetwork, chains[0], sidecar, []byte("{}"), chains[:2])
+	require.NoError(err)
+	require.Len(sidecars, 2)
+	require.Len(geneses, 2)
+	require.Equal(chains[1], sidecars[chains[1]].Name)
+	require.Equal([]byte("{}"), geneses[chains[1]])
+}
+
+// the --mainnet-chain-id flag is only applied to the chain given to deploy,
+// not to the chains added to its subnet with add-chain
+func TestPrepareChainsMainnetChainID(t *testing.T) {
+	require := require.New(t)
+
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewNonInteractivePrompter(), application.NewDownloader())
+	defer func() {
+		app = nil
+		mainnetChainID = 0
+	}()
+	// the test genesis funds the ewoq address
+	t.Setenv(constants.SimulatePublicNetwork, "true")
+	genesisBytes, err := os.ReadFile("../../tests/e2e/assets/test_subnet_evm_genesis.json")
+	require.NoError(err)
+	parent, child := "parent", "child"
+	for _, c := range []string{parent, child} {
+		require.NoError(app.WriteGenesisFile(c, genesisBytes))
+	}
+	parentSidecar := models.Sidecar{Name: parent, VM: models.SubnetEvm, Subnet: parent}
+	require.NoError(app.CreateSidecar(&parentSidecar))
+	require.NoError(app.CreateSidecar(&models.Sidecar{Name: child, VM: models.SubnetEvm, Subnet: parent, SubnetEVMMainnetChainID: 200}))
+
+	// as deploySubnet does
+	mainnetChainID = 100
+	parentGenesis, err := prepareChainGenesis(models.MainnetNetwork, &parentSidecar, parent)
+	require.NoError(err)
+	sidecars, _, err := prepareChains(models.MainnetNetwork, parent, parentSidecar, parentGenesis, []string{parent, child})
+	require.NoError(err)
+	require.Equal(uint(100), sidecars[parent].SubnetEVMMainnetChainID)
+	require.Equal(uint(200), sidecars[child].SubnetEVMMainnetChainID)
+
+	for c, chainID := range map[string]uint{parent: 100, child: 200} {
+		sc, err := app.LoadSidecar(c)
+		require.NoError(err)
+		require.Equal(chainID, sc.SubnetEVMMainnetChainID)
+	}
+}
@@ -3,14 +3,18 @@
 package subnetcmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/netrunner/utils"
 	"github.com/luxdefi/node/ids"
@@ -80,9 +84,15 @@ func printDetails(genesis core.Genesis, sc models.Sidecar) {
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 
 	table.Append([]string{"Subnet Name", sc.Subnet})
+	if sc.IsChildChain() {
+		table.Append([]string{"Chain Name", sc.Name})
+	}
+	if chains, err := subnet.GetSubnetChains(app, sc.Subnet); err == nil && len(chains) > 1 {
+		table.Append([]string{"Chains", strings.Join(chains, ", ")})
+	}
 	table.Append([]string{"ChainID", genesis.Config.ChainID.String()})
 	table.Append([]string{"Mainnet ChainID", fmt.Sprint(sc.SubnetEVMMainnetChainID)})
-	table.Append([]string{"Token Name", app.GetTokenName(sc.Subnet)})
+	table.Append([]string{"Token Name", app.GetTokenName(sc.Name)})
 	table.Append([]string{"VM Version", sc.VMVersion})
 	if sc.ImportedVMID != "" {
 		table.Append([]string{"VM ID", sc.ImportedVMID})
@@ -256,7 +266,7 @@ func appendToAddressTable(
 
 func describeSubnetEvmGenesis(sc models.Sidecar) error {
 	// Load genesis
-	genesis, err := app.LoadEvmGenesis(sc.Subnet)
+	genesis, err := app.LoadEvmGenesis(sc.Name)
 	if err != nil {
 		return err
 	}
@@ -270,9 +280,162 @@ func describeSubnetEvmGenesis(sc models.Sidecar) error {
 	return nil
 }
 
+// subnetDescription is the structured (json/yaml) output of subnet describe
+type subnetDescription struct {
+	Subnet         string                        `json:"subnet"`
+	Chain          string                        `json:"chain"`
+	Chains         []string                      `json:"chains,omitempty"`
+	VM             string                        `json:"vm"`
+	VMVersion      string                        `json:"vmVersion"`
+	VMID           string                        `json:"vmID"`
+	ChainID        string                        `json:"chainID,omitempty"`
+	MainnetChainID uint                          `json:"mainnetChainID,omitempty"`
+	TokenName      string                        `json:"tokenName,omitempty"`
+	Networks       map[string]subnetNetworkEntry `json:"networks"`
+	FeeConfig      *feeConfigDescription         `json:"feeConfig,omitempty"`
+	Airdrop        []airdropDescription          `json:"airdrop,omitempty"`
+	Precompiles    []precompileDescription       `json:"precompiles,omitempty"`
+	// only set for non Subnet-EVM genesis, or if --genesis was given
+	Genesis json.RawMessage `json:"genesis,omitempty"`
+}
+
+type feeConfigDescription struct {
+	GasLimit                 string `json:"gasLimit"`
+	MinBaseFee               string `json:"minBaseFee"`
+	TargetGas                string `json:"targetGas"`
+	BaseFeeChangeDenominator string `json:"baseFeeChangeDenominator"`
+	MinBlockGasCost          string `json:"minBlockGasCost"`
+	MaxBlockGasCost          string `json:"maxBlockGasCost"`
+	TargetBlockRate          uint64 `json:"targetBlockRate"`
+	BlockGasCostStep         string `json:"blockGasCostStep"`
+}
+
+type airdropDescription struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+}
+
+type precompileDescription struct {
+	Precompile       string   `json:"precompile"`
+	AdminAddresses   []string `json:"adminAddresses"`
+	EnabledAddresses []string `json:"enabledAddresses"`
+}
+
+func newPrecompileDescription(label string, adminAddresses []common.Address, enabledAddresses []common.Address) precompileDescription {
+	desc := precompileDescription{
+		Precompile:       label,
+		AdminAddresses:   []string{},
+		EnabledAddresses: []string{},
+	}
+	for _, addr := range adminAddresses {
+		desc.AdminAddresses = append(desc.AdminAddresses, addr.Hex())
+	}
+	for _, addr := range enabledAddresses {
+		desc.EnabledAddresses = append(desc.EnabledAddresses, addr.Hex())
+	}
+	return desc
+}
+
+func getPrecompileDescriptions(genesis core.Genesis) []precompileDescription {
+	precompiles := []precompileDescription{}
+	if genesis.Config.GenesisPrecompiles[nativeminter.ConfigKey] != nil {
+		cfg := genesis.Config.GenesisPrecompiles[nativeminter.ConfigKey].(*nativeminter.Config)
+		precompiles = append(precompiles, newPrecompileDescription("Native Minter", cfg.AdminAddresses, cfg.EnabledAddresses))
+	}
+	if genesis.Config.GenesisPrecompiles[deployerallowlist.ConfigKey] != nil {
+		cfg := genesis.Config.GenesisPrecompiles[deployerallowlist.ConfigKey].(*deployerallowlist.Config)
+		precompiles = append(precompiles, newPrecompileDescription("Contract Allow List", cfg.AdminAddresses, cfg.EnabledAddresses))
+	}
+	if genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey] != nil {
+		cfg := genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
+		precompiles = append(precompiles, newPrecompileDescription("Tx Allow List", cfg.AdminAddresses, cfg.EnabledAddresses))
+	}
+	if genesis.Config.GenesisPrecompiles[feemanager.ConfigKey] != nil {
+		cfg := genesis.Config.GenesisPrecompiles[feemanager.ConfigKey].(*feemanager.Config)
+		precompiles = append(precompiles, newPrecompileDescription("Fee Config Allow List", cfg.AdminAddresses, cfg.EnabledAddresses))
+	}
+	if genesis.Config.GenesisPrecompiles[rewardmanager.ConfigKey] != nil {
+		cfg := genesis.Config.GenesisPrecompiles[rewardmanager.ConfigKey].(*rewardmanager.Config)
+		precompiles = append(precompiles, newPrecompileDescription("Reward Manager Allow List", cfg.AdminAddresses, cfg.EnabledAddresses))
+	}
+	return precompiles
+}
+
+func printStructuredDescription(sc models.Sidecar, subnetName string) error {
+	desc := subnetDescription{
+		Subnet:         sc.Subnet,
+		Chain:          sc.Name,
+		VM:             string(sc.VM),
+		VMVersion:      sc.VMVersion,
+		VMID:           getSidecarVMID(&sc),
+		MainnetChainID: sc.SubnetEVMMainnetChainID,
+		Networks:       newSubnetNetworkEntries(&sc),
+	}
+	chains, err := subnet.GetSubnetChains(app, sc.Subnet)
+	if err != nil {
+		return err
+	}
+	if len(chains) > 1 {
+		desc.Chains = chains
+	}
+	isEVM, err := hasSubnetEVMGenesis(subnetName)
+	if err != nil {
+		return err
+	}
+	if printGenesisOnly || !isEVM {
+		gen, err := os.ReadFile(app.GetGenesisPath(subnetName))
+		if err != nil {
+			return err
+		}
+		if json.Valid(gen) {
+			desc.Genesis = gen
+		} else {
+			// custom VMs may have non json genesis
+			desc.Genesis, err = json.Marshal(string(gen))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if isEVM {
+		genesis, err := app.LoadEvmGenesis(sc.Name)
+		if err != nil {
+			return err
+		}
+		desc.ChainID = genesis.Config.ChainID.String()
+		desc.TokenName = app.GetTokenName(sc.Name)
+		feeConfig := genesis.Config.FeeConfig
+		desc.FeeConfig = &feeConfigDescription{
+			GasLimit:                 feeConfig.GasLimit.String(),
+			MinBaseFee:               feeConfig.MinBaseFee.String(),
+			TargetGas:                feeConfig.TargetGas.String(),
+			BaseFeeChangeDenominator: feeConfig.BaseFeeChangeDenominator.String(),
+			MinBlockGasCost:          feeConfig.MinBlockGasCost.String(),
+			MaxBlockGasCost:          feeConfig.MaxBlockGasCost.String(),
+			TargetBlockRate:          feeConfig.TargetBlockRate,
+			BlockGasCostStep:         feeConfig.BlockGasCostStep.String(),
+		}
+		desc.Airdrop = []airdropDescription{}
+		for address, account := range genesis.Alloc {
+			desc.Airdrop = append(desc.Airdrop, airdropDescription{
+				Address: address.Hex(),
+				Balance: account.Balance.String(),
+			})
+		}
+		sort.Slice(desc.Airdrop, func(i, j int) bool {
+			return desc.Airdrop[i].Address < desc.Airdrop[j].Address
+		})
+		desc.Precompiles = getPrecompileDescriptions(genesis)
+	}
+	return ux.PrintDocument(os.Stdout, app.OutputFormat, "SubnetDescription", desc)
+}
+
 func readGenesis(_ *cobra.Command, args []string) error {
 	subnetName := args[0]
 	if !app.GenesisExists(subnetName) {
+		if app.OutputFormat.IsStructured() {
+			return fmt.Errorf("the provided subnet name %q does not exist", subnetName)
+		}
 		ux.Logger.PrintToUser("The provided subnet name %q does not exist", subnetName)
 		return nil
 	}
@@ -280,6 +443,9 @@ func readGenesis(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if app.OutputFormat.IsStructured() {
+		return printStructuredDescription(sc, subnetName)
+	}
 	if printGenesisOnly {
 		return printGenesis(sc, subnetName)
 	}
@@ -0,0 +1,41 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"errors"
+
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+const dryRunFlag = "dry-run"
+
+var (
+	dryRun bool
+
+	errDryRunOnLocal = errors.New("--dry-run is only supported on public networks")
+)
+
+// AddDryRunFlag adds the --dry-run flag to a command issuing P-Chain txs on
+// public networks
+func AddDryRunFlag(cmd *cobra.Command, dryRun *bool) {
+	cmd.Flags().BoolVar(dryRun, dryRunFlag, false, "build and print the transactions, their fees and required signatures, without issuing them [fuji/devnet/mainnet only]")
+}
+
+// CheckDryRun returns an error if a dry run is requested on [network], as
+// local operations don't issue public network txs
+func CheckDryRun(dryRun bool, network models.Network) error {
+	if dryRun && network.Kind == models.Local {
+		return errDryRunOnLocal
+	}
+	return nil
+}
+
+// PrintDryRunDone tells the user that the dry run finished without issuing
+// any tx, and that no local state was updated
+func PrintDryRunDone() {
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Dry run finished: no transaction was issued and no configuration was updated")
+}
@@ -18,7 +18,6 @@ import (
 	"github.com/luxdefi/cli/pkg/models"
 	"github.com/luxdefi/cli/pkg/prompts"
 	subnet "github.com/luxdefi/cli/pkg/subnet"
-	"github.com/luxdefi/cli/pkg/txutils"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/genesis"
@@ -56,7 +55,10 @@ func newElasticCmd() *cobra.Command {
 P-Chain. When enabling Elastic Validation, the creator permanently locks the Subnet from future modification 
 (they relinquish their control keys), specifies an Lux Native Token (ANT) that validators must use for staking 
 and that will be distributed as staking rewards, and provides a set of parameters that govern how the Subnet’s staking 
-mechanics will work.`,
+mechanics will work.
+
+On Fuji, --dry-run builds and signs the asset creation, export, import and transform transactions without
+issuing them, and prints their fees and the control key signatures they require.`,
 		SilenceUsage:      true,
 		Args:              cobra.ExactArgs(1),
 		RunE:              transformElasticSubnet,
@@ -79,6 +81,7 @@ mechanics will work.`,
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
 	cmd.Flags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "control keys that will be used to authenticate the transformSubnet tx")
 	cmd.Flags().StringVar(&outputTxPath, "output-tx-path", "", "file path of the transformSubnet tx")
+	AddDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -147,6 +150,9 @@ func importFromXChain(deployer *subnet.PublicDeployer,
 
 func promptDeployFirst(cmd *cobra.Command, args []string, prompt string, err error) error {
 	yes, promptErr := app.Prompt.CaptureNoYes(prompt)
+	if errors.Is(promptErr, prompts.ErrNonInteractive) {
+		return fmt.Errorf("subnet %s must be created and deployed first, with lux subnet create and lux subnet deploy", args[0])
+	}
 	if promptErr != nil {
 		return promptErr
 	}
@@ -213,6 +219,10 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := CheckDryRun(dryRun, network); err != nil {
+		return err
+	}
+
 	if outputTxPath != "" {
 		if _, err := os.Stat(outputTxPath); err == nil {
 			return fmt.Errorf("outputTxPath %q already exists", outputTxPath)
@@ -310,6 +320,8 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 
 	recipientAddr := kc.Addresses().List()[0]
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
+	deployer.SetJournal(subnetName)
 	txHasOccurred, txID := checkIfTxHasOccurred(&sc, network, "CreateAssetTx")
 	var assetID ids.ID
 	// TODO: replace sleep functions with sticky API sessions
@@ -321,12 +333,9 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "CreateAssetTx", assetID)
-		if err != nil {
+		if err := savePartialElasticTx(&sc, network, "CreateAssetTx", assetID); err != nil {
 			return err
 		}
-		// we need to sleep after each operation to make sure that UTXO is available for consumption
-		time.Sleep(5 * time.Second)
 	}
 
 	txHasOccurred, _ = checkIfTxHasOccurred(&sc, network, "ExportTx")
@@ -335,11 +344,9 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "ExportTx", txID)
-		if err != nil {
+		if err := savePartialElasticTx(&sc, network, "ExportTx", txID); err != nil {
 			return err
 		}
-		time.Sleep(5 * time.Second)
 	} else {
 		ux.Logger.PrintToUser("Skipping ExportTx...")
 	}
@@ -350,16 +357,14 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		err = app.UpdateSidecarElasticSubnetPartialTx(&sc, network, "ImportTx", txID)
-		if err != nil {
+		if err := savePartialElasticTx(&sc, network, "ImportTx", txID); err != nil {
 			return err
 		}
-		time.Sleep(5 * time.Second)
 	} else {
 		ux.Logger.PrintToUser("Skipping ImportTx...")
 	}
 
-	controlKeys, threshold, err := txutils.GetOwners(network, subnetID)
+	controlKeys, threshold, err := deployer.GetOwners(subnetID)
 	if err != nil {
 		return err
 	}
@@ -391,6 +396,10 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		PrintDryRunDone()
+		return nil
+	}
 	flags := make(map[string]string)
 	flags[constants.Network] = network.Name()
 	if !isFullySigned {
@@ -424,6 +433,21 @@ func transformElasticSubnet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// records [txID] as the [txName] step of the transformation, so that it is
+// skipped if the command is run again. On a dry run nothing is recorded, as
+// the tx was not issued
+func savePartialElasticTx(sc *models.Sidecar, network models.Network, txName string, txID ids.ID) error {
+	if dryRun {
+		return nil
+	}
+	if err := app.UpdateSidecarElasticSubnetPartialTx(sc, network, txName, txID); err != nil {
+		return err
+	}
+	// we need to sleep after each operation to make sure that UTXO is available for consumption
+	time.Sleep(5 * time.Second)
+	return nil
+}
+
 func transformElasticSubnetLocal(sc models.Sidecar, subnetName string, tokenName string, tokenSymbol string, elasticSubnetConfig models.ElasticSubnetConfig, cmd *cobra.Command) error {
 	if checkIfSubnetIsElasticOnLocal(sc) {
 		return fmt.Errorf("%s is already an elastic subnet", subnetName)
@@ -437,7 +461,7 @@ func transformElasticSubnetLocal(sc models.Sidecar, subnetName string, tokenName
 	if !overrideWarning {
 		yes, err := app.Prompt.CaptureNoYes("WARNING: Transforming a Permissioned Subnet into an Elastic Subnet is an irreversible operation. Continue?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--force")
 		}
 		if !yes {
 			return nil
@@ -470,7 +494,7 @@ func transformElasticSubnetLocal(sc models.Sidecar, subnetName string, tokenName
 			yes, err := app.Prompt.CaptureNoYes("Do you want to transform existing validators to permissionless validators with equal weight? " +
 				"Press <No> if you want to customize the structure of your permissionless validators")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--transform-validators")
 			}
 			if !yes {
 				return nil
@@ -514,7 +538,7 @@ func promptNetworkElastic(sc models.Sidecar, prompt string) (string, error) {
 
 	selectedDeployment, err := app.Prompt.CaptureList(prompt, networkOptions)
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--local or --fuji/--testnet")
 	}
 	return selectedDeployment, nil
 }
@@ -544,7 +568,7 @@ func selectNetworkToTransform(sc models.Sidecar) (string, error) {
 
 	selectedDeployment, err := app.Prompt.CaptureList(networkPrompt, networkOptions)
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--local or --fuji/--testnet")
 	}
 	return selectedDeployment, nil
 }
@@ -575,7 +599,7 @@ func getTokenName() (string, error) {
 	ux.Logger.PrintToUser("Select a name for your subnet's native token")
 	tokenName, err := app.Prompt.CaptureString("Token name")
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--tokenName")
 	}
 	return tokenName, nil
 }
@@ -584,7 +608,7 @@ func getTokenSymbol() (string, error) {
 	ux.Logger.PrintToUser("Select a symbol for your subnet's native token")
 	tokenSymbol, err := app.Prompt.CaptureString("Token symbol")
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--tokenSymbol")
 	}
 	return tokenSymbol, nil
 }
@@ -697,7 +721,7 @@ func getTokenDenomination() (int, error) {
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--denomination")
 	}
 	return tokenDenomination, nil
 }
@@ -30,7 +30,7 @@ func newExportCmd() *cobra.Command {
 		Long: `The subnet export command write the details of an existing Subnet deploy to a file.
 
 The command prompts for an output path. You can also provide one with
-the --output flag.`,
+the --output-file flag.`,
 		RunE:         exportSubnet,
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
@@ -38,7 +38,7 @@ the --output flag.`,
 
 	cmd.Flags().StringVarP(
 		&exportOutput,
-		"output",
+		"output-file",
 		"o",
 		"",
 		"write the export data to the provided file path",
@@ -60,7 +60,7 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 		pathPrompt := "Enter file path to write export data to"
 		exportOutput, err = app.Prompt.CaptureString(pathPrompt)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--output-file")
 		}
 	}
 
@@ -88,7 +88,7 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 			if customVMRepoURL == "" {
 				customVMRepoURL, err = app.Prompt.CaptureURL("Source code repository URL")
 				if err != nil {
-					return err
+					return prompts.WithHint(err, "--custom-vm-repo-url")
 				}
 			}
 			if customVMBranch != "" {
@@ -101,7 +101,7 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 			if customVMBranch == "" {
 				customVMBranch, err = app.Prompt.CaptureRepoBranch("Branch", customVMRepoURL)
 				if err != nil {
-					return err
+					return prompts.WithHint(err, "--custom-vm-branch")
 				}
 			}
 			if customVMBuildScript != "" {
@@ -114,7 +114,7 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 			if customVMBuildScript == "" {
 				customVMBuildScript, err = app.Prompt.CaptureRepoFile("Build script", customVMRepoURL, customVMBranch)
 				if err != nil {
-					return err
+					return prompts.WithHint(err, "--custom-vm-build-script")
 				}
 			}
 			sc.CustomVMRepoURL = customVMRepoURL
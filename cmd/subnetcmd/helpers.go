@@ -8,6 +8,7 @@ import (
 
 	"github.com/luxdefi/cli/cmd/flags"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/utils"
 	"golang.org/x/exp/slices"
 )
@@ -16,7 +17,7 @@ func fillNetworkDetails(network *models.Network) error {
 	if network.Endpoint == "" {
 		endpoint, err := app.Prompt.CaptureString(fmt.Sprintf("%s Network Endpoint", network.Name()))
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--endpoint")
 		}
 		network.Endpoint = endpoint
 	}
@@ -49,6 +50,15 @@ func GetNetworkFromCmdLineFlags(
 		network.Endpoint = endpoint
 	}
 
+	// for err messages
+	networkFlags := map[models.NetworkKind]string{
+		models.Local:   "--local",
+		models.Devnet:  "--devnet",
+		models.Fuji:    "--fuji/--testnet",
+		models.Mainnet: "--mainnet",
+	}
+	supportedNetworksFlags := strings.Join(utils.Map(supportedNetworkKinds, func(n models.NetworkKind) string { return networkFlags[n] }), ", ")
+
 	// no flag was set, prompt user
 	if network.Kind == models.Undefined {
 		networkStr, err := app.Prompt.CaptureList(
@@ -56,7 +66,7 @@ func GetNetworkFromCmdLineFlags(
 			utils.Map(supportedNetworkKinds, func(n models.NetworkKind) string { return n.String() }),
 		)
 		if err != nil {
-			return models.UndefinedNetwork, err
+			return models.UndefinedNetwork, prompts.WithHint(err, "one of "+supportedNetworksFlags)
 		}
 		network = models.NetworkFromString(networkStr)
 		if askForDevnetEndpoint {
@@ -67,15 +77,6 @@ func GetNetworkFromCmdLineFlags(
 		return network, nil
 	}
 
-	// for err messages
-	networkFlags := map[models.NetworkKind]string{
-		models.Local:   "--local",
-		models.Devnet:  "--devnet",
-		models.Fuji:    "--fuji/--testnet",
-		models.Mainnet: "--mainnet",
-	}
-	supportedNetworksFlags := strings.Join(utils.Map(supportedNetworkKinds, func(n models.NetworkKind) string { return networkFlags[n] }), ", ")
-
 	// unsupported network
 	if !slices.Contains(supportedNetworkKinds, network.Kind) {
 		return models.UndefinedNetwork, fmt.Errorf("network flag %s is not supported. use one of %s", networkFlags[network.Kind], supportedNetworksFlags)
@@ -14,6 +14,7 @@ import (
 	"github.com/luxdefi/cli/pkg/lpmintegration"
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/cli/pkg/vm"
 	"github.com/spf13/cobra"
@@ -83,7 +84,7 @@ func importSubnet(_ *cobra.Command, args []string) error {
 		promptStr := "Would you like to import your subnet from a file or a repository?"
 		result, err := app.Prompt.CaptureList(promptStr, typeOptions)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "a subnetPath argument, or --repo and --subnet")
 		}
 
 		if result == fileOption {
@@ -101,7 +102,7 @@ func importFromFile(importPath string) error {
 		promptStr := "Select the file to import your subnet from"
 		importPath, err = app.Prompt.CaptureExistingFilepath(promptStr)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "a subnetPath argument")
 		}
 	}
 
@@ -237,7 +238,7 @@ func importFromLPM() error {
 		promptStr := "What repo would you like to import from"
 		repoAlias, err = app.Prompt.CaptureList(promptStr, installedRepos)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--repo")
 		}
 	}
 
@@ -246,7 +247,7 @@ func importFromLPM() error {
 			promptStr = "Enter your repo URL"
 			repoURL, err = app.Prompt.CaptureGitURL(promptStr)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--repo")
 			}
 		}
 
@@ -258,7 +259,7 @@ func importFromLPM() error {
 			promptStr = "What branch would you like to import from"
 			branch, err = app.Prompt.CaptureList(promptStr, branchList)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--branch")
 			}
 		}
 
@@ -293,7 +294,7 @@ func importFromLPM() error {
 		promptStr = "Select a subnet to import"
 		subnet, err = app.Prompt.CaptureList(promptStr, subnets)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--subnet")
 		}
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/cli/pkg/vm"
@@ -88,7 +89,7 @@ func importRunningSubnet(*cobra.Command, []string) error {
 			[]string{models.Fuji.String(), models.Mainnet.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--fuji/--testnet or --mainnet")
 		}
 		network = models.NetworkFromString(networkStr)
 	}
@@ -96,7 +97,7 @@ func importRunningSubnet(*cobra.Command, []string) error {
 	if genesisFilePath == "" {
 		genesisFilePath, err = app.Prompt.CaptureExistingFilepath("Provide the path to the genesis file")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--genesis-file-path")
 		}
 	}
 
@@ -105,13 +106,13 @@ func importRunningSubnet(*cobra.Command, []string) error {
 	if nodeURL == "" {
 		yes, err := app.Prompt.CaptureYesNo("Have nodes already been deployed to this subnet?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--node-url")
 		}
 		if yes {
 			nodeURL, err = app.Prompt.CaptureString(
 				"Please provide an API URL of such a node so we can query its VM version (e.g. http://111.22.33.44:5555)")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--node-url")
 			}
 			ctx, cancel := utils.GetAPIContext()
 			defer cancel()
@@ -128,7 +129,7 @@ func importRunningSubnet(*cobra.Command, []string) error {
 	if blockchainIDstr == "" {
 		blockchainID, err = app.Prompt.CaptureID("What is the ID of the blockchain?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--blockchain-id")
 		}
 	} else {
 		blockchainID, err = ids.FromString(blockchainIDstr)
@@ -194,7 +195,7 @@ func importRunningSubnet(*cobra.Command, []string) error {
 			[]string{models.SubnetEvm, models.CustomVM},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--evm or --custom")
 		}
 		vmType = models.VMTypeFromString(subnetTypeStr)
 	}
@@ -244,7 +245,7 @@ func importRunningSubnet(*cobra.Command, []string) error {
 			return fmt.Errorf("unexpected VM type: %v", vmType)
 		}
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--node-url")
 		}
 		sc.RPCVersion, err = vm.GetRPCProtocolVersion(app, vmType, sc.VMVersion)
 		if err != nil {
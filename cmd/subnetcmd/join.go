@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/luxdefi/cli/cmd/flags"
@@ -71,7 +72,10 @@ After you update your validator's config, you need to restart your validator man
 you provide the --node-config flag, this command attempts to edit the config file
 at that path.
 
-This command currently only supports Subnets deployed on the Fuji Testnet and Mainnet.`,
+This command currently only supports Subnets deployed on the Fuji Testnet and Mainnet.
+
+When joining an elastic Subnet on Fuji, --dry-run builds and signs the add permissionless
+validator transaction without issuing it, and prints its fee and the funding addresses balances.`,
 		RunE: joinCmd,
 		Args: cobra.ExactArgs(1),
 	}
@@ -93,6 +97,7 @@ This command currently only supports Subnets deployed on the Fuji Testnet and Ma
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	AddDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -149,7 +154,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 				[]string{models.Fuji.String(), models.Mainnet.String()},
 			)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--fuji/--testnet or --mainnet")
 			}
 			network = models.NetworkFromString(networkStr)
 		}
@@ -159,6 +164,10 @@ func joinCmd(_ *cobra.Command, args []string) error {
 		return handleValidatorJoinElasticSubnet(sc, network, subnetName)
 	}
 
+	if dryRun {
+		return errors.New("--dry-run is only supported when joining an elastic subnet")
+	}
+
 	network.HandlePublicNetworkSimulation()
 
 	subnetID := sc.Networks[network.Name()].SubnetID
@@ -169,11 +178,11 @@ func joinCmd(_ *cobra.Command, args []string) error {
 
 	if printManual {
 		pluginDir = app.GetTmpPluginDir()
-		vmPath, err := plugins.CreatePlugin(app, sc.Name, pluginDir)
+		vmPaths, err := createChainPlugins(chains, pluginDir)
 		if err != nil {
 			return err
 		}
-		printJoinCmd(subnetIDStr, network, vmPath)
+		printJoinCmd(subnetIDStr, network, strings.Join(vmPaths, ", "))
 		return nil
 	}
 
@@ -190,15 +199,15 @@ func joinCmd(_ *cobra.Command, args []string) error {
 			[]string{choiceAutomatic, choiceManual},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--node-config and --plugin-dir, or --print")
 		}
 		if choice == choiceManual {
 			pluginDir = app.GetTmpPluginDir()
-			vmPath, err := plugins.CreatePlugin(app, sc.Name, pluginDir)
+			vmPaths, err := createChainPlugins(chains, pluginDir)
 			if err != nil {
 				return err
 			}
-			printJoinCmd(subnetIDStr, network, vmPath)
+			printJoinCmd(subnetIDStr, network, strings.Join(vmPaths, ", "))
 			return nil
 		}
 	}
@@ -215,7 +224,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 			ux.Logger.PrintToUser(logging.Bold.Wrap(logging.Green.Wrap("Found a config file at %s")), luxdConfigPath)
 			yes, err := app.Prompt.CaptureYesNo("Is this the file we should update?")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--node-config")
 			}
 			if yes {
 				ux.Logger.PrintToUser("Will use file at path %s to update the configuration", luxdConfigPath)
@@ -226,7 +235,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 		if luxdConfigPath == "" {
 			luxdConfigPath, err = app.Prompt.CaptureString("Path to your existing config file (or where it will be generated)")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--node-config")
 			}
 		}
 	}
@@ -248,7 +257,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 			ux.Logger.PrintToUser(logging.Bold.Wrap(logging.Green.Wrap("Found the VM plugin directory at %s")), pluginDir)
 			yes, err := app.Prompt.CaptureYesNo("Is this where we should install the VM?")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--plugin-dir")
 			}
 			if yes {
 				ux.Logger.PrintToUser("Will use plugin directory at %s to install the VM", pluginDir)
@@ -259,7 +268,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 		if pluginDir == "" {
 			pluginDir, err = app.Prompt.CaptureString("Path to your node plugin dir (likely node/build/plugins)")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--plugin-dir")
 			}
 		}
 	}
@@ -270,16 +279,24 @@ func joinCmd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	vmPath, err := plugins.CreatePlugin(app, sc.Name, pluginDir)
+	vmPaths, err := createChainPlugins(chains, pluginDir)
 	if err != nil {
 		return err
 	}
 
-	ux.Logger.PrintToUser("VM binary written to %s", vmPath)
+	for _, vmPath := range vmPaths {
+		ux.Logger.PrintToUser("VM binary written to %s", vmPath)
+	}
 
 	if forceWrite {
-		if err := writeLuxdChainConfigFiles(app, dataDir, subnetName, sc, network); err != nil {
-			return err
+		for _, chain := range chains {
+			chainSc, err := app.LoadSidecar(chain)
+			if err != nil {
+				return err
+			}
+			if err := writeLuxdChainConfigFiles(app, dataDir, chain, chainSc, network); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -302,6 +319,19 @@ func joinCmd(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// writes the VM binaries of all the chains of the subnet into [pluginDir]
+func createChainPlugins(chains []string, pluginDir string) ([]string, error) {
+	vmPaths := []string{}
+	for _, chain := range chains {
+		vmPath, err := plugins.CreatePlugin(app, chain, pluginDir)
+		if err != nil {
+			return nil, err
+		}
+		vmPaths = append(vmPaths, vmPath)
+	}
+	return vmPaths, nil
+}
+
 func writeLuxdChainConfigFiles(
 	app *application.Lux,
 	dataDir string,
@@ -387,6 +417,10 @@ func handleValidatorJoinElasticSubnet(sc models.Sidecar, network models.Network,
 		return ErrMutuallyExlusiveKeyLedger
 	}
 
+	if err := CheckDryRun(dryRun, network); err != nil {
+		return err
+	}
+
 	subnetID := sc.Networks[network.Name()].SubnetID
 	if os.Getenv(constants.SimulatePublicNetwork) != "" {
 		subnetID = sc.Networks[models.Local.String()].SubnetID
@@ -437,6 +471,8 @@ func handleValidatorJoinElasticSubnet(sc models.Sidecar, network models.Network,
 
 	recipientAddr := kc.Addresses().List()[0]
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
+	deployer.SetJournal(subnetName)
 	assetID, err := getSubnetAssetID(subnetID, network)
 	if err != nil {
 		return err
@@ -446,6 +482,10 @@ func handleValidatorJoinElasticSubnet(sc models.Sidecar, network models.Network,
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		PrintDryRunDone()
+		return nil
+	}
 	printAddPermissionlessValOutput(txID, nodeID, network, start, endTime, stakedTokenAmount)
 	if err = app.UpdateSidecarPermissionlessValidator(&sc, network, nodeID.String(), txID); err != nil {
 		return fmt.Errorf("joining permissionless subnet was successful, but failed to update sidecar: %w", err)
@@ -567,7 +607,8 @@ func promptNodeIDToAdd(subnetID ids.ID, isValidator bool, network models.Network
 				promptStr = "Please enter the Node ID of the validator that you would like to delegate to"
 			}
 			ux.Logger.PrintToUser(promptStr)
-			return app.Prompt.CaptureNodeID("Node ID (format it as NodeID-<node_id>)")
+			nodeID, err := app.Prompt.CaptureNodeID("Node ID (format it as NodeID-<node_id>)")
+			return nodeID, prompts.WithHint(err, "--nodeID")
 		}
 		defaultLocalNetworkNodeIDs, err := getLocalNetworkIDs()
 		if err != nil {
@@ -601,7 +642,7 @@ func promptNodeIDToAdd(subnetID ids.ID, isValidator bool, network models.Network
 		}
 		nodeIDStr, err = app.Prompt.CaptureList(promptStr, validatorList)
 		if err != nil {
-			return ids.EmptyNodeID, err
+			return ids.EmptyNodeID, prompts.WithHint(err, "--nodeID")
 		}
 	}
 	nodeID, err := ids.NodeIDFromString(nodeIDStr)
@@ -630,7 +671,7 @@ func promptStakeAmount(subnetName string, isValidator bool, network models.Netwo
 		weightOptions := []string{maxValidatorStake, customWeight}
 		weightOption, err := app.Prompt.CaptureList(txt, weightOptions)
 		if err != nil {
-			return 0, err
+			return 0, prompts.WithHint(err, "--stake-amount")
 		}
 		pClient := platformvm.NewClient(constants.LocalAPIEndpoint)
 		walletBalance, err := getAssetBalance(pClient, ewoqPChainAddr, esc.AssetID)
@@ -647,7 +688,7 @@ func promptStakeAmount(subnetName string, isValidator bool, network models.Netwo
 		case maxValidatorStake:
 			return esc.MaxValidatorStake, nil
 		default:
-			return app.Prompt.CaptureUint64Compare(
+			stake, err := app.Prompt.CaptureUint64Compare(
 				txt,
 				[]prompts.Comparator{
 					{
@@ -667,12 +708,13 @@ func promptStakeAmount(subnetName string, isValidator bool, network models.Netwo
 					},
 				},
 			)
+			return stake, prompts.WithHint(err, "--stake-amount")
 		}
 	}
 	ux.Logger.PrintToUser("What amount of the subnet native token would you like to stake?")
 	initialSupply, err := app.Prompt.CaptureUint64("Stake amount")
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--stake-amount")
 	}
 	return initialSupply, nil
 }
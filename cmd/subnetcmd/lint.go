@@ -0,0 +1,102 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnetcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/luxdefi/cli/cmd/flags"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet/lint"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintLocal   bool
+	lintTestnet bool
+	lintMainnet bool
+)
+
+// lux subnet lint
+func newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [subnetName]",
+		Short: "Check a subnet's configuration files for inconsistencies",
+		Long: `The subnet lint command loads the genesis, chain config, subnet config, node config
+and upgrade file of a subnet and checks them against each other.
+
+Findings are reported from most to least severe. The command fails if any
+finding is an error, so it can be used to gate deployments.
+
+Pass the network you are going to deploy to, so that checks that only apply
+to public networks (such as the ewoq address being funded) are reported as errors.`,
+		RunE:         lintSubnet,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVarP(&lintLocal, "local", "l", false, "lint for a local network deploy")
+	cmd.Flags().BoolVarP(&lintTestnet, "testnet", "t", false, "lint for a testnet deploy (alias to `fuji`)")
+	cmd.Flags().BoolVarP(&lintTestnet, "fuji", "f", false, "lint for a fuji deploy (alias to `testnet`)")
+	cmd.Flags().BoolVarP(&lintMainnet, "mainnet", "m", false, "lint for a mainnet deploy")
+	return cmd
+}
+
+func lintSubnet(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	if !flags.EnsureMutuallyExclusive([]bool{lintLocal, lintTestnet, lintMainnet}) {
+		return errMutuallyExlusiveNetworks
+	}
+	if !app.SidecarExists(subnetName) {
+		return fmt.Errorf("subnet %s does not exist", subnetName)
+	}
+
+	network := models.UndefinedNetwork
+	switch {
+	case lintLocal:
+		network = models.LocalNetwork
+	case lintTestnet:
+		network = models.FujiNetwork
+	case lintMainnet:
+		network = models.MainnetNetwork
+	}
+
+	report, err := lint.Subnet(app, subnetName, network)
+	if err != nil {
+		return err
+	}
+
+	if app.OutputFormat.IsStructured() {
+		if err := ux.PrintDocument(os.Stdout, app.OutputFormat, "SubnetLintReport", report); err != nil {
+			return err
+		}
+	} else {
+		printLintReport(report)
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("subnet %s has %d configuration errors", subnetName, report.Count(lint.Error))
+	}
+	return nil
+}
+
+func printLintReport(report *lint.Report) {
+	if len(report.Findings) == 0 {
+		ux.Logger.PrintToUser("No issues found in the configuration of subnet %s", report.Subnet)
+		return
+	}
+	header := []string{"Severity", "File", "Check", "Message"}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(true)
+	for _, f := range report.Findings {
+		table.Append([]string{f.Severity.String(), f.File, f.Check, f.Message})
+	}
+	table.Render()
+	ux.Logger.PrintToUser("%d errors, %d warnings, %d infos",
+		report.Count(lint.Error), report.Count(lint.Warning), report.Count(lint.Info))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
 	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/netrunner/utils"
 	"github.com/luxdefi/node/ids"
 	"github.com/olekukonko/tablewriter"
@@ -46,12 +47,126 @@ func (c subnetMatrix) Swap(i, j int) {
 	c[i], c[j] = c[j], c[i]
 }
 
-// Compare strings by first key of the sub-slice
+// Compare strings by first key of the sub-slice, and then by chain, with the
+// chain created with the subnet first
 func (c subnetMatrix) Less(i, j int) bool {
-	return strings.Compare(c[i][0], c[j][0]) == -1
+	if c[i][0] != c[j][0] {
+		return strings.Compare(c[i][0], c[j][0]) == -1
+	}
+	return lessChain(c[i][0], c[i][1], c[j][1])
+}
+
+func lessChain(subnetName string, chain1 string, chain2 string) bool {
+	if chain1 == subnetName || chain2 == subnetName {
+		return chain1 == subnetName && chain2 != subnetName
+	}
+	return strings.Compare(chain1, chain2) == -1
+}
+
+// subnetListEntry is the structured (json/yaml) representation of a sidecar
+// as printed by subnet list
+type subnetListEntry struct {
+	Subnet          string                        `json:"subnet"`
+	Chain           string                        `json:"chain"`
+	ChainID         string                        `json:"chainID"`
+	VMID            string                        `json:"vmID"`
+	VM              string                        `json:"vm"`
+	VMVersion       string                        `json:"vmVersion"`
+	ImportedFromLPM bool                          `json:"importedFromLPM"`
+	DeployedLocally *bool                         `json:"deployedLocally,omitempty"`
+	Networks        map[string]subnetNetworkEntry `json:"networks"`
+}
+
+type subnetNetworkEntry struct {
+	SubnetID     string `json:"subnetID,omitempty"`
+	BlockchainID string `json:"blockchainID,omitempty"`
+}
+
+func newSubnetNetworkEntries(sc *models.Sidecar) map[string]subnetNetworkEntry {
+	networks := map[string]subnetNetworkEntry{}
+	for net, data := range sc.Networks {
+		entry := subnetNetworkEntry{}
+		if data.SubnetID != ids.Empty {
+			entry.SubnetID = data.SubnetID.String()
+		}
+		if data.BlockchainID != ids.Empty {
+			entry.BlockchainID = data.BlockchainID.String()
+		}
+		networks[net] = entry
+	}
+	return networks
+}
+
+func getSidecarVMID(sc *models.Sidecar) string {
+	vmID := sc.ImportedVMID
+	if vmID == "" {
+		id, err := utils.VMID(sc.Name)
+		if err != nil {
+			vmID = constants.NotAvailableLabel
+		} else {
+			vmID = id.String()
+		}
+	}
+	return vmID
+}
+
+func getSidecarChainID(sc *models.Sidecar) string {
+	chainID := sc.ChainID
+	// for older sidecars, check in genesis if sidecar has
+	// no chainID set
+	if chainID == "" {
+		gen, err := app.LoadEvmGenesis(sc.Name)
+		// ignore the error in this case: just leave it to ""
+		if err == nil {
+			chainID = gen.Config.ChainID.String()
+		}
+	}
+	return chainID
+}
+
+func printStructuredSubnetList() error {
+	cars, err := getSidecars(app)
+	if err != nil {
+		return err
+	}
+	var deployedNames map[string]struct{}
+	if deployed {
+		deployedNames, err = subnet.GetLocallyDeployedSubnets()
+		if err != nil {
+			app.Log.Warn("problem contacting server to get deployed subnets")
+		}
+	}
+	entries := []subnetListEntry{}
+	for _, sc := range cars {
+		entry := subnetListEntry{
+			Subnet:          sc.Subnet,
+			Chain:           sc.Name,
+			ChainID:         getSidecarChainID(sc),
+			VMID:            getSidecarVMID(sc),
+			VM:              string(sc.VM),
+			VMVersion:       sc.VMVersion,
+			ImportedFromLPM: sc.ImportedFromLPM,
+			Networks:        newSubnetNetworkEntries(sc),
+		}
+		if deployed {
+			_, ok := deployedNames[sc.Name]
+			entry.DeployedLocally = &ok
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Subnet != entries[j].Subnet {
+			return entries[i].Subnet < entries[j].Subnet
+		}
+		return lessChain(entries[i].Subnet, entries[i].Chain, entries[j].Chain)
+	})
+	return ux.PrintDocument(os.Stdout, app.OutputFormat, "SubnetList", entries)
 }
 
 func listSubnets(cmd *cobra.Command, args []string) error {
+	if app.OutputFormat.IsStructured() {
+		return printStructuredSubnetList()
+	}
 	if deployed {
 		return listDeployInfo(cmd, args)
 	}
@@ -69,37 +184,17 @@ func listSubnets(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	for _, sc := range cars {
-		chainID := sc.ChainID
-		// for older sidecars, check in genesis if sidecar has
-		// no chainID set
-		if chainID == "" {
-			sc, err := app.LoadEvmGenesis(sc.Name)
-			// ignore the error in this case: just leave it to ""
-			if err == nil {
-				chainID = sc.Config.ChainID.String()
-			}
-		}
-
-		vmID := sc.ImportedVMID
-		if vmID == "" {
-			id, err := utils.VMID(sc.Name)
-			if err != nil {
-				vmID = constants.NotAvailableLabel
-			} else {
-				vmID = id.String()
-			}
-		}
 		rows = append(rows, []string{
 			sc.Subnet,
 			sc.Name,
-			chainID,
-			vmID,
+			getSidecarChainID(sc),
+			getSidecarVMID(sc),
 			string(sc.VM),
 			sc.VMVersion,
 			strconv.FormatBool(sc.ImportedFromLPM),
 		})
 	}
-	sort.Sort(rows)
+	sort.Stable(rows)
 	for _, row := range rows {
 		table.Append(row)
 	}
@@ -108,7 +203,7 @@ func listSubnets(cmd *cobra.Command, args []string) error {
 }
 
 func getSidecars(app *application.Lux) ([]*models.Sidecar, error) {
-	subnets, err := os.ReadDir(filepath.Join(app.GetBaseDir(), constants.SubnetDir))
+	subnets, err := os.ReadDir(app.GetSubnetDir())
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +263,7 @@ func listDeployInfo(*cobra.Command, []string) error {
 	for _, sc := range cars {
 		netToID := map[string][]string{}
 		deployedLocal := constants.NoLabel
-		if _, ok := deployedNames[sc.Subnet]; ok {
+		if _, ok := deployedNames[sc.Name]; ok {
 			deployedLocal = constants.YesLabel
 		}
 		if _, ok := sc.Networks[fujiKey]; ok {
@@ -193,15 +288,7 @@ func listDeployInfo(*cobra.Command, []string) error {
 		} else {
 			netToID[mainKey] = []string{constants.NoLabel, constants.NoLabel}
 		}
-		vmID := sc.ImportedVMID
-		if vmID == "" {
-			id, err := utils.VMID(sc.Name)
-			if err != nil {
-				vmID = constants.NotAvailableLabel
-			} else {
-				vmID = id.String()
-			}
-		}
+		vmID := getSidecarVMID(sc)
 
 		rows = append(rows, []string{
 			sc.Subnet,
@@ -224,7 +311,7 @@ func listDeployInfo(*cobra.Command, []string) error {
 		}
 	}
 
-	sort.Sort(rows)
+	sort.Stable(rows)
 	for _, row := range rows {
 		table.Append(row)
 	}
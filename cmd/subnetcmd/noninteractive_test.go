@@ -0,0 +1,137 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/config"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+var flagRegex = regexp.MustCompile(`--([a-zA-Z][a-zA-Z0-9-]*)`)
+
+// every command run without flags in non interactive mode must fail naming
+// the flags that answer the prompt it stopped at
+func TestNonInteractiveHints(t *testing.T) {
+	const testSubnet = "testSubnet"
+
+	type test struct {
+		name   string
+		newCmd func() *cobra.Command
+		args   []string
+	}
+	tests := []test{
+		{
+			name:   "create",
+			newCmd: newCreateCmd,
+			args:   []string{"newSubnet"},
+		},
+		{
+			name:   "deploy",
+			newCmd: newDeployCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "addValidator",
+			newCmd: newAddValidatorCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "removeValidator",
+			newCmd: newRemoveValidatorCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "join",
+			newCmd: newJoinCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "validators",
+			newCmd: newValidatorsCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "stats",
+			newCmd: newStatsCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "configure",
+			newCmd: newConfigureCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "export",
+			newCmd: newExportCmd,
+			args:   []string{testSubnet},
+		},
+		{
+			name:   "import file",
+			newCmd: newImportFileCmd,
+			args:   []string{},
+		},
+		{
+			name:   "import public",
+			newCmd: newImportFromNetworkCmd,
+			args:   []string{},
+		},
+		{
+			name:   "publish",
+			newCmd: newPublishCmd,
+			args:   []string{testSubnet},
+		},
+	}
+
+	testDir := t.TempDir()
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	app = application.New()
+	app.Setup(testDir, logging.NoLog{}, config.New(), prompts.NewNonInteractivePrompter(), application.NewDownloader())
+	defer func() {
+		app = nil
+	}()
+	require.NoError(t, os.MkdirAll(app.GetReposDir(), constants.DefaultPerms755))
+	require.NoError(t, app.WriteGenesisFile(testSubnet, []byte("{}")))
+	require.NoError(t, app.CreateSidecar(&models.Sidecar{
+		Name:   testSubnet,
+		VM:     models.SubnetEvm,
+		Subnet: testSubnet,
+		Networks: map[string]models.NetworkData{
+			models.Fuji.String(): {
+				SubnetID:     ids.GenerateTestID(),
+				BlockchainID: ids.GenerateTestID(),
+			},
+		},
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// also resets the flag vars to their defaults
+			cmd := tt.newCmd()
+			err := cmd.RunE(cmd, tt.args)
+			require.ErrorIs(err, prompts.ErrNonInteractive)
+
+			var nonInteractiveErr *prompts.NonInteractiveError
+			require.ErrorAs(err, &nonInteractiveErr)
+			flagNames := flagRegex.FindAllStringSubmatch(nonInteractiveErr.Hint, -1)
+			require.NotEmpty(flagNames, "no flag named in %q", err)
+			for _, flagName := range flagNames {
+				require.NotNil(cmd.Flags().Lookup(flagName[1]), "unknown flag --%s in %q", flagName[1], err)
+			}
+		})
+	}
+}
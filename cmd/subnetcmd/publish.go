@@ -252,7 +252,7 @@ func getAlias(reposDir string) error {
 			choice, err := app.Prompt.CaptureList(
 				"Don't know which repo to publish to. How would you like to proceed?", options)
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--alias")
 			}
 			if choice == options[0] {
 				// user chose to provide a new alias
@@ -266,7 +266,7 @@ func getAlias(reposDir string) error {
 						"The repository with the given alias already exists locally. You may have already published this subnet there (the other explanation is that a different subnet has been published there).")
 					yes, err := app.Prompt.CaptureYesNo("Do you wish to continue?")
 					if err != nil {
-						return err
+						return prompts.WithHint(err, "--alias")
 					}
 					if !yes {
 						ux.Logger.PrintToUser("User canceled, nothing got published.")
@@ -280,7 +280,7 @@ func getAlias(reposDir string) error {
 				}
 				alias, err = app.Prompt.CaptureList("Pick an alias", aliases)
 				if err != nil {
-					return err
+					return prompts.WithHint(err, "--alias")
 				}
 			}
 		}
@@ -290,7 +290,8 @@ func getAlias(reposDir string) error {
 
 // ask for a new alias
 func getNewAlias() (string, error) {
-	return app.Prompt.CaptureString("Provide an alias for the repository we are going to use")
+	newAlias, err := app.Prompt.CaptureString("Provide an alias for the repository we are going to use")
+	return newAlias, prompts.WithHint(err, "--alias")
 }
 
 // TODO -- do we want to modify global [repoURL]?
@@ -304,7 +305,7 @@ func getRepoURL(reposDir string) error {
 		app.Log.Debug(
 			"opening repo failed - alias might have not been created yet, so ignore", zap.String("alias", alias), zap.Error(err))
 		repoURL, err = app.Prompt.CaptureString("Provide the repository URL")
-		return err
+		return prompts.WithHint(err, "--repo-url")
 	}
 	// there is a repo already for this alias, let's try to figure out the remote URL from there
 	conf, err := repo.Config()
@@ -322,7 +323,7 @@ func getRepoURL(reposDir string) error {
 	repoURL, err = app.Prompt.CaptureList("Which is the remote URL for this repo?", remotes)
 	if err != nil {
 		// should never happen
-		return err
+		return prompts.WithHint(err, "--repo-url")
 	}
 	return nil
 }
@@ -340,12 +341,12 @@ func loadYAMLFile[T types.Definition](path string, defType T) error {
 func getSubnetInfo(sc *models.Sidecar) (*types.Subnet, error) {
 	homepage, err := app.Prompt.CaptureStringAllowEmpty("What is the homepage of the Subnet project?")
 	if err != nil {
-		return nil, err
+		return nil, prompts.WithHint(err, "--subnet-file-path")
 	}
 
 	desc, err := app.Prompt.CaptureStringAllowEmpty("Provide a free-text description of the Subnet")
 	if err != nil {
-		return nil, err
+		return nil, prompts.WithHint(err, "--subnet-file-path")
 	}
 
 	maintrs, canceled, err := prompts.CaptureListDecision(
@@ -357,7 +358,7 @@ func getSubnetInfo(sc *models.Sidecar) (*types.Subnet, error) {
 		"",
 	)
 	if err != nil {
-		return nil, err
+		return nil, prompts.WithHint(err, "--subnet-file-path")
 	}
 	if canceled {
 		ux.Logger.PrintToUser("Publishing aborted")
@@ -389,11 +390,11 @@ func getVMInfo(sc *models.Sidecar) (*types.VM, error) {
 	case sc.VM == models.CustomVM:
 		vmID, err = app.Prompt.CaptureStringAllowEmpty("What is the ID of this VM?")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 		desc, err = app.Prompt.CaptureStringAllowEmpty("Provide a description for this VM")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 		maintrs, canceled, err = prompts.CaptureListDecision(
 			app.Prompt,
@@ -404,7 +405,7 @@ func getVMInfo(sc *models.Sidecar) (*types.VM, error) {
 			"",
 		)
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 		if canceled {
 			ux.Logger.PrintToUser("Publishing aborted")
@@ -414,18 +415,18 @@ func getVMInfo(sc *models.Sidecar) (*types.VM, error) {
 		url, err = app.Prompt.CaptureStringAllowEmpty(
 			"Tell us the URL to download the source. Needs to be a fixed version, not `latest`.")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 
 		sha, err = app.Prompt.CaptureStringAllowEmpty(
 			"For integrity checks, provide the sha256 commit for the used version")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 		strVer, err := app.Prompt.CaptureVersion(
 			"This is the last question! What is the version being used? Use semantic versioning (v1.2.3)")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--vm-file-path")
 		}
 		ver, err = version.Parse(strVer)
 		if err != nil {
@@ -453,13 +454,13 @@ func getVMInfo(sc *models.Sidecar) (*types.VM, error) {
 	scr, err := app.Prompt.CaptureStringAllowEmpty(
 		"What scripts needs to run to install this VM? Needs to be an executable command to build the VM")
 	if err != nil {
-		return nil, err
+		return nil, prompts.WithHint(err, "--vm-file-path")
 	}
 
 	bin, err := app.Prompt.CaptureStringAllowEmpty(
 		"What is the binary path? (This is the output of the build command)")
 	if err != nil {
-		return nil, err
+		return nil, prompts.WithHint(err, "--vm-file-path")
 	}
 
 	vm := &types.VM{
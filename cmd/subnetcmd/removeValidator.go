@@ -29,7 +29,9 @@ func newRemoveValidatorCmd() *cobra.Command {
 validating your deployed Subnet.
 
 To remove the validator from the Subnet's allow list, provide the validator's unique NodeID. You can bypass
-these prompts by providing the values with flags.`,
+these prompts by providing the values with flags.
+
+Use --dry-run to print the transaction, its fee and the control key signatures it requires, without issuing it.`,
 		SilenceUsage: true,
 		RunE:         removeValidator,
 		Args:         cobra.ExactArgs(1),
@@ -44,6 +46,7 @@ these prompts by providing the values with flags.`,
 	cmd.Flags().StringVar(&outputTxPath, "output-tx-path", "", "file path of the removeValidator tx")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	AddDryRunFlag(cmd, &dryRun)
 	return cmd
 }
 
@@ -69,11 +72,15 @@ func removeValidator(_ *cobra.Command, args []string) error {
 			[]string{models.Local.String(), models.Fuji.String(), models.Mainnet.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--local, --fuji/--testnet or --mainnet")
 		}
 		network = models.NetworkFromString(networkStr)
 	}
 
+	if err := CheckDryRun(dryRun, network); err != nil {
+		return err
+	}
+
 	if outputTxPath != "" {
 		if _, err := os.Stat(outputTxPath); err == nil {
 			return fmt.Errorf("outputTxPath %q already exists", outputTxPath)
@@ -187,10 +194,16 @@ func removeValidator(_ *cobra.Command, args []string) error {
 	ux.Logger.PrintToUser("Inputs complete, issuing transaction to remove the specified validator...")
 
 	deployer := subnet.NewPublicDeployer(app, kc, network)
+	deployer.SetDryRun(dryRun)
+	deployer.SetJournal(subnetName)
 	isFullySigned, tx, remainingSubnetAuthKeys, err := deployer.RemoveValidator(controlKeys, subnetAuthKeys, subnetID, nodeID)
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		PrintDryRunDone()
+		return nil
+	}
 	if !isFullySigned {
 		if err := SaveNotFullySignedTx(
 			"Remove Validator",
@@ -234,7 +247,7 @@ func removeFromLocal(subnetName string) error {
 	if nodeIDStr == "" {
 		nodeIDStr, err = app.Prompt.CaptureList("Choose a validator to remove", validatorList)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--nodeID")
 		}
 	}
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/api/info"
 	"github.com/luxdefi/node/ids"
@@ -53,7 +54,7 @@ func stats(_ *cobra.Command, args []string) error {
 			[]string{models.Fuji.String(), models.Mainnet.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--fuji/--testnet or --mainnet")
 		}
 		// flag provided
 		networkStr = strings.Title(networkStr)
@@ -90,6 +91,15 @@ func stats(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+
+	if app.OutputFormat.IsStructured() {
+		pendingRows, err := buildPendingValidatorStats(pClient, infoClient, table, subnetID)
+		if err != nil {
+			return err
+		}
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "SubnetStats", newSubnetStats(rows, pendingRows))
+	}
+
 	for _, row := range rows {
 		table.Append(row)
 	}
@@ -111,6 +121,55 @@ func stats(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// subnetStats is the structured (json/yaml) output of subnet stats.
+// It is built from the same rows used for the tables
+type subnetStats struct {
+	Current []currentValidatorStat `json:"current"`
+	Pending []pendingValidatorStat `json:"pending"`
+}
+
+type currentValidatorStat struct {
+	NodeID    string `json:"nodeID"`
+	Connected string `json:"connected"`
+	Weight    string `json:"weight"`
+	Remaining string `json:"remaining"`
+	VMVersion string `json:"vmVersion"`
+}
+
+type pendingValidatorStat struct {
+	NodeID    string `json:"nodeID"`
+	Weight    string `json:"weight"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	VMVersion string `json:"vmVersion"`
+}
+
+func newSubnetStats(currentRows [][]string, pendingRows [][]string) subnetStats {
+	stats := subnetStats{
+		Current: []currentValidatorStat{},
+		Pending: []pendingValidatorStat{},
+	}
+	for _, row := range currentRows {
+		stats.Current = append(stats.Current, currentValidatorStat{
+			NodeID:    row[0],
+			Connected: row[1],
+			Weight:    row[2],
+			Remaining: strings.TrimSpace(row[3]),
+			VMVersion: strings.TrimSpace(row[4]),
+		})
+	}
+	for _, row := range pendingRows {
+		stats.Pending = append(stats.Pending, pendingValidatorStat{
+			NodeID:    row[0],
+			Weight:    row[1],
+			StartTime: row[2],
+			EndTime:   row[3],
+			VMVersion: strings.TrimSpace(row[4]),
+		})
+	}
+	return stats
+}
+
 func buildPendingValidatorStats(pClient platformvm.Client, infoClient info.Client, table *tablewriter.Table, subnetID ids.ID) ([][]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
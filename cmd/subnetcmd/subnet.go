@@ -34,6 +34,8 @@ manage your Subnet configurations and live deployments.`,
 	app = injectedApp
 	// subnet create
 	cmd.AddCommand(newCreateCmd())
+	// subnet add-chain
+	cmd.AddCommand(newAddChainCmd())
 	// subnet delete
 	cmd.AddCommand(newDeleteCmd())
 	// subnet deploy
@@ -70,5 +72,7 @@ manage your Subnet configurations and live deployments.`,
 	cmd.AddCommand(newValidatorsCmd())
 	// subnet addPermissionlessDelegator
 	cmd.AddCommand(newAddPermissionlessDelegatorCmd())
+	// subnet lint
+	cmd.AddCommand(newLintCmd())
 	return cmd
 }
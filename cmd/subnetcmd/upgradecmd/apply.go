@@ -16,6 +16,7 @@ import (
 	"github.com/luxdefi/cli/pkg/binutils"
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
@@ -97,6 +98,7 @@ func applyCmd(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to load sidecar: %w", err)
 	}
+	printOtherChainsHint(subnetName)
 
 	networkToUpgrade, err := selectNetworkToUpgrade(sc, []string{})
 	if err != nil {
@@ -116,6 +118,17 @@ func applyCmd(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// CallApplyLocal applies the upgrade bytes file of [subnetName] to the local
+// network. With [forceParam], upgrades in the past are applied without prompting
+func CallApplyLocal(subnetName string, forceParam bool) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("unable to load sidecar: %w", err)
+	}
+	force = forceParam
+	return applyLocalNetworkUpgrade(subnetName, models.Local.String(), &sc)
+}
+
 // applyLocalNetworkUpgrade:
 // * if subnet NOT deployed (`network status`):
 // *   Stop the apply command and print a message suggesting to deploy first
@@ -281,13 +294,13 @@ func applyPublicNetworkUpgrade(subnetName, networkKey string, sc *models.Sidecar
 	if nodeChainConfigDir == nodeChainConfigDirDefault {
 		useDefault, err := app.Prompt.CaptureYesNo("It is set to the default. Is that correct?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+nodeChainConfigFlag)
 		}
 		if !useDefault {
 			nodeChainConfigDir, err = app.Prompt.CaptureExistingFilepath(
 				"Enter the path to your custom chain config dir (*without* the blockchain ID, e.g /my/configs/dir)")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--"+nodeChainConfigFlag)
 			}
 		}
 	}
@@ -422,7 +435,7 @@ func validateUpgradeBytes(file, lockFile []byte, skipPrompting bool) ([]params.P
 					"The config MUST be removed. Use caution before proceeding")
 				yes, err := app.Prompt.CaptureYesNo("Do you want to continue (use --force to skip prompting)?")
 				if err != nil {
-					return nil, err
+					return nil, prompts.WithHint(err, "--force")
 				}
 				if !yes {
 					ux.Logger.PrintToUser("No selected.")
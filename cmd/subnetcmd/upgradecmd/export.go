@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
@@ -39,7 +40,7 @@ func upgradeExportCmd(_ *cobra.Command, args []string) error {
 		var err error
 		upgradeBytesFilePath, err = app.Prompt.CaptureString("Provide a path where we should export the file to")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+upgradeBytesFilePathKey)
 		}
 	}
 
@@ -49,7 +50,7 @@ func upgradeExportCmd(_ *cobra.Command, args []string) error {
 
 			yes, err := app.Prompt.CaptureYesNo("Should we overwrite it?")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--force")
 			}
 			if !yes {
 				ux.Logger.PrintToUser("Aborted by user. Nothing has been exported")
@@ -42,6 +42,9 @@ const (
 
 	enabledLabel = "enabled"
 	adminLabel   = "admin"
+
+	// the wizard has no flags, an upgrade file written by hand can be imported instead
+	generateHint = "an upgrade file imported with lux subnet upgrade import --" + upgradeBytesFilePathKey
 )
 
 var subnetName string
@@ -83,7 +86,7 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 	txt := "Press [Enter] to continue, or abort by choosing 'no'"
 	yes, err := app.Prompt.CaptureYesNo(txt)
 	if err != nil {
-		return err
+		return prompts.WithHint(err, generateHint)
 	}
 	if !yes {
 		ux.Logger.PrintToUser("Aborted by user")
@@ -112,7 +115,7 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 	for {
 		precomp, err := app.Prompt.CaptureList("Select the precompile to configure", allPreComps)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, generateHint)
 		}
 
 		ux.Logger.PrintToUser(fmt.Sprintf("Set parameters for the %q precompile", precomp))
@@ -123,7 +126,7 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		if len(allPreComps) > 1 {
 			yes, err := app.Prompt.CaptureNoYes("Should we configure another precompile?")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, generateHint)
 			}
 			if !yes {
 				break
@@ -157,7 +160,7 @@ func queryActivationTimestamp() (time.Time, error) {
 	options := []string{in5min, in1day, in1week, in2weeks, custom}
 	choice, err := app.Prompt.CaptureList("When should the precompile be activated?", options)
 	if err != nil {
-		return time.Time{}, err
+		return time.Time{}, prompts.WithHint(err, generateHint)
 	}
 
 	var date time.Time
@@ -176,7 +179,7 @@ func queryActivationTimestamp() (time.Time, error) {
 		date, err = app.Prompt.CaptureFutureDate(
 			"Enter the block activation UTC datetime in 'YYYY-MM-DD HH:MM:SS' format", time.Now().Add(time.Minute).UTC())
 		if err != nil {
-			return time.Time{}, err
+			return time.Time{}, prompts.WithHint(err, generateHint)
 		}
 	}
 
@@ -215,7 +218,7 @@ func promptNativeMintParams(precompiles *[]params.PrecompileUpgrade, date time.T
 
 	yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Airdrop more tokens? (`%s` section in file)", initialMintKey))
 	if err != nil {
-		return err
+		return prompts.WithHint(err, generateHint)
 	}
 
 	if yes {
@@ -225,11 +228,11 @@ func promptNativeMintParams(precompiles *[]params.PrecompileUpgrade, date time.T
 			func(s string) (string, error) {
 				addr, err := app.Prompt.CaptureAddress("Address to airdrop to")
 				if err != nil {
-					return "", err
+					return "", prompts.WithHint(err, generateHint)
 				}
 				amount, err := app.Prompt.CaptureUint64("Amount to airdrop (in LUX units)")
 				if err != nil {
-					return "", err
+					return "", prompts.WithHint(err, generateHint)
 				}
 				initialMint[addr] = math.NewHexOrDecimal256(int64(amount))
 				return fmt.Sprintf("%s-%d", addr.Hex(), amount), nil
@@ -240,7 +243,7 @@ func promptNativeMintParams(precompiles *[]params.PrecompileUpgrade, date time.T
 				"for example: 0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC (address) and 1000000000000000000 (value)",
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, generateHint)
 		}
 		if cancel {
 			return errors.New("aborted by user")
@@ -294,7 +297,7 @@ func promptFeeManagerParams(precompiles *[]params.PrecompileUpgrade, date time.T
 	yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf(
 		"Do you want to update the fee config upon precompile activation? ('%s' section in file)", feeConfigKey))
 	if err != nil {
-		return err
+		return prompts.WithHint(err, generateHint)
 	}
 
 	var feeConfig *commontype.FeeConfig
@@ -469,7 +472,7 @@ func promptAdminAndEnabledAddresses() ([]common.Address, []common.Address, error
 func captureAddress(which string, addrsField *[]common.Address) error {
 	yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Add '%sAddresses'?", which))
 	if err != nil {
-		return err
+		return prompts.WithHint(err, generateHint)
 	}
 	if yes {
 		var (
@@ -485,7 +488,7 @@ func captureAddress(which string, addrsField *[]common.Address) error {
 			fmt.Sprintf("Hex-formatted %s addresses", which),
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, generateHint)
 		}
 		if cancel {
 			return errors.New("aborted by user")
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
@@ -40,7 +41,7 @@ func upgradeImportCmd(_ *cobra.Command, args []string) error {
 		var err error
 		upgradeBytesFilePath, err = app.Prompt.CaptureExistingFilepath("Provide the path to the upgrade file to import")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+upgradeBytesFilePathKey)
 		}
 	}
 
@@ -4,8 +4,11 @@ package upgradecmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
 
@@ -40,3 +43,14 @@ updating your developmental and deployed Subnets.`,
 	cmd.AddCommand(newUpgradeApplyCmd())
 	return cmd
 }
+
+// chains added with subnet add-chain have their own VM and upgrade bytes, so
+// they are upgraded by chain name, not with the subnet name
+func printOtherChainsHint(subnetName string) {
+	chains, err := subnet.GetSubnetChains(app, subnetName)
+	if err != nil || len(chains) < 2 {
+		return
+	}
+	ux.Logger.PrintToUser("Only chain %s is upgraded. To upgrade the other chains of the subnet, use their names: %s",
+		subnetName, strings.Join(chains[1:], ", "))
+}
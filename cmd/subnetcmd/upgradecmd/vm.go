@@ -10,6 +10,7 @@ import (
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
 	"github.com/luxdefi/cli/pkg/plugins"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/luxdefi/cli/pkg/utils"
 	"github.com/luxdefi/cli/pkg/ux"
@@ -107,6 +108,7 @@ func upgradeVM(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to load sidecar: %w", err)
 	}
+	printOtherChainsHint(subnetName)
 
 	upgradeOptions := []string{futureDeployment}
 	networkToUpgrade, err := selectNetworkToUpgrade(sc, upgradeOptions)
@@ -181,7 +183,7 @@ func selectNetworkToUpgrade(sc models.Sidecar, upgradeOptions []string) (string,
 
 	selectedDeployment, err := app.Prompt.CaptureList(updatePrompt, upgradeOptions)
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, "--config, --local, --fuji/--testnet or --mainnet")
 	}
 	return selectedDeployment, nil
 }
@@ -205,7 +207,7 @@ func selectUpdateOption(vmType models.VMType, sc models.Sidecar, networkToUpgrad
 	updatePrompt := "How would you like to update your subnet's virtual machine"
 	updateDecision, err := app.Prompt.CaptureList(updatePrompt, updateOptions)
 	if err != nil {
-		return err
+		return prompts.WithHint(err, "--latest, --version or --binary")
 	}
 
 	switch updateDecision {
@@ -251,7 +253,7 @@ func updateToSpecificVersion(sc models.Sidecar, networkToUpgrade string) error {
 	if targetVersion == "" {
 		targetVersion, err = app.Prompt.CaptureVersion("Enter version")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--version")
 		}
 	}
 
@@ -284,7 +286,7 @@ func updateToCustomBin(sc models.Sidecar, networkToUpgrade, binaryPath string, u
 	if binaryPath == "" {
 		binaryPath, err = app.Prompt.CaptureExistingFilepath("Enter path to custom binary")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--binary")
 		}
 	}
 
@@ -377,7 +379,7 @@ func chooseManualOrAutomatic(sc models.Sidecar, targetVersion string) error {
 		[]string{choiceAutomatic, choiceManual},
 	)
 	if err != nil {
-		return err
+		return prompts.WithHint(err, "--plugin-dir or --print")
 	}
 
 	if choice == choiceManual {
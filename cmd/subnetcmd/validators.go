@@ -11,7 +11,9 @@ import (
 
 	"github.com/luxdefi/cli/cmd/flags"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/vms/platformvm"
 	"github.com/olekukonko/tablewriter"
@@ -64,7 +66,7 @@ func printValidators(_ *cobra.Command, args []string) error {
 			[]string{models.Local.String(), models.Fuji.String(), models.Mainnet.String()},
 		)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--local, --fuji/--testnet or --mainnet")
 		}
 		network = models.NetworkFromString(networkStr)
 	}
@@ -107,30 +109,65 @@ func printPublicValidators(subnetID ids.ID, network models.Network) error {
 	return printValidatorsFromList(validators)
 }
 
+// validatorEntry is the structured (json/yaml) output of subnet validators
+type validatorEntry struct {
+	NodeID          string `json:"nodeID"`
+	StakeAmount     uint64 `json:"stakeAmount"`
+	DelegatorWeight uint64 `json:"delegatorWeight"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	Type            string `json:"type"`
+}
+
+func newValidatorEntry(validator platformvm.ClientPermissionlessValidator) validatorEntry {
+	var delegatorWeight uint64
+	if validator.DelegatorWeight != nil {
+		delegatorWeight = *validator.DelegatorWeight
+	}
+
+	validatorType := "permissioned"
+	if validator.PotentialReward != nil && *validator.PotentialReward > 0 {
+		validatorType = "elastic"
+	}
+
+	var stakeAmount uint64
+	if validator.StakeAmount != nil {
+		stakeAmount = *validator.StakeAmount
+	}
+
+	return validatorEntry{
+		NodeID:          validator.NodeID.String(),
+		StakeAmount:     stakeAmount,
+		DelegatorWeight: delegatorWeight,
+		StartTime:       formatUnixTime(validator.StartTime),
+		EndTime:         formatUnixTime(validator.EndTime),
+		Type:            validatorType,
+	}
+}
+
 func printValidatorsFromList(validators []platformvm.ClientPermissionlessValidator) error {
+	if app.OutputFormat.IsStructured() {
+		entries := []validatorEntry{}
+		for _, validator := range validators {
+			entries = append(entries, newValidatorEntry(validator))
+		}
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "SubnetValidators", entries)
+	}
+
 	header := []string{"NodeID", "Stake Amount", "Delegator Weight", "Start Time", "End Time", "Type"}
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader(header)
 	table.SetRowLine(true)
 
 	for _, validator := range validators {
-		var delegatorWeight uint64
-		if validator.DelegatorWeight != nil {
-			delegatorWeight = *validator.DelegatorWeight
-		}
-
-		validatorType := "permissioned"
-		if validator.PotentialReward != nil && *validator.PotentialReward > 0 {
-			validatorType = "elastic"
-		}
-
+		entry := newValidatorEntry(validator)
 		table.Append([]string{
-			validator.NodeID.String(),
-			strconv.FormatUint(*validator.StakeAmount, 10),
-			strconv.FormatUint(delegatorWeight, 10),
-			formatUnixTime(validator.StartTime),
-			formatUnixTime(validator.EndTime),
-			validatorType,
+			entry.NodeID,
+			strconv.FormatUint(entry.StakeAmount, 10),
+			strconv.FormatUint(entry.DelegatorWeight, 10),
+			entry.StartTime,
+			entry.EndTime,
+			entry.Type,
 		})
 	}
 
@@ -29,5 +29,11 @@ func NewCmd(injectedApp *application.Lux) *cobra.Command {
 	cmd.AddCommand(newTransactionSignCmd())
 	// subnet upgrade generate
 	cmd.AddCommand(newTransactionCommitCmd())
+	// transaction inspect
+	cmd.AddCommand(newTransactionInspectCmd())
+	// transaction merge
+	cmd.AddCommand(newTransactionMergeCmd())
+	// transaction export-kit
+	cmd.AddCommand(newTransactionExportKitCmd())
 	return cmd
 }
@@ -7,20 +7,27 @@ import (
 
 	"github.com/luxdefi/cli/cmd/subnetcmd"
 	"github.com/luxdefi/cli/pkg/keychain"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/subnet"
 	"github.com/luxdefi/cli/pkg/txutils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 )
 
 // lux transaction commit
 func newTransactionCommitCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "commit [subnetName]",
-		Short:        "commit a transaction",
-		Long:         "The transaction commit command commits a transaction by submitting it to the P-Chain.",
+		Use:   "commit [subnetName]",
+		Short: "commit a transaction",
+		Long: `The transaction commit command commits a transaction by submitting it to the P-Chain.
+
+The input file can also be a signing kit signed offline. The kit is verified, and its
+subnet owners are checked against the network before the transaction is issued.`,
 		RunE:         commitTx,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -34,11 +41,23 @@ func commitTx(_ *cobra.Command, args []string) error {
 	var err error
 	if inputTxPath == "" {
 		inputTxPath, err = app.Prompt.CaptureExistingFilepath("What is the path to the signed transactions file?")
+		if err != nil {
+			return prompts.WithHint(err, "--"+inputTxPathFlag)
+		}
+	}
+	var (
+		tx  *txs.Tx
+		kit *txutils.SigningKit
+	)
+	if txutils.IsSigningKitFile(inputTxPath) {
+		kit, err = txutils.LoadSigningKit(inputTxPath)
 		if err != nil {
 			return err
 		}
+		tx, _, err = kit.Verify()
+	} else {
+		tx, err = txutils.LoadFromDisk(inputTxPath)
 	}
-	tx, err := txutils.LoadFromDisk(inputTxPath)
 	if err != nil {
 		return err
 	}
@@ -58,10 +77,18 @@ func commitTx(_ *cobra.Command, args []string) error {
 		return errNoSubnetID
 	}
 
-	controlKeys, _, err := txutils.GetOwners(network, subnetID)
+	controlKeys, threshold, err := txutils.GetOwners(network, subnetID)
 	if err != nil {
 		return err
 	}
+	if kit != nil {
+		if kit.SubnetID != subnetID {
+			return fmt.Errorf("signing kit is for subnet %s, but %s has subnet %s", kit.SubnetID, subnetName, subnetID)
+		}
+		if !slices.Equal(kit.ControlKeys, controlKeys) || kit.Threshold != threshold {
+			return txutils.ErrKitOwnersChange
+		}
+	}
 	subnetAuthKeys, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
 	if err != nil {
 		return err
@@ -87,13 +114,47 @@ func commitTx(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	if txutils.IsCreateChainTx(tx) {
+	// update the sidecar as done by the commands that issue fully signed txs
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *txs.CreateChainTx:
 		if err := subnetcmd.PrintDeployResults(subnetName, subnetID, txID); err != nil {
 			return err
 		}
 		return app.UpdateSidecarNetworks(&sc, network, subnetID, txID)
+	case *txs.TransformSubnetTx:
+		return commitElasticSubnet(sc, network, subnetID, tx, txID)
+	case *txs.AddSubnetValidatorTx:
+		ux.Logger.PrintToUser("Validator %s added to subnet %s", unsignedTx.NodeID(), subnetName)
+	case *txs.RemoveSubnetValidatorTx:
+		ux.Logger.PrintToUser("Validator %s removed from subnet %s", unsignedTx.NodeID, subnetName)
 	}
 	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
 
 	return nil
 }
+
+func commitElasticSubnet(
+	sc models.Sidecar,
+	network models.Network,
+	subnetID ids.ID,
+	tx *txs.Tx,
+	txID ids.ID,
+) error {
+	elasticSubnetConfig, err := txutils.GetElasticSubnetConfig(tx)
+	if err != nil {
+		return err
+	}
+	if err := app.CreateElasticSubnetConfig(sc.Name, &elasticSubnetConfig); err != nil {
+		return err
+	}
+	// token name and symbol are not part of the tx, but of the asset
+	tokenName, tokenSymbol, err := subnet.GetAssetDescription(network, elasticSubnetConfig.AssetID)
+	if err != nil {
+		ux.Logger.PrintToUser("Skipping token name and symbol: %s", err)
+	}
+	if err := app.UpdateSidecarElasticSubnet(&sc, network, subnetID, elasticSubnetConfig.AssetID, txID, tokenName, tokenSymbol); err != nil {
+		return fmt.Errorf("elastic subnet transformation was successful, but failed to update sidecar: %w", err)
+	}
+	subnetcmd.PrintTransformResults(sc.Name, txID, subnetID, tokenName, tokenSymbol, elasticSubnetConfig.AssetID)
+	return nil
+}
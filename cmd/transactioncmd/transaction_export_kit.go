@@ -0,0 +1,72 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"github.com/luxdefi/cli/pkg/txutils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var forceKitOverwrite bool
+
+// lux transaction export-kit
+func newTransactionExportKitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-kit [txPath] [kitPath]",
+		Short: "bundle a transaction with the data needed to sign it offline",
+		Long: `The transaction export-kit command, run on a machine with network access, bundles
+the multisig transaction at txPath together with the subnet owners, the threshold and
+the UTXOs it consumes into a signing kit written to kitPath.
+
+The kit can then be signed on a disconnected machine with
+  lux transaction sign [subnetName] --offline --input-tx-filepath kitPath
+and given to transaction commit once it is fully signed.`,
+		RunE:         exportKit,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVarP(&forceKitOverwrite, "force", "f", false, "overwrite the kit file if it exists")
+	return cmd
+}
+
+func exportKit(_ *cobra.Command, args []string) error {
+	txPath := args[0]
+	kitPath := args[1]
+	tx, err := txutils.LoadFromDisk(txPath)
+	if err != nil {
+		return err
+	}
+	network, err := txutils.GetNetwork(tx)
+	if err != nil {
+		return err
+	}
+	kit, err := txutils.NewSigningKit(network, tx)
+	if err != nil {
+		return err
+	}
+	// check the kit before it reaches the offline machine
+	if _, _, err := kit.Verify(); err != nil {
+		return err
+	}
+	if err := kit.Save(kitPath, forceKitOverwrite); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Signing kit for subnet %s saved to %s", kit.SubnetID, kitPath)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Control keys (threshold %d):", kit.Threshold)
+	for _, addr := range kit.ControlKeys {
+		ux.Logger.PrintToUser("  %s", addr)
+	}
+	if len(kit.FeePayer) > 0 {
+		ux.Logger.PrintToUser("Fee payer (threshold %d):", kit.FeePayerThreshold)
+		for _, addr := range kit.FeePayer {
+			ux.Logger.PrintToUser("  %s", addr)
+		}
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Offline signing command:")
+	ux.Logger.PrintToUser("  lux transaction sign [subnetName] --offline --input-tx-filepath %s", kitPath)
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/txutils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// lux transaction inspect
+func newTransactionInspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect [txPath]",
+		Short: "show the contents of a transaction file",
+		Long: `The transaction inspect command decodes a transaction file, as written for multisig
+transactions, and shows its contents and which subnet control keys have signed it or still
+must sign it. The file is not modified.
+
+The signing status requires querying the P-Chain for the subnet control keys, and is
+skipped if that is not possible. Signing kits created by transaction export-kit can also be
+inspected, using the control keys they carry.`,
+		RunE:         inspectTx,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func inspectTx(_ *cobra.Command, args []string) error {
+	txPath := args[0]
+	var (
+		tx          *txs.Tx
+		controlKeys []string
+		threshold   uint32
+		err         error
+	)
+	if txutils.IsSigningKitFile(txPath) {
+		// signing kits carry the subnet owners, so no network access is needed
+		kit, err := txutils.LoadSigningKit(txPath)
+		if err != nil {
+			return err
+		}
+		tx, _, err = kit.Verify()
+		if err != nil {
+			return err
+		}
+		controlKeys, threshold = kit.ControlKeys, kit.Threshold
+	} else {
+		tx, err = txutils.LoadFromDisk(txPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if controlKeys == nil && txutils.NeedsSubnetAuth(tx) {
+		network, err := txutils.GetNetwork(tx)
+		if err != nil {
+			return err
+		}
+		subnetID, err := txutils.GetSubnetID(tx)
+		if err != nil {
+			return err
+		}
+		controlKeys, threshold, err = txutils.GetOwners(network, subnetID)
+		if err != nil {
+			ux.Logger.PrintToUser("Skipping signing status: %s", err)
+			controlKeys = nil
+		}
+	}
+
+	info, err := txutils.Inspect(tx, controlKeys, threshold)
+	if err != nil {
+		return err
+	}
+	if app.OutputFormat.IsStructured() {
+		return ux.PrintDocument(os.Stdout, app.OutputFormat, "TransactionInfo", info)
+	}
+	printTxInfo(info)
+	return nil
+}
+
+func printTxInfo(info *txutils.TxInfo) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"Type", info.Type})
+	table.Append([]string{"Network", info.Network})
+	table.Append([]string{"Subnet ID", info.SubnetID})
+	table.Append([]string{"Fee", fmt.Sprintf("%.9f LUX", float64(info.Fee)/float64(units.Lux))})
+	if info.Chain != nil {
+		table.Append([]string{"Chain Name", info.Chain.Name})
+		table.Append([]string{"VM ID", info.Chain.VMID})
+		table.Append([]string{"Genesis SHA256", info.Chain.GenesisHash})
+	}
+	if info.Validator != nil {
+		table.Append([]string{"Node ID", info.Validator.NodeID})
+		if info.Validator.Weight != 0 {
+			table.Append([]string{"Weight", fmt.Sprintf("%d", info.Validator.Weight)})
+		}
+		if info.Validator.StartTime != nil {
+			table.Append([]string{"Start Time", info.Validator.StartTime.UTC().Format(time.RFC3339)})
+		}
+		if info.Validator.EndTime != nil {
+			table.Append([]string{"End Time", info.Validator.EndTime.UTC().Format(time.RFC3339)})
+		}
+	}
+	if info.Signers != nil {
+		table.Append([]string{"Threshold", fmt.Sprintf("%d", info.Signers.Threshold)})
+		table.Append([]string{"Signed", formatAddrs(info.Signers.Signed)})
+		table.Append([]string{"Remaining Signers", formatAddrs(info.Signers.Remaining)})
+	}
+	table.Render()
+}
+
+func formatAddrs(addrs []string) string {
+	if len(addrs) == 0 {
+		return "-"
+	}
+	return strings.Join(addrs, "\n")
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"github.com/luxdefi/cli/pkg/txutils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/spf13/cobra"
+)
+
+var forceMergeOverwrite bool
+
+// lux transaction merge
+func newTransactionMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [outputTxPath] [inputTxPath]...",
+		Short: "merge independently signed copies of a transaction",
+		Long: `The transaction merge command combines the signatures of copies of the same multisig
+transaction that were signed in parallel by different control key holders, and writes
+the result to outputTxPath.
+
+All the input files must contain the same unsigned transaction. Use it to let all the
+signers sign at the same time instead of passing a single file around.`,
+		RunE:         mergeTxs,
+		Args:         cobra.MinimumNArgs(3),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVarP(&forceMergeOverwrite, "force", "f", false, "overwrite the output file if it exists")
+	return cmd
+}
+
+func mergeTxs(_ *cobra.Command, args []string) error {
+	outputTxPath := args[0]
+	txList := []*txs.Tx{}
+	for _, inputTxPath := range args[1:] {
+		tx, err := txutils.LoadFromDisk(inputTxPath)
+		if err != nil {
+			return err
+		}
+		txList = append(txList, tx)
+	}
+
+	tx, err := txutils.Merge(txList)
+	if err != nil {
+		return err
+	}
+	if err := txutils.SaveToDisk(tx, outputTxPath, forceMergeOverwrite); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Merged %d transactions into %s", len(txList), outputTxPath)
+
+	if !txutils.NeedsSubnetAuth(tx) {
+		return nil
+	}
+	network, err := txutils.GetNetwork(tx)
+	if err != nil {
+		return err
+	}
+	subnetID, err := txutils.GetSubnetID(tx)
+	if err != nil {
+		return err
+	}
+	controlKeys, _, err := txutils.GetOwners(network, subnetID)
+	if err != nil {
+		return err
+	}
+	subnetAuthKeys, remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, controlKeys)
+	if err != nil {
+		return err
+	}
+
+	signedCount := len(subnetAuthKeys) - len(remainingSubnetAuthKeys)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("%d of %d required signatures have been signed.", signedCount, len(subnetAuthKeys))
+	if len(remainingSubnetAuthKeys) == 0 {
+		ux.Logger.PrintToUser("Tx is fully signed, and ready to be committed with")
+		ux.Logger.PrintToUser("  lux transaction commit [subnetName] --input-tx-filepath %s", outputTxPath)
+		return nil
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Addresses remaining to sign the tx")
+	for _, subnetAuthKey := range remainingSubnetAuthKeys {
+		ux.Logger.PrintToUser("  %s", subnetAuthKey)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package transactioncmd
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/luxdefi/cli/cmd/subnetcmd"
 	"github.com/luxdefi/cli/pkg/keychain"
@@ -14,6 +15,7 @@ import (
 	"github.com/luxdefi/cli/pkg/txutils"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +26,7 @@ var (
 	keyName         string
 	useLedger       bool
 	ledgerAddresses []string
+	offline         bool
 
 	errNoSubnetID = errors.New("failed to find the subnet ID for this subnet, has it been deployed/created on this network?")
 )
@@ -31,9 +34,12 @@ var (
 // lux transaction sign
 func newTransactionSignCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "sign [subnetName]",
-		Short:        "sign a transaction",
-		Long:         "The transaction sign command signs a multisig transaction.",
+		Use:   "sign [subnetName]",
+		Short: "sign a transaction",
+		Long: `The transaction sign command signs a multisig transaction.
+
+With --offline, the input file must be a signing kit created by transaction export-kit.
+The kit is validated and signed without any network access, and updated in place.`,
 		RunE:         signTx,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -43,6 +49,7 @@ func newTransactionSignCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji only]")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji)")
 	cmd.Flags().StringSliceVar(&ledgerAddresses, "ledger-addrs", []string{}, "use the given ledger addresses")
+	cmd.Flags().BoolVar(&offline, "offline", false, "sign a signing kit created with export-kit, without network access")
 	return cmd
 }
 
@@ -51,12 +58,34 @@ func signTx(_ *cobra.Command, args []string) error {
 	if inputTxPath == "" {
 		inputTxPath, err = app.Prompt.CaptureExistingFilepath("What is the path to the transactions file which needs signing?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--"+inputTxPathFlag)
 		}
 	}
-	tx, err := txutils.LoadFromDisk(inputTxPath)
-	if err != nil {
-		return err
+	var (
+		tx         *txs.Tx
+		kit        *txutils.SigningKit
+		kitBackend *txutils.KitSignerBackend
+	)
+	if offline {
+		kit, err = txutils.LoadSigningKit(inputTxPath)
+		if err != nil {
+			return err
+		}
+		tx, kitBackend, err = kit.Verify()
+		if err != nil {
+			return err
+		}
+		if len(kit.FeePayer) > 0 {
+			ux.Logger.PrintToUser("Tx fees are paid by %s", strings.Join(kit.FeePayer, ", "))
+		}
+	} else {
+		if txutils.IsSigningKitFile(inputTxPath) {
+			return fmt.Errorf("%s is a signing kit, sign it with --offline", inputTxPath)
+		}
+		tx, err = txutils.LoadFromDisk(inputTxPath)
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(ledgerAddresses) > 0 {
@@ -91,26 +120,36 @@ func signTx(_ *cobra.Command, args []string) error {
 
 	// we need subnet wallet signing validation + process
 	subnetName := args[0]
-	sc, err := app.LoadSidecar(subnetName)
-	if err != nil {
-		return err
-	}
-	subnetID := sc.Networks[network.Name()].SubnetID
-	if subnetID == ids.Empty {
-		return errNoSubnetID
-	}
+	var (
+		subnetID    ids.ID
+		controlKeys []string
+	)
+	if offline {
+		// owners were already validated against the subnet tx in the kit
+		subnetID = kit.SubnetID
+		controlKeys = kit.ControlKeys
+	} else {
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return err
+		}
+		subnetID = sc.Networks[network.Name()].SubnetID
+		if subnetID == ids.Empty {
+			return errNoSubnetID
+		}
 
-	subnetIDFromTX, err := txutils.GetSubnetID(tx)
-	if err != nil {
-		return err
-	}
-	if subnetIDFromTX != ids.Empty {
-		subnetID = subnetIDFromTX
-	}
+		subnetIDFromTX, err := txutils.GetSubnetID(tx)
+		if err != nil {
+			return err
+		}
+		if subnetIDFromTX != ids.Empty {
+			subnetID = subnetIDFromTX
+		}
 
-	controlKeys, _, err := txutils.GetOwners(network, subnetID)
-	if err != nil {
-		return err
+		controlKeys, _, err = txutils.GetOwners(network, subnetID)
+		if err != nil {
+			return err
+		}
 	}
 
 	// get the remaining tx signers so as to check that the wallet does contain an expected signer
@@ -137,7 +176,12 @@ func signTx(_ *cobra.Command, args []string) error {
 	}
 
 	deployer := subnet.NewPublicDeployer(app, kc, network)
-	if err := deployer.Sign(tx, remainingSubnetAuthKeys, subnetID); err != nil {
+	if offline {
+		err = deployer.SignOffline(tx, remainingSubnetAuthKeys, kitBackend)
+	} else {
+		err = deployer.Sign(tx, remainingSubnetAuthKeys, subnetID)
+	}
+	if err != nil {
 		if errors.Is(err, subnet.ErrNoSubnetAuthKeysInWallet) {
 			ux.Logger.PrintToUser("There are no required subnet auth keys present in the wallet")
 			ux.Logger.PrintToUser("")
@@ -157,6 +201,10 @@ func signTx(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	if offline {
+		return saveSignedKit(kit, tx, subnetName, subnetAuthKeys, remainingSubnetAuthKeys)
+	}
+
 	if err := subnetcmd.SaveNotFullySignedTx(
 		"Tx",
 		tx,
@@ -171,3 +219,29 @@ func signTx(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// updates the signing kit at [inputTxPath] with the newly signed [tx]
+func saveSignedKit(
+	kit *txutils.SigningKit,
+	tx *txs.Tx,
+	subnetName string,
+	subnetAuthKeys []string,
+	remainingSubnetAuthKeys []string,
+) error {
+	if err := kit.SetTx(tx); err != nil {
+		return err
+	}
+	signedCount := len(subnetAuthKeys) - len(remainingSubnetAuthKeys)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("%d of %d required signatures have been signed. Updating signing kit %s",
+		signedCount, len(subnetAuthKeys), inputTxPath)
+	if err := kit.Save(inputTxPath, true); err != nil {
+		return err
+	}
+	if len(remainingSubnetAuthKeys) == 0 {
+		subnetcmd.PrintReadyToSignMsg(subnetName, inputTxPath)
+	} else {
+		subnetcmd.PrintRemainingToSignMsg(subnetName, remainingSubnetAuthKeys, inputTxPath)
+	}
+	return nil
+}
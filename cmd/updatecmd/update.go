@@ -13,6 +13,7 @@ import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/binutils"
 	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -97,6 +98,10 @@ func Update(cmd *cobra.Command, isUserCalled bool, version string, lastActs *app
 		ux.Logger.PrintToUser("We found a new version of Lux-CLI %s upstream. You are running %s", latest, thisVFmt)
 		y, err := app.Prompt.CaptureYesNo("Do you want to update?")
 		if err != nil {
+			// the update check run before other commands must not make them fail
+			if isUserCalled {
+				return prompts.WithHint(err, "--confirm")
+			}
 			return nil
 		}
 		if !y {
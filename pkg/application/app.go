@@ -13,6 +13,8 @@ import (
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
 	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/cli/pkg/state"
+	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/subnet-evm/core"
@@ -26,6 +28,19 @@ type Lux struct {
 	Lpm        *lpm.LPM
 	LpmDir     string
 	Downloader Downloader
+	// OutputFormat selects table (default), json or yaml output
+	// for listing and describe commands
+	OutputFormat ux.OutputFormat
+	// passphrases of the encrypted keys already loaded, by key path
+	keyPassphrases map[string][]byte
+	// project workspace holding the subnet configurations, if any
+	workspaceDir string
+	// backend sharing the subnet and node state with a team, if any
+	stateBackend state.Backend
+	// hash of each shared state object as last synced with stateBackend, by key
+	stateVersions map[string]string
+	// backend version of each shared state object as last pulled, by key
+	stateRemoteVersions map[string]string
 }
 
 func New() *Lux {
@@ -53,7 +68,7 @@ func (app *Lux) GetBaseDir() string {
 }
 
 func (app *Lux) GetSubnetDir() string {
-	return filepath.Join(app.baseDir, constants.SubnetDir)
+	return filepath.Join(app.GetWorkspaceDir(), constants.SubnetDir)
 }
 
 func (app *Lux) GetNodesDir() string {
@@ -230,8 +245,15 @@ func (app *Lux) ReadUpgradeFile(subnetName string) ([]byte, error) {
 	return app.readFile(upgradeBytesFilePath)
 }
 
+// GetUpgradeBytesLockFilePath returns the path of the upgrade bytes last applied
+// to the local network for [subnetName]. As it depends on the local network, it
+// is kept in the base dir even when the subnet is in a workspace
+func (app *Lux) GetUpgradeBytesLockFilePath(subnetName string) string {
+	return filepath.Join(app.baseDir, constants.SubnetDir, subnetName, constants.UpgradeBytesFileName+constants.UpgradeBytesLockExtension)
+}
+
 func (app *Lux) ReadLockUpgradeFile(subnetName string) ([]byte, error) {
-	upgradeBytesLockFilePath := app.GetUpgradeBytesFilePath(subnetName) + constants.UpgradeBytesLockExtension
+	upgradeBytesLockFilePath := app.GetUpgradeBytesLockFilePath(subnetName)
 
 	return app.readFile(upgradeBytesLockFilePath)
 }
@@ -243,7 +265,7 @@ func (app *Lux) WriteUpgradeFile(subnetName string, bytes []byte) error {
 }
 
 func (app *Lux) WriteLockUpgradeFile(subnetName string, bytes []byte) error {
-	upgradeBytesLockFilePath := app.GetUpgradeBytesFilePath(subnetName) + constants.UpgradeBytesLockExtension
+	upgradeBytesLockFilePath := app.GetUpgradeBytesLockFilePath(subnetName)
 
 	return app.writeFile(upgradeBytesLockFilePath, bytes)
 }
@@ -407,7 +429,7 @@ func (app *Lux) CreateSidecar(sc *models.Sidecar) error {
 		return err
 	}
 
-	return os.WriteFile(sidecarPath, scBytes, constants.WriteReadReadPerms)
+	return app.writeStateFile(sidecarPath, scBytes)
 }
 
 func (app *Lux) LoadSidecar(subnetName string) (models.Sidecar, error) {
@@ -435,7 +457,7 @@ func (app *Lux) UpdateSidecar(sc *models.Sidecar) error {
 	}
 
 	sidecarPath := app.GetSidecarPath(sc.Name)
-	return os.WriteFile(sidecarPath, scBytes, constants.WriteReadReadPerms)
+	return app.writeStateFile(sidecarPath, scBytes)
 }
 
 func (app *Lux) UpdateSidecarNetworks(
@@ -577,7 +599,7 @@ func (app *Lux) CreateNodeCloudConfigFile(nodeName string, nodeConfig *models.No
 		return err
 	}
 
-	return os.WriteFile(nodeConfigPath, esBytes, constants.WriteReadReadPerms)
+	return app.writeStateFile(nodeConfigPath, esBytes)
 }
 
 func (app *Lux) CreateElasticSubnetConfig(subnetName string, es *models.ElasticSubnetConfig) error {
@@ -669,7 +691,7 @@ func (app *Lux) WriteClustersConfigFile(clustersConfig *models.ClustersConfig) e
 		return err
 	}
 
-	return os.WriteFile(clustersConfigPath, clustersConfigBytes, constants.WriteReadReadPerms)
+	return app.writeStateFile(clustersConfigPath, clustersConfigBytes)
 }
 
 func (*Lux) GetSSHCertFilePath(certName string) (string, error) {
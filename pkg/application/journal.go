@@ -0,0 +1,79 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+)
+
+// GetJournalPath returns the path of the journal of the txs issued for
+// [subnetName], next to its sidecar
+func (app *Lux) GetJournalPath(subnetName string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, constants.JournalFileName)
+}
+
+// AppendJournalEntry adds [entry] at the end of the journal of [subnetName].
+// The journal has one JSON entry per line, and each entry is synced to disk
+// before returning, so that it survives the CLI being interrupted
+func (app *Lux) AppendJournalEntry(subnetName string, entry models.JournalEntry) error {
+	journalPath := app.GetJournalPath(subnetName)
+	if err := os.MkdirAll(filepath.Dir(journalPath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.WriteReadReadPerms)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(entryBytes, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadJournal returns the journal entries of [subnetName] in the order they
+// were written. A subnet without journal has no entries
+func (app *Lux) LoadJournal(subnetName string) ([]models.JournalEntry, error) {
+	journalPath := app.GetJournalPath(subnetName)
+	f, err := os.Open(journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []models.JournalEntry{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry models.JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed unmarshaling line %d of journal %s: %w", line, journalPath, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading journal %s: %w", journalPath, err)
+	}
+	return entries, nil
+}
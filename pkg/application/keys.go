@@ -0,0 +1,136 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/prompts"
+)
+
+var errPassphraseMismatch = errors.New("passphrases do not match")
+
+// LoadKey loads the stored key referenced by [keyRef]. It is either a key name, or
+// <keyName>/<accountIndex> to select one of the accounts of a mnemonic key.
+func (app *Lux) LoadKey(networkID uint32, keyRef string) (*key.SoftKey, error) {
+	keyName, index, err := key.ParseKeyRef(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	return app.loadSoftKeyAccount(networkID, app.GetKeyPath(keyName), index)
+}
+
+// LoadSoftKey loads the stored key at [keyPath]. If the key is encrypted, the passphrase
+// is taken from the LUX_KEY_PASSPHRASE or LUX_KEY_PASSPHRASE_FILE env vars, or asked to the user.
+// Passphrases are remembered for the rest of the command execution.
+func (app *Lux) LoadSoftKey(networkID uint32, keyPath string) (*key.SoftKey, error) {
+	return app.loadSoftKeyAccount(networkID, keyPath, 0)
+}
+
+func (app *Lux) loadSoftKeyAccount(networkID uint32, keyPath string, index uint32) (*key.SoftKey, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsHD(kb) {
+		h, err := key.LoadHDFromBytes(kb)
+		if err != nil {
+			return nil, err
+		}
+		return h.Account(networkID, index)
+	}
+	if !key.IsEncrypted(kb) {
+		if index != 0 {
+			return nil, key.ErrNotHDKey
+		}
+		return key.LoadSoftFromBytes(networkID, kb)
+	}
+	var sk *key.SoftKey
+	err = app.withKeyPassphrase(keyPath, func(passphrase []byte) error {
+		var err error
+		sk, err = key.LoadSoftAccountFromEncryptedBytes(networkID, kb, passphrase, index)
+		return err
+	})
+	return sk, err
+}
+
+// withKeyPassphrase calls [decrypt] with the passphrase of the encrypted key at [keyPath],
+// remembering it if the decryption succeeds
+func (app *Lux) withKeyPassphrase(keyPath string, decrypt func([]byte) error) error {
+	if passphrase, ok := app.keyPassphrases[keyPath]; ok {
+		return decrypt(passphrase)
+	}
+	keyName := strings.TrimSuffix(filepath.Base(keyPath), constants.KeySuffix)
+	passphrase, err := app.GetKeyPassphrase(fmt.Sprintf("Passphrase for key %s", keyName))
+	if err != nil {
+		return err
+	}
+	if err := decrypt(passphrase); err != nil {
+		return err
+	}
+	if app.keyPassphrases == nil {
+		app.keyPassphrases = map[string][]byte{}
+	}
+	app.keyPassphrases[keyPath] = passphrase
+	return nil
+}
+
+// GetNewKeyPassphrase obtains the passphrase to encrypt a key with. When asked to
+// the user, it has to be typed twice.
+func (app *Lux) GetNewKeyPassphrase() ([]byte, error) {
+	if passphrase, ok, err := getEnvKeyPassphrase(); ok || err != nil {
+		return passphrase, err
+	}
+	passphrase, err := app.GetKeyPassphrase("New key passphrase")
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := app.Prompt.CapturePassword("Repeat the key passphrase")
+	if err != nil {
+		return nil, prompts.WithHint(err, constants.KeyPassphraseEnvVarName+" or "+constants.KeyPassphraseFileEnvVarName)
+	}
+	if string(passphrase) != confirmation {
+		return nil, errPassphraseMismatch
+	}
+	return passphrase, nil
+}
+
+// GetKeyPassphrase obtains the passphrase of an existing key, from the env vars or
+// asking the user with [promptStr]
+func (app *Lux) GetKeyPassphrase(promptStr string) ([]byte, error) {
+	if passphrase, ok, err := getEnvKeyPassphrase(); ok || err != nil {
+		return passphrase, err
+	}
+	passphrase, err := app.Prompt.CapturePassword(promptStr)
+	if err != nil {
+		return nil, prompts.WithHint(err, constants.KeyPassphraseEnvVarName+" or "+constants.KeyPassphraseFileEnvVarName)
+	}
+	return []byte(passphrase), nil
+}
+
+// getEnvKeyPassphrase returns the passphrase given through env vars, if any
+func getEnvKeyPassphrase() ([]byte, bool, error) {
+	if passphrase := os.Getenv(constants.KeyPassphraseEnvVarName); passphrase != "" {
+		return []byte(passphrase), true, nil
+	}
+	passphraseFile := os.Getenv(constants.KeyPassphraseFileEnvVarName)
+	if passphraseFile == "" {
+		return nil, false, nil
+	}
+	passphrase, err := os.ReadFile(passphraseFile)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read %s: %w", constants.KeyPassphraseFileEnvVarName, err)
+	}
+	// files usually end with a newline that is not part of the passphrase
+	passphrase = []byte(strings.TrimRight(string(passphrase), "\r\n"))
+	if len(passphrase) == 0 {
+		return nil, true, fmt.Errorf("%s points to an empty file", constants.KeyPassphraseFileEnvVarName)
+	}
+	return passphrase, true, nil
+}
@@ -0,0 +1,386 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/state"
+	"go.uber.org/zap"
+)
+
+// ErrStateConflict is returned when a shared state object was changed by
+// someone else since it was pulled
+var ErrStateConflict = errors.New("shared state conflict")
+
+// stateIndex records the state objects as last synced with a backend, so
+// that objects deleted on the backend are also deleted locally, and only the
+// objects changed on the backend are fetched again
+type stateIndex struct {
+	URL      string
+	Versions map[string]string
+	// backend versions of the objects as last pulled, by key
+	RemoteVersions map[string]string
+}
+
+// SetStateBackend makes the subnet configurations and the node state (cluster
+// config, ansible inventories and node instance dirs) be shared through
+// [backend]. With a nil backend they only live in the local dirs
+func (app *Lux) SetStateBackend(backend state.Backend) {
+	app.stateBackend = backend
+	app.stateVersions = map[string]string{}
+	app.stateRemoteVersions = map[string]string{}
+}
+
+func (app *Lux) GetStateBackend() state.Backend {
+	return app.stateBackend
+}
+
+func (app *Lux) getStateIndexPath() string {
+	return filepath.Join(app.baseDir, constants.StateIndexFileName)
+}
+
+// local dirs holding the shared state, by key prefix
+func (app *Lux) getStateDirs() map[string]string {
+	return map[string]string{
+		constants.SubnetDir: app.GetSubnetDir(),
+		constants.NodesDir:  app.GetNodesDir(),
+	}
+}
+
+// terraform and ansible files are regenerated by each command, and the lock
+// of the upgrades applied to the local network is machine local
+func isSharedStateKey(key string) bool {
+	excludedDirs := []string{
+		constants.NodesDir + "/" + constants.TerraformDir + "/",
+		constants.NodesDir + "/" + constants.AnsibleDir + "/",
+	}
+	for _, dir := range excludedDirs {
+		if strings.HasPrefix(key+"/", dir) {
+			return false
+		}
+	}
+	return !strings.HasSuffix(key, constants.UpgradeBytesLockExtension)
+}
+
+// getStateKey returns the backend key of the local file [path], or false if
+// it is not shared state
+func (app *Lux) getStateKey(path string) (string, bool) {
+	for prefix, dir := range app.getStateDirs() {
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+		key := prefix + "/" + filepath.ToSlash(relPath)
+		return key, isSharedStateKey(key)
+	}
+	return "", false
+}
+
+// getStatePath returns the local path of the backend [key]
+func (app *Lux) getStatePath(key string) (string, error) {
+	prefix, relPath, _ := strings.Cut(key, "/")
+	dir, ok := app.getStateDirs()[prefix]
+	if !ok || relPath == "" || strings.Contains("/"+relPath+"/", "/../") {
+		return "", fmt.Errorf("invalid shared state key %q", key)
+	}
+	return filepath.Join(dir, filepath.FromSlash(relPath)), nil
+}
+
+func getStateVersion(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// returns the version of the local file [path], or an empty string if it does not exist
+func getFileStateVersion(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return getStateVersion(data), nil
+}
+
+func (app *Lux) loadStateIndex() (stateIndex, error) {
+	emptyIndex := stateIndex{
+		Versions:       map[string]string{},
+		RemoteVersions: map[string]string{},
+	}
+	indexBytes, err := os.ReadFile(app.getStateIndexPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return emptyIndex, nil
+	}
+	if err != nil {
+		return stateIndex{}, err
+	}
+	var index stateIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return stateIndex{}, fmt.Errorf("invalid shared state index %s: %w", app.getStateIndexPath(), err)
+	}
+	if index.URL != app.stateBackend.URL() || index.Versions == nil {
+		// synced with another backend
+		return emptyIndex, nil
+	}
+	if index.RemoteVersions == nil {
+		index.RemoteVersions = map[string]string{}
+	}
+	return index, nil
+}
+
+func (app *Lux) saveStateIndex() error {
+	indexBytes, err := json.MarshalIndent(stateIndex{
+		URL:            app.stateBackend.URL(),
+		Versions:       app.stateVersions,
+		RemoteVersions: app.stateRemoteVersions,
+	}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return app.writeFile(app.getStateIndexPath(), indexBytes)
+}
+
+// PullState updates the local subnet and node state from the state backend.
+// Only the objects changed on the backend since they were last pulled are
+// fetched. Local files changed since they were last synced are kept, and will
+// fail to be pushed if they were also changed on the backend
+func (app *Lux) PullState() error {
+	if app.stateBackend == nil {
+		return nil
+	}
+	index, err := app.loadStateIndex()
+	if err != nil {
+		return err
+	}
+	app.stateVersions = map[string]string{}
+	app.stateRemoteVersions = map[string]string{}
+	remoteKeys := map[string]string{}
+	for prefix := range app.getStateDirs() {
+		versions, err := app.stateBackend.ListVersions(prefix + "/")
+		if err != nil {
+			return fmt.Errorf("failed to list shared state at %s: %w", app.stateBackend.URL(), err)
+		}
+		for key, version := range versions {
+			if isSharedStateKey(key) {
+				remoteKeys[key] = version
+			}
+		}
+	}
+	for key, listedVersion := range remoteKeys {
+		path, err := app.getStatePath(key)
+		if err != nil {
+			return err
+		}
+		localVersion, err := getFileStateVersion(path)
+		if err != nil {
+			return err
+		}
+		version, synced := index.Versions[key]
+		if synced && localVersion != "" && index.RemoteVersions[key] == listedVersion {
+			// unchanged on the backend, so the local file is kept as it is
+			app.stateVersions[key] = version
+			app.stateRemoteVersions[key] = listedVersion
+			continue
+		}
+		data, readVersion, err := app.stateBackend.ReadVersion(key)
+		if err != nil {
+			return fmt.Errorf("failed to read shared state %s: %w", key, err)
+		}
+		remoteVersion := getStateVersion(data)
+		switch {
+		case localVersion == remoteVersion:
+		case localVersion == "" || localVersion == version:
+			if err := os.MkdirAll(filepath.Dir(path), constants.DefaultPerms755); err != nil {
+				return err
+			}
+			// node instance dirs hold staking keys
+			if err := os.WriteFile(path, data, constants.WriteReadUserOnlyPerms); err != nil {
+				return err
+			}
+		default:
+			app.Log.Warn("shared state changed both locally and on the backend, keeping the local one", zap.String("key", key))
+			if synced {
+				app.stateVersions[key] = version
+			}
+			continue
+		}
+		app.stateVersions[key] = remoteVersion
+		app.stateRemoteVersions[key] = readVersion
+	}
+	// deleted by someone else
+	for key, version := range index.Versions {
+		if _, ok := remoteKeys[key]; ok {
+			continue
+		}
+		path, err := app.getStatePath(key)
+		if err != nil {
+			return err
+		}
+		localVersion, err := getFileStateVersion(path)
+		if err != nil {
+			return err
+		}
+		if localVersion == version {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return app.saveStateIndex()
+}
+
+// PushState sends to the state backend the local subnet and node state
+// changed since it was pulled, and deletes the objects removed locally. Objects
+// changed on the backend in the meantime are left as they are, and reported
+// in the returned error
+func (app *Lux) PushState() error {
+	if app.stateBackend == nil {
+		return nil
+	}
+	localVersions := map[string]string{}
+	for _, dir := range app.getStateDirs() {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			key, ok := app.getStateKey(path)
+			if d.IsDir() {
+				if key != "" && !ok {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !ok {
+				return nil
+			}
+			version, err := getFileStateVersion(path)
+			if err != nil {
+				return err
+			}
+			localVersions[key] = version
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	keys := []string{}
+	for key, version := range localVersions {
+		if app.stateVersions[key] != version {
+			keys = append(keys, key)
+		}
+	}
+	for key := range app.stateVersions {
+		if _, ok := localVersions[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	pushErrs := []error{}
+	for _, key := range keys {
+		if err := app.pushStateObject(key); err != nil {
+			pushErrs = append(pushErrs, err)
+		}
+	}
+	if err := app.saveStateIndex(); err != nil {
+		pushErrs = append(pushErrs, err)
+	}
+	return errors.Join(pushErrs...)
+}
+
+// pushStateObject writes the local file of [key] to the state backend, or
+// deletes it there if the local file does not exist
+func (app *Lux) pushStateObject(key string) error {
+	path, err := app.getStatePath(key)
+	if err != nil {
+		return err
+	}
+	return app.withStateLock(key, func() error {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			if err := app.stateBackend.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete shared state %s: %w", key, err)
+			}
+			delete(app.stateVersions, key)
+			delete(app.stateRemoteVersions, key)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := app.stateBackend.Write(key, data); err != nil {
+			return fmt.Errorf("failed to write shared state %s: %w", key, err)
+		}
+		app.setPushedStateVersion(key, data)
+		return nil
+	})
+}
+
+// records [data] as the synced content of [key]. Its backend version is not
+// known, so it is fetched again on the next pull
+func (app *Lux) setPushedStateVersion(key string, data []byte) {
+	app.stateVersions[key] = getStateVersion(data)
+	delete(app.stateRemoteVersions, key)
+}
+
+// withStateLock runs [f] holding the backend lock of [key], once checked that
+// nobody else changed [key] since it was pulled
+func (app *Lux) withStateLock(key string, f func() error) error {
+	unlock, err := app.stateBackend.Lock(key)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			app.Log.Warn("failed to release shared state lock", zap.String("key", key), zap.Error(err))
+		}
+	}()
+	remoteVersion := ""
+	data, err := app.stateBackend.Read(key)
+	switch {
+	case err == nil:
+		remoteVersion = getStateVersion(data)
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to read shared state %s: %w", key, err)
+	}
+	if remoteVersion != app.stateVersions[key] {
+		return fmt.Errorf("%w: %s was changed by someone else since it was loaded, run the command again to work on the latest version", ErrStateConflict, key)
+	}
+	return f()
+}
+
+// writeStateFile writes [data] to the local file [path] and, if it is shared
+// state, to the state backend under lock. It fails without writing if someone
+// else changed the shared object since it was pulled
+func (app *Lux) writeStateFile(path string, data []byte) error {
+	key, ok := app.getStateKey(path)
+	if app.stateBackend == nil || !ok {
+		return os.WriteFile(path, data, constants.WriteReadReadPerms)
+	}
+	err := app.withStateLock(key, func() error {
+		if err := app.stateBackend.Write(key, data); err != nil {
+			return fmt.Errorf("failed to write shared state %s: %w", key, err)
+		}
+		app.setPushedStateVersion(key, data)
+		return os.WriteFile(path, data, constants.WriteReadReadPerms)
+	})
+	if err != nil {
+		return err
+	}
+	return app.saveStateIndex()
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/state"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStateApp(t *testing.T, backend state.Backend) *Lux {
+	ap := newTestApp(t)
+	ap.SetStateBackend(backend)
+	require.NoError(t, ap.PullState())
+	return ap
+}
+
+func TestSharedState(t *testing.T) {
+	require := require.New(t)
+
+	backend, err := state.NewLocalBackend(t.TempDir())
+	require.NoError(err)
+	alice := newTestStateApp(t, backend)
+
+	// sidecars and cluster configs are written through
+	sc := &models.Sidecar{Name: subnetName1, VM: models.SubnetEvm}
+	require.NoError(alice.CreateSidecar(sc))
+	require.NoError(alice.WriteClustersConfigFile(&models.ClustersConfig{
+		Clusters: map[string]models.ClusterConfig{"team": {Nodes: []string{"i-1"}}},
+	}))
+	_, err = backend.Read(constants.SubnetDir + "/" + subnetName1 + "/" + constants.SidecarFileName)
+	require.NoError(err)
+
+	// other files are pushed at the end of the command
+	require.NoError(alice.WriteGenesisFile(subnetName1, []byte("{}")))
+	require.NoError(alice.WriteLockUpgradeFile(subnetName1, []byte("{}")))
+	inventoryPath := filepath.Join(alice.GetAnsibleInventoryDirPath("team"), constants.AnsibleHostInventoryFileName)
+	require.NoError(os.MkdirAll(filepath.Dir(inventoryPath), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(inventoryPath, []byte("hosts"), constants.WriteReadReadPerms))
+	require.NoError(os.MkdirAll(alice.GetTerraformDir(), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(alice.GetTerraformDir(), "node_config.tf"), nil, constants.WriteReadReadPerms))
+	require.NoError(alice.PushState())
+
+	keys, err := backend.List("")
+	require.NoError(err)
+	require.ElementsMatch([]string{
+		constants.SubnetDir + "/" + subnetName1 + "/" + constants.SidecarFileName,
+		constants.SubnetDir + "/" + subnetName1 + "/" + constants.GenesisFileName,
+		constants.NodesDir + "/" + constants.ClustersConfigFileName,
+		constants.NodesDir + "/" + constants.AnsibleInventoryDir + "/team/" + constants.AnsibleHostInventoryFileName,
+	}, keys)
+
+	// a colleague gets the same state
+	bob := newTestStateApp(t, backend)
+	bobSc, err := bob.LoadSidecar(subnetName1)
+	require.NoError(err)
+	require.Equal(subnetName1, bobSc.Name)
+	clustersConfig, err := bob.LoadClustersConfig()
+	require.NoError(err)
+	require.Equal([]string{"i-1"}, clustersConfig.Clusters["team"].Nodes)
+	require.FileExists(filepath.Join(bob.GetAnsibleInventoryDirPath("team"), constants.AnsibleHostInventoryFileName))
+
+	// a sidecar changed by a colleague since it was loaded can't be overwritten
+	bobSc.Subnet = "bob"
+	require.NoError(bob.UpdateSidecar(&bobSc))
+	sc.Subnet = "alice"
+	require.ErrorIs(alice.UpdateSidecar(sc), ErrStateConflict)
+	require.NoError(alice.PullState())
+	aliceSc, err := alice.LoadSidecar(subnetName1)
+	require.NoError(err)
+	require.Equal("bob", aliceSc.Subnet)
+	aliceSc.Subnet = "alice"
+	require.NoError(alice.UpdateSidecar(&aliceSc))
+
+	// deletions are propagated, but not over changes made by someone else
+	require.NoError(os.RemoveAll(filepath.Join(bob.GetSubnetDir(), subnetName1)))
+	require.ErrorIs(bob.PushState(), ErrStateConflict)
+	require.NoError(alice.PullState())
+	require.FileExists(alice.GetSidecarPath(subnetName1))
+	require.NoFileExists(alice.GetGenesisPath(subnetName1))
+}
+
+// countingBackend counts the objects read from the backend it wraps
+type countingBackend struct {
+	state.Backend
+	reads int
+}
+
+func (b *countingBackend) ReadVersion(key string) ([]byte, string, error) {
+	b.reads++
+	return b.Backend.ReadVersion(key)
+}
+
+func TestPullStateFetchesChangedObjects(t *testing.T) {
+	require := require.New(t)
+
+	localBackend, err := state.NewLocalBackend(t.TempDir())
+	require.NoError(err)
+	alice := newTestStateApp(t, localBackend)
+	require.NoError(alice.CreateSidecar(&models.Sidecar{Name: subnetName1, VM: models.SubnetEvm}))
+	require.NoError(alice.WriteGenesisFile(subnetName1, []byte("{}")))
+	require.NoError(alice.PushState())
+
+	backend := &countingBackend{Backend: localBackend}
+	bob := newTestStateApp(t, backend)
+	require.Equal(2, backend.reads)
+
+	// nothing changed
+	backend.reads = 0
+	require.NoError(bob.PullState())
+	require.Zero(backend.reads)
+
+	// only the changed object is fetched
+	require.NoError(alice.WriteGenesisFile(subnetName1, []byte(`{"config":{}}`)))
+	require.NoError(alice.PushState())
+	require.NoError(bob.PullState())
+	require.Equal(1, backend.reads)
+	genesisBytes, err := os.ReadFile(bob.GetGenesisPath(subnetName1))
+	require.NoError(err)
+	require.Equal([]byte(`{"config":{}}`), genesisBytes)
+
+	// a file deleted locally is fetched again
+	backend.reads = 0
+	require.NoError(os.Remove(bob.GetGenesisPath(subnetName1)))
+	require.NoError(bob.PullState())
+	require.Equal(1, backend.reads)
+	require.FileExists(bob.GetGenesisPath(subnetName1))
+}
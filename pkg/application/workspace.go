@@ -0,0 +1,57 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/luxdefi/cli/pkg/constants"
+)
+
+// FindWorkspace returns the closest .lux workspace found walking up from [dir],
+// or an empty string if there is none. A .lux directory in the user home is not
+// taken as a workspace, as it would apply to every project below it
+func FindWorkspace(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if dir != homeDir {
+			workspaceDir := filepath.Join(dir, constants.WorkspaceDirName)
+			info, err := os.Stat(workspaceDir)
+			if err == nil && info.IsDir() {
+				return workspaceDir, nil
+			}
+			if err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+		}
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return "", nil
+		}
+		dir = parentDir
+	}
+}
+
+// SetWorkspaceDir makes the committable subnet configurations (sidecars,
+// genesis, chain configs and upgrade files) be kept in [workspaceDir] instead
+// of the base dir. Binaries, snapshots, logs and keys stay in the base dir
+func (app *Lux) SetWorkspaceDir(workspaceDir string) {
+	app.workspaceDir = workspaceDir
+}
+
+// GetWorkspaceDir returns the directory holding the subnet configurations: the
+// project workspace if any, or else the base dir
+func (app *Lux) GetWorkspaceDir() string {
+	if app.workspaceDir == "" {
+		return app.baseDir
+	}
+	return app.workspaceDir
+}
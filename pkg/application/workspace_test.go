@@ -0,0 +1,64 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkspace(t *testing.T) {
+	require := require.New(t)
+
+	projectDir := t.TempDir()
+	nestedDir := filepath.Join(projectDir, "a", "b")
+	require.NoError(os.MkdirAll(nestedDir, constants.DefaultPerms755))
+
+	workspaceDir, err := FindWorkspace(nestedDir)
+	require.NoError(err)
+	require.Empty(workspaceDir)
+
+	// a file named .lux is not a workspace
+	require.NoError(os.WriteFile(filepath.Join(nestedDir, constants.WorkspaceDirName), nil, constants.WriteReadReadPerms))
+	expectedDir := filepath.Join(projectDir, constants.WorkspaceDirName)
+	require.NoError(os.Mkdir(expectedDir, constants.DefaultPerms755))
+
+	workspaceDir, err = FindWorkspace(nestedDir)
+	require.NoError(err)
+	require.Equal(expectedDir, workspaceDir)
+
+	workspaceDir, err = FindWorkspace(projectDir)
+	require.NoError(err)
+	require.Equal(expectedDir, workspaceDir)
+}
+
+func TestWorkspaceSubnetFiles(t *testing.T) {
+	require := require.New(t)
+
+	ap := newTestApp(t)
+	require.Equal(filepath.Join(ap.GetBaseDir(), constants.SubnetDir), ap.GetSubnetDir())
+	require.Equal(
+		ap.GetUpgradeBytesFilePath(subnetName1)+constants.UpgradeBytesLockExtension,
+		ap.GetUpgradeBytesLockFilePath(subnetName1),
+	)
+
+	workspaceDir := filepath.Join(t.TempDir(), constants.WorkspaceDirName)
+	ap.SetWorkspaceDir(workspaceDir)
+	require.Equal(filepath.Join(workspaceDir, constants.SubnetDir), ap.GetSubnetDir())
+
+	sc := &models.Sidecar{Name: subnetName1, VM: models.SubnetEvm}
+	require.NoError(ap.CreateSidecar(sc))
+	require.FileExists(filepath.Join(workspaceDir, constants.SubnetDir, subnetName1, constants.SidecarFileName))
+	require.NoError(ap.WriteUpgradeFile(subnetName1, []byte("{}")))
+	require.FileExists(filepath.Join(workspaceDir, constants.SubnetDir, subnetName1, constants.UpgradeBytesFileName))
+
+	// the lock of the upgrades applied to the local network is machine-local
+	require.NoError(ap.WriteLockUpgradeFile(subnetName1, []byte("{}")))
+	require.FileExists(filepath.Join(ap.GetBaseDir(), constants.SubnetDir, subnetName1, constants.UpgradeBytesFileName+constants.UpgradeBytesLockExtension))
+	require.NoFileExists(ap.GetUpgradeBytesFilePath(subnetName1) + constants.UpgradeBytesLockExtension)
+}
@@ -138,8 +138,13 @@ func installZipArchive(zipfile []byte, binDir string) error {
 
 // installTarGzArchive expects a byte array in targz format
 func installTarGzArchive(targz []byte, binDir string) error {
-	byteReader := bytes.NewReader(targz)
-	uncompressedStream, err := gzip.NewReader(byteReader)
+	return InstallTarGzArchiveFromReader(bytes.NewReader(targz), binDir)
+}
+
+// InstallTarGzArchiveFromReader extracts the tar.gz archive read from [r] into
+// [binDir], without holding the whole archive in memory
+func InstallTarGzArchiveFromReader(r io.Reader, binDir string) error {
+	uncompressedStream, err := gzip.NewReader(r)
 	if err != nil {
 		return fmt.Errorf("failed creating gzip reader from node binary stream: %w", err)
 	}
@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/subnet-evm/core/types"
+	"github.com/luxdefi/subnet-evm/ethclient"
+)
+
+// gas of a plain transfer
+const probeTxGas = 21_000
+
+// ChainClient queries a custom chain, and sends it probe transactions, through
+// the RPC of a node
+type ChainClient interface {
+	BlockNumber(ctx context.Context, nodeURI string, blockchainID string) (uint64, error)
+	SendProbeTx(ctx context.Context, nodeURI string, blockchainID string) error
+}
+
+type evmChainClient struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewEVMChainClient returns a ChainClient for EVM chains. EVM chains only build
+// blocks when they have transactions, so its probe transactions are zero value
+// transfers of the prefunded ewoq address to itself
+func NewEVMChainClient() (ChainClient, error) {
+	key, err := crypto.HexToECDSA(vm.PrefundedEwoqPrivate)
+	if err != nil {
+		return nil, err
+	}
+	return &evmChainClient{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}, nil
+}
+
+func (c *evmChainClient) dial(ctx context.Context, nodeURI string, blockchainID string) (ethclient.Client, error) {
+	return ethclient.DialContext(ctx, fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI, blockchainID))
+}
+
+func (c *evmChainClient) BlockNumber(ctx context.Context, nodeURI string, blockchainID string) (uint64, error) {
+	client, err := c.dial(ctx, nodeURI, blockchainID)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	return client.BlockNumber(ctx)
+}
+
+func (c *evmChainClient) SendProbeTx(ctx context.Context, nodeURI string, blockchainID string) error {
+	client, err := c.dial(ctx, nodeURI, blockchainID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	nonce, err := client.AcceptedNonceAt(ctx, c.address)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      probeTxGas,
+		To:       &c.address,
+		Value:    big.NewInt(0),
+	}), types.LatestSignerForChainID(chainID), c.key)
+	if err != nil {
+		return err
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to send probe tx from %s: %w", c.address.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import "time"
+
+// ChainResult is the liveness check of a chain after a step
+type ChainResult struct {
+	ChainName    string `json:"chainName"`
+	BlockchainID string `json:"blockchainID"`
+	HeightBefore uint64 `json:"heightBefore"`
+	HeightAfter  uint64 `json:"heightAfter"`
+	// time from the end of the step to the first new block
+	RecoverySeconds float64 `json:"recoverySeconds"`
+	Passed          bool    `json:"passed"`
+	Error           string  `json:"error,omitempty"`
+}
+
+type StepResult struct {
+	Index           int           `json:"index"`
+	Action          Action        `json:"action"`
+	Nodes           []string      `json:"nodes,omitempty"`
+	Start           time.Time     `json:"start"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Passed          bool          `json:"passed"`
+	Error           string        `json:"error,omitempty"`
+	Chains          []ChainResult `json:"chains"`
+}
+
+// Report is the result of a scenario run. It passes if all the chains
+// produced new blocks after every step
+type Report struct {
+	Scenario        string       `json:"scenario"`
+	Seed            int64        `json:"seed"`
+	Start           time.Time    `json:"start"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Passed          bool         `json:"passed"`
+	Steps           []StepResult `json:"steps"`
+}
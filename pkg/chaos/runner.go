@@ -0,0 +1,468 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/client"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/luxdefi/node/utils/set"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+// VMUpgrader installs [vmBinary] as the VM of [subnetName], to be loaded by the
+// nodes when they restart
+type VMUpgrader func(subnetName string, vmBinary string) error
+
+// Runner runs scenarios against the local network managed by [cli]
+type Runner struct {
+	cli          client.Client
+	chainClient  ChainClient
+	upgradeVM    VMUpgrader
+	seed         int64
+	rand         *rand.Rand
+	pollInterval time.Duration
+	restartOpts  []client.OpOption
+}
+
+// NewRunner creates a runner that chooses random nodes from [seed], so a failed
+// run can be repeated. [restartOpts] are given to the node restarts
+func NewRunner(
+	cli client.Client,
+	chainClient ChainClient,
+	upgradeVM VMUpgrader,
+	seed int64,
+	restartOpts ...client.OpOption,
+) *Runner {
+	return &Runner{
+		cli:          cli,
+		chainClient:  chainClient,
+		upgradeVM:    upgradeVM,
+		seed:         seed,
+		rand:         rand.New(rand.NewSource(seed)), //nolint:gosec
+		pollInterval: defaultPollInterval,
+		restartOpts:  restartOpts,
+	}
+}
+
+type chain struct {
+	name         string
+	blockchainID string
+	subnetID     string
+}
+
+// Run runs the scenario steps in order. It stops at the first step whose fault
+// could not be injected, as the network state is then unknown. An error is
+// only returned if the scenario can't start
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) (*Report, error) {
+	clusterInfo, err := r.getClusterInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := getChains(clusterInfo, scenario.Chains)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		Scenario: scenario.Name,
+		Seed:     r.seed,
+		Start:    time.Now(),
+		Passed:   true,
+		Steps:    []StepResult{},
+	}
+	for i, step := range scenario.Steps {
+		ux.Logger.PrintToUser("Step %d/%d: %s", i+1, len(scenario.Steps), step.Action)
+		result := r.runStep(ctx, scenario, step, chains)
+		result.Index = i + 1
+		report.Steps = append(report.Steps, result)
+		report.Passed = report.Passed && result.Passed
+		if result.Error != "" {
+			ux.Logger.PrintToUser("Step %d failed: %s", i+1, result.Error)
+			break
+		}
+		for _, chainResult := range result.Chains {
+			if chainResult.Passed {
+				ux.Logger.PrintToUser("  chain %s produced block %d after %.1fs",
+					chainResult.ChainName, chainResult.HeightAfter, chainResult.RecoverySeconds)
+			} else {
+				ux.Logger.PrintToUser("  chain %s failed: %s", chainResult.ChainName, chainResult.Error)
+			}
+		}
+	}
+	report.DurationSeconds = time.Since(report.Start).Seconds()
+	return report, nil
+}
+
+func (r *Runner) runStep(ctx context.Context, scenario *Scenario, step Step, chains []chain) StepResult {
+	result := StepResult{
+		Action: step.Action,
+		Start:  time.Now(),
+		Chains: []ChainResult{},
+	}
+	defer func() {
+		result.DurationSeconds = time.Since(result.Start).Seconds()
+	}()
+	clusterInfo, err := r.getClusterInfo(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Nodes, err = r.selectNodes(clusterInfo, step, chains)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	heightsBefore := map[string]uint64{}
+	for _, c := range chains {
+		// an unreachable chain just needs to produce any block
+		heightsBefore[c.blockchainID], _ = r.getHeight(ctx, clusterInfo, c.blockchainID)
+	}
+	if err := r.injectFault(ctx, step, result.Nodes); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	clusterInfo, err = r.getClusterInfo(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	deadline := scenario.Deadline
+	if step.Deadline > 0 {
+		deadline = step.Deadline
+	}
+	result.Chains = r.checkChains(ctx, clusterInfo, chains, heightsBefore, deadline, !scenario.NoTraffic)
+	result.Passed = true
+	for _, chainResult := range result.Chains {
+		result.Passed = result.Passed && chainResult.Passed
+	}
+	return result
+}
+
+func (r *Runner) injectFault(ctx context.Context, step Step, nodeNames []string) error {
+	switch step.Action {
+	case KillAction:
+		for _, nodeName := range nodeNames {
+			ux.Logger.PrintToUser("  removing node %s", nodeName)
+			if err := r.anrOp(ctx, func(ctx context.Context) error {
+				_, err := r.cli.RemoveNode(ctx, nodeName)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to remove node %s: %w", nodeName, err)
+			}
+		}
+	case PauseAction:
+		for _, nodeName := range nodeNames {
+			ux.Logger.PrintToUser("  pausing node %s", nodeName)
+			if err := r.anrOp(ctx, func(ctx context.Context) error {
+				_, err := r.cli.PauseNode(ctx, nodeName)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to pause node %s: %w", nodeName, err)
+			}
+		}
+		sleepErr := sleep(ctx, step.Duration)
+		// the nodes are resumed even if the run was interrupted, so the
+		// network is left as it was found
+		for _, nodeName := range nodeNames {
+			ux.Logger.PrintToUser("  resuming node %s", nodeName)
+			if err := r.anrOp(context.Background(), func(ctx context.Context) error {
+				_, err := r.cli.ResumeNode(ctx, nodeName)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to resume node %s: %w", nodeName, err)
+			}
+		}
+		if sleepErr != nil {
+			return sleepErr
+		}
+		return r.waitForHealthy(ctx)
+	case RestartAction:
+		if step.VMBinary != "" {
+			ux.Logger.PrintToUser("  installing VM binary %s for subnet %s", step.VMBinary, step.Subnet)
+			if err := r.upgradeVM(step.Subnet, step.VMBinary); err != nil {
+				return err
+			}
+		}
+		for _, nodeName := range nodeNames {
+			ux.Logger.PrintToUser("  restarting node %s", nodeName)
+			if err := r.anrOp(ctx, func(ctx context.Context) error {
+				_, err := r.cli.RestartNode(ctx, nodeName, r.restartOpts...)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to restart node %s: %w", nodeName, err)
+			}
+		}
+		return r.waitForHealthy(ctx)
+	case WaitAction:
+		return sleep(ctx, step.Duration)
+	}
+	return nil
+}
+
+// checks that every chain produces a block higher than the one before the
+// step, within [deadline]. Probe transactions are sent to trigger the blocks
+func (r *Runner) checkChains(
+	ctx context.Context,
+	clusterInfo *rpcpb.ClusterInfo,
+	chains []chain,
+	heightsBefore map[string]uint64,
+	deadline time.Duration,
+	traffic bool,
+) []ChainResult {
+	results := make([]ChainResult, len(chains))
+	for i, c := range chains {
+		results[i] = ChainResult{
+			ChainName:    c.name,
+			BlockchainID: c.blockchainID,
+			HeightBefore: heightsBefore[c.blockchainID],
+		}
+	}
+	start := time.Now()
+	for {
+		done := true
+		for i := range results {
+			result := &results[i]
+			if result.Passed {
+				continue
+			}
+			var probeErr error
+			if traffic {
+				probeErr = r.sendProbeTx(ctx, clusterInfo, result.BlockchainID)
+			}
+			height, err := r.getHeight(ctx, clusterInfo, result.BlockchainID)
+			switch {
+			case err != nil:
+				result.Error = err.Error()
+			case height > result.HeightBefore:
+				result.HeightAfter = height
+				result.RecoverySeconds = time.Since(start).Seconds()
+				result.Passed = true
+				result.Error = ""
+				continue
+			case probeErr != nil:
+				result.HeightAfter = height
+				result.Error = probeErr.Error()
+			default:
+				result.HeightAfter = height
+				result.Error = ""
+			}
+			done = false
+		}
+		if done {
+			return results
+		}
+		if time.Since(start) >= deadline {
+			for i := range results {
+				result := &results[i]
+				if result.Passed {
+					continue
+				}
+				if result.Error == "" {
+					result.Error = fmt.Sprintf("no new block within %s", deadline)
+				} else {
+					result.Error = fmt.Sprintf("no new block within %s: %s", deadline, result.Error)
+				}
+			}
+			return results
+		}
+		if err := sleep(ctx, r.pollInterval); err != nil {
+			for i := range results {
+				if !results[i].Passed {
+					results[i].Error = err.Error()
+				}
+			}
+			return results
+		}
+	}
+}
+
+// returns the highest block of the chain among the running nodes
+func (r *Runner) getHeight(ctx context.Context, clusterInfo *rpcpb.ClusterInfo, blockchainID string) (uint64, error) {
+	var (
+		maxHeight uint64
+		found     bool
+		lastErr   error = errors.New("no running nodes")
+	)
+	for _, nodeInfo := range getRunningNodes(clusterInfo) {
+		apiCtx, cancel := context.WithTimeout(ctx, constants.APIRequestTimeout)
+		height, err := r.chainClient.BlockNumber(apiCtx, nodeInfo.Uri, blockchainID)
+		cancel()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		found = true
+		if height > maxHeight {
+			maxHeight = height
+		}
+	}
+	if !found {
+		return 0, lastErr
+	}
+	return maxHeight, nil
+}
+
+// sends a probe tx through the first running node that accepts it
+func (r *Runner) sendProbeTx(ctx context.Context, clusterInfo *rpcpb.ClusterInfo, blockchainID string) error {
+	var lastErr error = errors.New("no running nodes")
+	for _, nodeInfo := range getRunningNodes(clusterInfo) {
+		apiCtx, cancel := context.WithTimeout(ctx, constants.APIRequestTimeout)
+		lastErr = r.chainClient.SendProbeTx(apiCtx, nodeInfo.Uri, blockchainID)
+		cancel()
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// returns the nodes named by the step, or [Step.Random] validators of the
+// chains chosen at random. The node serving the local API endpoint is never
+// chosen at random, as the CLI needs it
+func (r *Runner) selectNodes(clusterInfo *rpcpb.ClusterInfo, step Step, chains []chain) ([]string, error) {
+	if step.Action == WaitAction {
+		return nil, nil
+	}
+	if len(step.Nodes) > 0 {
+		for _, nodeName := range step.Nodes {
+			nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+			if !ok {
+				return nil, fmt.Errorf("node %s is not part of the local network, available nodes: %s",
+					nodeName, strings.Join(clusterInfo.NodeNames, ", "))
+			}
+			if nodeInfo.Paused {
+				return nil, fmt.Errorf("node %s is paused", nodeName)
+			}
+		}
+		return step.Nodes, nil
+	}
+	candidates := getValidators(clusterInfo, chains)
+	if len(candidates) < step.Random {
+		return nil, fmt.Errorf("%d nodes requested, but only %d validators can be chosen at random: %s",
+			step.Random, len(candidates), strings.Join(candidates, ", "))
+	}
+	r.rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	selected := candidates[:step.Random]
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// returns the running validators of the subnets of [chains], or all the
+// running nodes if the network has no record of them
+func getValidators(clusterInfo *rpcpb.ClusterInfo, chains []chain) []string {
+	validators := set.Set[string]{}
+	for _, c := range chains {
+		subnetInfo, ok := clusterInfo.Subnets[c.subnetID]
+		if ok && subnetInfo.SubnetParticipants != nil {
+			validators.Add(subnetInfo.SubnetParticipants.NodeNames...)
+		}
+	}
+	candidates := []string{}
+	for _, nodeInfo := range getRunningNodes(clusterInfo) {
+		if validators.Len() > 0 && !validators.Contains(nodeInfo.Name) {
+			continue
+		}
+		if nodeInfo.Uri == constants.LocalAPIEndpoint {
+			continue
+		}
+		candidates = append(candidates, nodeInfo.Name)
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+func getRunningNodes(clusterInfo *rpcpb.ClusterInfo) []*rpcpb.NodeInfo {
+	nodeInfos := []*rpcpb.NodeInfo{}
+	for _, nodeName := range clusterInfo.NodeNames {
+		if nodeInfo, ok := clusterInfo.NodeInfos[nodeName]; ok && !nodeInfo.Paused {
+			nodeInfos = append(nodeInfos, nodeInfo)
+		}
+	}
+	return nodeInfos
+}
+
+// returns the custom chains with the given names, or all of them if
+// [chainNames] is empty
+func getChains(clusterInfo *rpcpb.ClusterInfo, chainNames []string) ([]chain, error) {
+	byName := map[string]chain{}
+	for blockchainID, chainInfo := range clusterInfo.CustomChains {
+		byName[chainInfo.ChainName] = chain{
+			name:         chainInfo.ChainName,
+			blockchainID: blockchainID,
+			subnetID:     chainInfo.SubnetId,
+		}
+	}
+	available := make([]string, 0, len(byName))
+	for name := range byName {
+		available = append(available, name)
+	}
+	sort.Strings(available)
+	if len(available) == 0 {
+		return nil, errors.New("the local network has no custom chains to check, deploy a subnet first")
+	}
+	if len(chainNames) == 0 {
+		chainNames = available
+	}
+	chains := []chain{}
+	for _, chainName := range chainNames {
+		c, ok := byName[chainName]
+		if !ok {
+			return nil, fmt.Errorf("chain %s not found in the local network, available chains: %s",
+				chainName, strings.Join(available, ", "))
+		}
+		chains = append(chains, c)
+	}
+	return chains, nil
+}
+
+func (r *Runner) getClusterInfo(ctx context.Context) (*rpcpb.ClusterInfo, error) {
+	var clusterInfo *rpcpb.ClusterInfo
+	err := r.anrOp(ctx, func(ctx context.Context) error {
+		status, err := r.cli.Status(ctx)
+		if err != nil {
+			return err
+		}
+		clusterInfo = status.ClusterInfo
+		return nil
+	})
+	return clusterInfo, err
+}
+
+func (r *Runner) waitForHealthy(ctx context.Context) error {
+	if err := r.anrOp(ctx, func(ctx context.Context) error {
+		_, err := r.cli.WaitForHealthy(ctx)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	return nil
+}
+
+func (r *Runner) anrOp(ctx context.Context, op func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.ANRRequestTimeout)
+	defer cancel()
+	return op(ctx)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
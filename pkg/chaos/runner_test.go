@@ -0,0 +1,154 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/luxdefi/cli/internal/mocks"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// chain whose height grows with each probe tx, unless halted
+type fakeChainClient struct {
+	height uint64
+	halted bool
+}
+
+func (c *fakeChainClient) BlockNumber(context.Context, string, string) (uint64, error) {
+	return c.height, nil
+}
+
+func (c *fakeChainClient) SendProbeTx(context.Context, string, string) error {
+	if c.halted {
+		return errors.New("tx not accepted")
+	}
+	c.height++
+	return nil
+}
+
+func newTestClusterInfo() *rpcpb.ClusterInfo {
+	return &rpcpb.ClusterInfo{
+		NodeNames: []string{"node1", "node2", "node3", "node4"},
+		NodeInfos: map[string]*rpcpb.NodeInfo{
+			"node1": {Name: "node1", Uri: constants.LocalAPIEndpoint},
+			"node2": {Name: "node2", Uri: "http://127.0.0.1:9652"},
+			"node3": {Name: "node3", Uri: "http://127.0.0.1:9654"},
+			"node4": {Name: "node4", Uri: "http://127.0.0.1:9656"},
+		},
+		CustomChains: map[string]*rpcpb.CustomChainInfo{
+			"chainID": {ChainName: "test", SubnetId: "subnetID"},
+		},
+		Subnets: map[string]*rpcpb.SubnetInfo{
+			"subnetID": {SubnetParticipants: &rpcpb.SubnetParticipants{NodeNames: []string{"node1", "node2", "node3"}}},
+		},
+	}
+}
+
+func newTestRunner(cli *mocks.Client, chainClient ChainClient, upgradeVM VMUpgrader) *Runner {
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	cli.On("Status", mock.Anything).Return(&rpcpb.StatusResponse{ClusterInfo: newTestClusterInfo()}, nil)
+	runner := NewRunner(cli, chainClient, upgradeVM, 1)
+	runner.pollInterval = time.Millisecond
+	return runner
+}
+
+func TestRunScenario(t *testing.T) {
+	require := require.New(t)
+
+	cli := &mocks.Client{}
+	upgrades := []string{}
+	runner := newTestRunner(cli, &fakeChainClient{}, func(subnetName string, vmBinary string) error {
+		upgrades = append(upgrades, subnetName+":"+vmBinary)
+		return nil
+	})
+	cli.On("RemoveNode", mock.Anything, mock.Anything).Return(&rpcpb.RemoveNodeResponse{}, nil)
+	cli.On("PauseNode", mock.Anything, "node4").Return(&rpcpb.PauseNodeResponse{}, nil)
+	cli.On("ResumeNode", mock.Anything, "node4").Return(&rpcpb.ResumeNodeResponse{}, nil)
+	cli.On("RestartNode", mock.Anything, "node3").Return(&rpcpb.RestartNodeResponse{}, nil)
+	cli.On("WaitForHealthy", mock.Anything).Return(&rpcpb.WaitForHealthyResponse{}, nil)
+
+	report, err := runner.Run(context.Background(), &Scenario{
+		Name:     "test",
+		Deadline: time.Second,
+		Steps: []Step{
+			{Action: KillAction, Random: 1},
+			{Action: PauseAction, Nodes: []string{"node4"}, Duration: time.Millisecond},
+			{Action: RestartAction, Nodes: []string{"node3"}, VMBinary: "vm", Subnet: "test"},
+			{Action: WaitAction, Duration: time.Millisecond},
+		},
+	})
+	require.NoError(err)
+	require.True(report.Passed)
+	require.Equal(int64(1), report.Seed)
+	require.Len(report.Steps, 4)
+	for i, step := range report.Steps {
+		require.Equal(i+1, step.Index)
+		require.True(step.Passed)
+		require.Len(step.Chains, 1)
+		require.Equal("test", step.Chains[0].ChainName)
+		require.Greater(step.Chains[0].HeightAfter, step.Chains[0].HeightBefore)
+	}
+	// the local API node and non validators are never chosen at random
+	require.Len(report.Steps[0].Nodes, 1)
+	require.Contains([]string{"node2", "node3"}, report.Steps[0].Nodes[0])
+	cli.AssertCalled(t, "RemoveNode", mock.Anything, report.Steps[0].Nodes[0])
+	cli.AssertCalled(t, "ResumeNode", mock.Anything, "node4")
+	require.Equal([]string{"test:vm"}, upgrades)
+	cli.AssertNumberOfCalls(t, "WaitForHealthy", 2)
+}
+
+func TestRunScenarioFailures(t *testing.T) {
+	require := require.New(t)
+
+	cli := &mocks.Client{}
+	runner := newTestRunner(cli, &fakeChainClient{halted: true}, nil)
+	report, err := runner.Run(context.Background(), &Scenario{
+		Name:     "test",
+		Deadline: 10 * time.Millisecond,
+		Steps:    []Step{{Action: WaitAction, Duration: time.Millisecond}},
+	})
+	require.NoError(err)
+	require.False(report.Passed)
+	require.Empty(report.Steps[0].Error)
+	require.False(report.Steps[0].Chains[0].Passed)
+	require.Contains(report.Steps[0].Chains[0].Error, "no new block within 10ms: tx not accepted")
+
+	// the scenario stops at the first fault that can't be injected
+	cli = &mocks.Client{}
+	runner = newTestRunner(cli, &fakeChainClient{}, nil)
+	cli.On("RemoveNode", mock.Anything, "node2").Return(nil, errors.New("unknown node"))
+	report, err = runner.Run(context.Background(), &Scenario{
+		Name:     "test",
+		Deadline: time.Second,
+		Steps: []Step{
+			{Action: KillAction, Nodes: []string{"node2"}},
+			{Action: WaitAction, Duration: time.Millisecond},
+		},
+	})
+	require.NoError(err)
+	require.False(report.Passed)
+	require.Len(report.Steps, 1)
+	require.Contains(report.Steps[0].Error, "failed to remove node node2")
+
+	_, err = runner.Run(context.Background(), &Scenario{
+		Chains: []string{"missing"},
+		Steps:  []Step{{Action: WaitAction, Duration: time.Millisecond}},
+	})
+	require.ErrorContains(err, "chain missing not found in the local network, available chains: test")
+
+	report, err = runner.Run(context.Background(), &Scenario{
+		Steps: []Step{{Action: KillAction, Random: 3}},
+	})
+	require.NoError(err)
+	require.Contains(report.Steps[0].Error, "3 nodes requested, but only 2 validators can be chosen at random")
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDeadline is the time given to the chains to produce a new block after
+// a step, when the scenario does not set one
+const DefaultDeadline = time.Minute
+
+type Action string
+
+const (
+	// KillAction removes the nodes from the network
+	KillAction Action = "kill"
+	// PauseAction stops the nodes for [Step.Duration], and then resumes them
+	PauseAction Action = "pause"
+	// RestartAction restarts the nodes, optionally replacing the VM binary of
+	// [Step.Subnet] before
+	RestartAction Action = "restart"
+	// WaitAction does nothing for [Step.Duration]
+	WaitAction Action = "wait"
+)
+
+// Scenario is a list of faults injected into the local network, one after the
+// other. After each step, the chains must produce a new block within the deadline
+type Scenario struct {
+	Name string `yaml:"name"`
+	// names of the custom chains to check, all of them if empty
+	Chains []string `yaml:"chains"`
+	// time for the chains to produce a new block after each step
+	Deadline time.Duration `yaml:"deadline"`
+	// do not send probe transactions, for chains that get their own traffic
+	NoTraffic bool   `yaml:"noTraffic"`
+	Steps     []Step `yaml:"steps"`
+}
+
+type Step struct {
+	Action Action `yaml:"action"`
+	// nodes to act on, given by name
+	Nodes []string `yaml:"nodes"`
+	// number of validators of the checked chains to act on, chosen at random
+	Random   int           `yaml:"random"`
+	Duration time.Duration `yaml:"duration"`
+	// VM binary installed for [Subnet] before a restart
+	VMBinary string `yaml:"vmBinary"`
+	Subnet   string `yaml:"subnet"`
+	// overrides the scenario deadline
+	Deadline time.Duration `yaml:"deadline"`
+}
+
+// LoadScenario reads and validates a scenario from a YAML file
+func LoadScenario(path string) (*Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	scenario := &Scenario{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	// catch misspelled fields, that would silently change the scenario
+	decoder.KnownFields(true)
+	if err := decoder.Decode(scenario); err != nil {
+		return nil, fmt.Errorf("invalid scenario file %s: %w", path, err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario file %s: %w", path, err)
+	}
+	return scenario, nil
+}
+
+// Validate checks the scenario steps and sets the default deadline
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("scenario has no steps")
+	}
+	if s.Deadline < 0 {
+		return errors.New("deadline can't be negative")
+	}
+	if s.Deadline == 0 {
+		s.Deadline = DefaultDeadline
+	}
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Action, err)
+		}
+	}
+	return nil
+}
+
+func (s Step) validate() error {
+	if s.Deadline < 0 {
+		return errors.New("deadline can't be negative")
+	}
+	if s.Duration < 0 {
+		return errors.New("duration can't be negative")
+	}
+	if s.Random < 0 {
+		return errors.New("random can't be negative")
+	}
+	switch s.Action {
+	case KillAction, PauseAction, RestartAction:
+		if len(s.Nodes) == 0 && s.Random == 0 {
+			return errors.New("either nodes or random must be given")
+		}
+		if len(s.Nodes) > 0 && s.Random > 0 {
+			return errors.New("nodes and random are mutually exclusive")
+		}
+	case WaitAction:
+		if len(s.Nodes) > 0 || s.Random > 0 {
+			return errors.New("wait doesn't act on nodes")
+		}
+		if s.Duration == 0 {
+			return errors.New("duration must be given")
+		}
+	case "":
+		return errors.New("action must be given")
+	default:
+		return fmt.Errorf("unknown action, expected one of %s, %s, %s or %s",
+			KillAction, PauseAction, RestartAction, WaitAction)
+	}
+	if s.Action == PauseAction && s.Duration == 0 {
+		return errors.New("duration must be given")
+	}
+	if s.Action != PauseAction && s.Action != WaitAction && s.Duration > 0 {
+		return errors.New("duration is only valid for pause and wait")
+	}
+	if (s.VMBinary != "" || s.Subnet != "") && s.Action != RestartAction {
+		return errors.New("vmBinary is only valid for restart")
+	}
+	if (s.VMBinary == "") != (s.Subnet == "") {
+		return errors.New("vmBinary and subnet must be given together")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package chaos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testScenario = `name: upgrade rehearsal
+chains: [test]
+deadline: 45s
+steps:
+  - action: kill
+    random: 1
+  - action: pause
+    nodes: [node3, node4]
+    duration: 10s
+  - action: restart
+    nodes: [node3]
+    vmBinary: /tmp/subnet-evm
+    subnet: test
+    deadline: 2m
+  - action: wait
+    duration: 30s
+`
+
+func TestLoadScenario(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(os.WriteFile(path, []byte(testScenario), 0o600))
+	scenario, err := LoadScenario(path)
+	require.NoError(err)
+	require.Equal("upgrade rehearsal", scenario.Name)
+	require.Equal([]string{"test"}, scenario.Chains)
+	require.Equal(45*time.Second, scenario.Deadline)
+	require.Len(scenario.Steps, 4)
+	require.Equal(Step{Action: KillAction, Random: 1}, scenario.Steps[0])
+	require.Equal(Step{Action: PauseAction, Nodes: []string{"node3", "node4"}, Duration: 10 * time.Second}, scenario.Steps[1])
+	require.Equal(Step{
+		Action:   RestartAction,
+		Nodes:    []string{"node3"},
+		VMBinary: "/tmp/subnet-evm",
+		Subnet:   "test",
+		Deadline: 2 * time.Minute,
+	}, scenario.Steps[2])
+	require.Equal(Step{Action: WaitAction, Duration: 30 * time.Second}, scenario.Steps[3])
+
+	// misspelled fields are rejected
+	require.NoError(os.WriteFile(path, []byte("steps:\n  - action: kill\n    node: [node1]\n"), 0o600))
+	_, err = LoadScenario(path)
+	require.ErrorContains(err, "field node not found")
+
+	require.NoError(os.WriteFile(path, []byte("steps:\n  - action: wait\n    duration: 1s\n"), 0o600))
+	scenario, err = LoadScenario(path)
+	require.NoError(err)
+	require.Equal(path, scenario.Name)
+	require.Equal(DefaultDeadline, scenario.Deadline)
+}
+
+func TestValidateScenario(t *testing.T) {
+	tests := []struct {
+		name string
+		step Step
+		err  string
+	}{
+		{"missing action", Step{Nodes: []string{"node1"}}, "action must be given"},
+		{"unknown action", Step{Action: "crash", Nodes: []string{"node1"}}, "unknown action"},
+		{"no nodes", Step{Action: KillAction}, "either nodes or random must be given"},
+		{"nodes and random", Step{Action: KillAction, Nodes: []string{"node1"}, Random: 1}, "mutually exclusive"},
+		{"pause without duration", Step{Action: PauseAction, Random: 1}, "duration must be given"},
+		{"kill with duration", Step{Action: KillAction, Random: 1, Duration: time.Second}, "only valid for pause and wait"},
+		{"wait with nodes", Step{Action: WaitAction, Nodes: []string{"node1"}, Duration: time.Second}, "doesn't act on nodes"},
+		{"vm binary on pause", Step{Action: PauseAction, Random: 1, Duration: time.Second, VMBinary: "vm", Subnet: "test"}, "only valid for restart"},
+		{"vm binary without subnet", Step{Action: RestartAction, Random: 1, VMBinary: "vm"}, "must be given together"},
+		{"negative deadline", Step{Action: KillAction, Random: 1, Deadline: -time.Second}, "deadline can't be negative"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scenario := &Scenario{Steps: []Step{test.step}}
+			require.ErrorContains(t, scenario.Validate(), test.err)
+		})
+	}
+	require.ErrorContains(t, (&Scenario{}).Validate(), "no steps")
+}
@@ -14,6 +14,9 @@ const (
 	BaseDirName = ".cli"
 	LogDir      = "logs"
 
+	// project directory holding the subnet configurations, found walking up from the CWD
+	WorkspaceDirName = ".lux"
+
 	ServerRunFile      = "gRPCserver.run"
 	LuxCliBinDir = "bin"
 	RunDir             = "runs"
@@ -22,6 +25,8 @@ const (
 	SidecarFileName              = "sidecar.json"
 	GenesisFileName              = "genesis.json"
 	ElasticSubnetConfigFileName  = "elastic_subnet_config.json"
+	JournalFileName              = "journal.jsonl"
+	StateIndexFileName           = "state_index.json"
 	SidecarSuffix                = SuffixSeparator + SidecarFileName
 	GenesisSuffix                = SuffixSeparator + GenesisFileName
 	NodeFileName                 = "node.json"
@@ -158,6 +163,9 @@ const (
 	ConfigMetricsEnabledKey      = "MetricsEnabled"
 	ConfigAutorizeCloudAccessKey = "AutorizeCloudAccess"
 	ConfigSingleNodeEnabledKey   = "SingleNodeEnabled"
+	ConfigStateBackendKey        = "state-backend"
+	ConfigStateEndpointKey       = "state-backend-endpoint"
+	ConfigStateRegionKey         = "state-backend-region"
 	OldConfigFileName            = ".cli.json"
 	OldMetricsConfigFileName     = ".cli/config"
 	DefaultConfigFileName        = ".cli/config.json"
@@ -198,6 +206,13 @@ const (
 	// #nosec G101
 	GithubAPITokenEnvVarName = "LUX_CLI_GITHUB_TOKEN"
 
+	// #nosec G101
+	KeyPassphraseEnvVarName = "LUX_KEY_PASSPHRASE"
+	// #nosec G101
+	KeyPassphraseFileEnvVarName = "LUX_KEY_PASSPHRASE_FILE"
+
+	BaseDirEnvVarName = "LUX_CLI_HOME"
+
 	ReposDir                   = "repos"
 	SubnetDir                  = "subnets"
 	NodesDir                   = "nodes"
@@ -237,10 +252,13 @@ const (
 
 	PluginDir = "plugins"
 
-	Network        = "network"
-	MultiSig       = "multi-sig"
-	SkipUpdateFlag = "skip-update-check"
-	LastFileName   = ".last_actions.json"
+	Network            = "network"
+	MultiSig           = "multi-sig"
+	SkipUpdateFlag     = "skip-update-check"
+	OutputFormatFlag   = "output"
+	NonInteractiveFlag = "non-interactive"
+	BaseDirFlag        = "base-dir"
+	LastFileName       = ".last_actions.json"
 
 	DefaultWalletCreationTimeout = 5 * time.Second
 
@@ -64,7 +64,7 @@ func GetElasticSubnetConfig(app *application.Lux, tokenSymbol string, useDefault
 		elasticSubnetConfigOptions,
 	)
 	if err != nil {
-		return models.ElasticSubnetConfig{}, err
+		return models.ElasticSubnetConfig{}, prompts.WithHint(err, "--default")
 	}
 
 	if chosenConfig == defaultConfig {
@@ -138,7 +138,7 @@ func getInitialSupply(app *application.Lux, tokenName string) (uint64, error) {
 	ux.Logger.PrintToUser(fmt.Sprintf("Mainnet Initial Supply is %s", ux.ConvertToStringWithThousandSeparator(defaultInitialSupply)))
 	initialSupply, err := app.Prompt.CaptureUint64("Initial Supply amount")
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	return initialSupply, nil
 }
@@ -157,7 +157,7 @@ func getMaximumSupply(app *application.Lux, tokenName string, initialSupply uint
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	return maxSupply, nil
 }
@@ -177,7 +177,7 @@ func getConsumptionRate(app *application.Lux) (uint64, uint64, error) {
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 
 	ux.Logger.PrintToUser("Select the Maximum Consumption Rate. Please denominate your percentage in PercentDenominator")
@@ -199,7 +199,7 @@ func getConsumptionRate(app *application.Lux) (uint64, uint64, error) {
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 	return minConsumptionRate, maxConsumptionRate, nil
 }
@@ -223,7 +223,7 @@ func getValidatorStake(app *application.Lux, initialSupply uint64, maximumSupply
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 
 	ux.Logger.PrintToUser("Select the Maximum Validator Stake. \"_\" can be used as thousand separator")
@@ -244,7 +244,7 @@ func getValidatorStake(app *application.Lux, initialSupply uint64, maximumSupply
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 	return minValidatorStake, maxValidatorStake, nil
 }
@@ -268,7 +268,7 @@ func getStakeDuration(app *application.Lux) (time.Duration, time.Duration, error
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 
 	ux.Logger.PrintToUser("Select the Maximum Stake Duration")
@@ -289,7 +289,7 @@ func getStakeDuration(app *application.Lux) (time.Duration, time.Duration, error
 		},
 	)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, prompts.WithHint(err, "--default")
 	}
 
 	return time.Duration(minStakeDuration) * time.Hour, time.Duration(maxStakeDuration) * time.Hour, nil
@@ -310,7 +310,7 @@ func getMinDelegationFee(app *application.Lux) (uint32, error) {
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	if minDelegationFee > math.MaxInt32 {
 		return 0, fmt.Errorf("minimum Delegation Fee needs to be unsigned 32-bit integer")
@@ -332,7 +332,7 @@ func getMinDelegatorStake(app *application.Lux) (uint64, error) {
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	return minDelegatorStake, nil
 }
@@ -352,7 +352,7 @@ func getMaxValidatorWeightFactor(app *application.Lux) (byte, error) {
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	if maxValidatorWeightFactor > math.MaxInt8 {
 		return 0, fmt.Errorf("maximum Validator Weight Factor needs to be unsigned 8-bit integer")
@@ -375,7 +375,7 @@ func getUptimeRequirement(app *application.Lux) (uint32, error) {
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, prompts.WithHint(err, "--default")
 	}
 	if uptimeReq > math.MaxInt32 {
 		return 0, fmt.Errorf("uptime Requirement needs to be unsigned 32-bit integer")
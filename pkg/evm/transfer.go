@@ -0,0 +1,102 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/subnet-evm/core/types"
+	"github.com/luxdefi/subnet-evm/ethclient"
+)
+
+const (
+	// gas of a plain transfer
+	transferGas         = 21_000
+	receiptPollInterval = time.Second
+)
+
+// LUXToWei converts an amount of the native token, given with up to 9 decimals as
+// on the P-Chain, to its 18 decimals EVM denomination
+func LUXToWei(amount float64) *big.Int {
+	nLUX := new(big.Int).SetUint64(uint64(amount * float64(units.Lux)))
+	return nLUX.Mul(nLUX, new(big.Int).SetUint64(units.Lux))
+}
+
+// WeiToLUX converts an EVM amount to units of the native token
+func WeiToLUX(wei *big.Int) float64 {
+	lux, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)).Float64()
+	return lux
+}
+
+// GetBalance returns the native token balance of [address] at the EVM chain served by [rpcURL]
+func GetBalance(ctx context.Context, rpcURL string, address common.Address) (*big.Int, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.BalanceAt(ctx, address, nil)
+}
+
+// Transfer sends [amount] of the native token from the address of [privKey] to [to]
+// at the EVM chain served by [rpcURL], and waits until the tx is accepted
+func Transfer(
+	ctx context.Context,
+	rpcURL string,
+	privKey *ecdsa.PrivateKey,
+	to common.Address,
+	amount *big.Int,
+) (common.Hash, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer client.Close()
+	from := crypto.PubkeyToAddress(privKey.PublicKey)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	nonce, err := client.AcceptedNonceAt(ctx, from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      transferGas,
+		To:       &to,
+		Value:    amount,
+	}), types.LatestSignerForChainID(chainID), privKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to send transfer from %s: %w", from.Hex(), err)
+	}
+	for {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return tx.Hash(), fmt.Errorf("transfer tx %s failed", tx.Hash().Hex())
+			}
+			return tx.Hash(), nil
+		}
+		select {
+		case <-ctx.Done():
+			return tx.Hash(), fmt.Errorf("timeout waiting for transfer tx %s: %w", tx.Hash().Hex(), ctx.Err())
+		case <-time.After(receiptPollInterval):
+		}
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/formatting/address"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedKeyVersion = 1
+	encryptedKeyCipher  = "aes-256-gcm"
+	encryptedKeyKDF     = "scrypt"
+
+	// same cost parameters as the go-ethereum standard keystore
+	scryptN       = 1 << 18
+	scryptR       = 8
+	scryptP       = 1
+	scryptKeyLen  = 32
+	scryptSaltLen = 32
+
+	// upper bounds of the cost parameters read from a key file, so that a
+	// crafted file can't make the key derivation exhaust memory or CPU.
+	// 128*N*r bytes of memory are used, 1 GiB at the max.
+	maxScryptN = 1 << 20
+	maxScryptR = 8
+	maxScryptP = 16
+)
+
+var (
+	ErrEncryptedKey     = errors.New("key is encrypted, a passphrase is required")
+	ErrWrongPassphrase  = errors.New("could not decrypt key with the given passphrase, or the key file was modified")
+	ErrEmptyPassphrase  = errors.New("passphrase can't be empty")
+	ErrKeyNotEncrypted  = errors.New("key is not encrypted")
+	errInvalidKeyFormat = errors.New("invalid encrypted key file")
+)
+
+// encryptedKeyFile is the on-disk format of an encrypted key, modeled after
+// the ethereum keystore v3 format. Addresses are kept in clear, so that the key
+// can be listed without its passphrase, and are authenticated by the cipher.
+type encryptedKeyFile struct {
+	Version int `json:"version"`
+	// empty for a private key, mnemonicKind for a mnemonic
+	Kind     string `json:"kind,omitempty"`
+	Address  string `json:"address"`
+	CAddress string `json:"cAddress"`
+	// addresses of all the accounts of a mnemonic
+	Accounts []encryptedKeyAccount `json:"accounts,omitempty"`
+	Crypto   cryptoParams          `json:"crypto"`
+}
+
+type encryptedKeyAccount struct {
+	Address  string `json:"address"`
+	CAddress string `json:"cAddress"`
+}
+
+// AccountAddresses are the P-Chain and C-Chain addresses of an account of a stored key
+type AccountAddresses struct {
+	P string
+	C string
+}
+
+type cryptoParams struct {
+	Cipher     string       `json:"cipher"`
+	CipherText string       `json:"ciphertext"`
+	Nonce      string       `json:"nonce"`
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+}
+
+type scryptParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"dklen"`
+	Salt   string `json:"salt"`
+}
+
+// IsEncrypted returns true if [kb] is the content of an encrypted key file
+func IsEncrypted(kb []byte) bool {
+	var f encryptedKeyFile
+	return json.Unmarshal(kb, &f) == nil && f.Version > 0 && f.Crypto.Cipher == encryptedKeyCipher && f.Crypto.CipherText != ""
+}
+
+// IsEncryptedFile returns true if the key file at [keyPath] is encrypted
+func IsEncryptedFile(keyPath string) (bool, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return false, err
+	}
+	return IsEncrypted(kb), nil
+}
+
+// SaveEncrypted saves the private key to disk, encrypted with a key derived from [passphrase].
+func (m *SoftKey) SaveEncrypted(p string, passphrase []byte) error {
+	kb, err := m.encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(p, kb)
+}
+
+// writeKeyFile replaces the key file [p] through a synced temp file renamed
+// over it, so that a crash while writing can't lose the key
+func writeKeyFile(p string, kb []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(p), filepath.Base(p)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if err := tmpFile.Chmod(constants.WriteReadUserOnlyPerms); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if _, err := tmpFile.Write(kb); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), p)
+}
+
+func (m *SoftKey) encrypt(passphrase []byte) ([]byte, error) {
+	return encryptKeyFile(m.privKeyRaw, "", []encryptedKeyAccount{m.encryptedKeyAccount()}, passphrase)
+}
+
+func (m *SoftKey) encryptedKeyAccount() encryptedKeyAccount {
+	addr := m.privKey.PublicKey().Address()
+	return encryptedKeyAccount{
+		Address:  hex.EncodeToString(addr[:]),
+		CAddress: m.C(),
+	}
+}
+
+// encryptKeyFile encrypts [plaintext] into the content of an encrypted key file. The
+// first of [accounts] is the default one.
+func encryptKeyFile(plaintext []byte, kind string, accounts []encryptedKeyAccount, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	f := encryptedKeyFile{
+		Version:  encryptedKeyVersion,
+		Kind:     kind,
+		Address:  accounts[0].Address,
+		CAddress: accounts[0].CAddress,
+		Crypto: cryptoParams{
+			Cipher: encryptedKeyCipher,
+			Nonce:  hex.EncodeToString(nonce),
+			KDF:    encryptedKeyKDF,
+			KDFParams: scryptParams{
+				N:      scryptN,
+				R:      scryptR,
+				P:      scryptP,
+				KeyLen: scryptKeyLen,
+				Salt:   hex.EncodeToString(salt),
+			},
+		},
+	}
+	if kind == mnemonicKind {
+		f.Accounts = accounts
+	}
+	additionalData, err := f.additionalData()
+	if err != nil {
+		return nil, err
+	}
+	f.Crypto.CipherText = hex.EncodeToString(gcm.Seal(nil, nonce, plaintext, additionalData))
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// additionalData returns the header fields authenticated together with the
+// ciphertext: the kind, the addresses of every account and their count, so
+// that none of them can be changed in the file
+func (f *encryptedKeyFile) additionalData() ([]byte, error) {
+	return json.Marshal(struct {
+		Version     int
+		Kind        string
+		Address     string
+		CAddress    string
+		NumAccounts int
+		Accounts    []encryptedKeyAccount
+	}{
+		Version:     f.Version,
+		Kind:        f.Kind,
+		Address:     f.Address,
+		CAddress:    f.CAddress,
+		NumAccounts: len(f.Accounts),
+		Accounts:    f.Accounts,
+	})
+}
+
+// LoadSoftEncrypted loads an encrypted private key from disk and creates the corresponding SoftKey.
+func LoadSoftEncrypted(networkID uint32, keyPath string, passphrase []byte) (*SoftKey, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSoftFromEncryptedBytes(networkID, kb, passphrase)
+}
+
+// LoadSoftFromEncryptedBytes decrypts the content of an encrypted key file and
+// creates the corresponding SoftKey. For mnemonics, the default account is used.
+func LoadSoftFromEncryptedBytes(networkID uint32, kb []byte, passphrase []byte) (*SoftKey, error) {
+	return LoadSoftAccountFromEncryptedBytes(networkID, kb, passphrase, 0)
+}
+
+// LoadSoftAccountFromEncryptedBytes decrypts the content of an encrypted key file and
+// creates the SoftKey of account [index]. Only mnemonics have accounts other than 0.
+func LoadSoftAccountFromEncryptedBytes(networkID uint32, kb []byte, passphrase []byte, index uint32) (*SoftKey, error) {
+	f, plaintext, err := decryptKeyFile(kb, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if f.Kind == mnemonicKind {
+		h, err := NewHD(string(plaintext), uint32(len(f.Accounts)))
+		if err != nil {
+			return nil, err
+		}
+		return h.Account(networkID, index)
+	}
+	if index != 0 {
+		return nil, ErrNotHDKey
+	}
+	privKey, err := secp256k1.ToPrivateKey(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return NewSoft(networkID, WithPrivateKey(privKey))
+}
+
+// LoadHDFromEncryptedBytes decrypts the content of an encrypted mnemonic key file
+func LoadHDFromEncryptedBytes(kb []byte, passphrase []byte) (*HDKey, error) {
+	f, plaintext, err := decryptKeyFile(kb, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if f.Kind != mnemonicKind {
+		return nil, ErrNotHDKey
+	}
+	return NewHD(string(plaintext), uint32(len(f.Accounts)))
+}
+
+func decryptKeyFile(kb []byte, passphrase []byte) (*encryptedKeyFile, []byte, error) {
+	var f encryptedKeyFile
+	if err := json.Unmarshal(kb, &f); err != nil || f.Version <= 0 {
+		return nil, nil, ErrKeyNotEncrypted
+	}
+	if f.Version > encryptedKeyVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported version %d", errInvalidKeyFormat, f.Version)
+	}
+	if f.Crypto.Cipher != encryptedKeyCipher || f.Crypto.KDF != encryptedKeyKDF {
+		return nil, nil, fmt.Errorf("%w: unsupported cipher %q or kdf %q", errInvalidKeyFormat, f.Crypto.Cipher, f.Crypto.KDF)
+	}
+	if f.Kind != "" && f.Kind != mnemonicKind {
+		return nil, nil, fmt.Errorf("%w: unsupported kind %q", errInvalidKeyFormat, f.Kind)
+	}
+	salt, err := hex.DecodeString(f.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+	}
+	nonce, err := hex.DecodeString(f.Crypto.Nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+	}
+	cipherText, err := hex.DecodeString(f.Crypto.CipherText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+	}
+	params := f.Crypto.KDFParams
+	if params.N > maxScryptN || params.R > maxScryptR || params.P > maxScryptP {
+		return nil, nil, fmt.Errorf("%w: scrypt parameters n=%d r=%d p=%d over the limits n=%d r=%d p=%d",
+			errInvalidKeyFormat, params.N, params.R, params.P, maxScryptN, maxScryptR, maxScryptP)
+	}
+	if params.KeyLen != scryptKeyLen {
+		return nil, nil, fmt.Errorf("%w: unsupported dklen %d", errInvalidKeyFormat, params.KeyLen)
+	}
+	derivedKey, err := scrypt.Key(passphrase, salt, params.N, params.R, params.P, params.KeyLen)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+	}
+	gcm, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, nil, fmt.Errorf("%w: invalid nonce length", errInvalidKeyFormat)
+	}
+	additionalData, err := f.additionalData()
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, cipherText, additionalData)
+	if err != nil {
+		return nil, nil, ErrWrongPassphrase
+	}
+	return &f, plaintext, nil
+}
+
+// LoadAddresses returns the addresses of all the accounts of the key stored at
+// [keyPath], the default one first. Encrypted keys don't need to be decrypted for this.
+func LoadAddresses(networkID uint32, keyPath string) ([]AccountAddresses, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if IsHD(kb) {
+		h, err := LoadHDFromBytes(kb)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]AccountAddresses, h.Accounts())
+		for i := range addrs {
+			sk, err := h.Account(networkID, uint32(i))
+			if err != nil {
+				return nil, err
+			}
+			addrs[i] = AccountAddresses{P: sk.P()[0], C: sk.C()}
+		}
+		return addrs, nil
+	}
+	if !IsEncrypted(kb) {
+		sk, err := LoadSoftFromBytes(networkID, kb)
+		if err != nil {
+			return nil, err
+		}
+		return []AccountAddresses{{P: sk.P()[0], C: sk.C()}}, nil
+	}
+	var f encryptedKeyFile
+	if err := json.Unmarshal(kb, &f); err != nil {
+		return nil, err
+	}
+	accounts := f.Accounts
+	if f.Kind != mnemonicKind {
+		accounts = []encryptedKeyAccount{{Address: f.Address, CAddress: f.CAddress}}
+	}
+	addrs := make([]AccountAddresses, len(accounts))
+	for i, account := range accounts {
+		addrBytes, err := hex.DecodeString(account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidKeyFormat, err)
+		}
+		if !common.IsHexAddress(account.CAddress) {
+			return nil, fmt.Errorf("%w: invalid C-Chain address", errInvalidKeyFormat)
+		}
+		pAddr, err := address.Format("P", GetHRP(networkID), addr[:])
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = AccountAddresses{P: pAddr, C: account.CAddress}
+	}
+	return addrs, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/constants"
+)
+
+func TestEncryptedKeyRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(
+		fallbackNetworkID,
+		WithPrivateKeyEncoded(EwoqPrivateKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := m.SaveEncrypted(keyPath, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := IsEncryptedFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !encrypted {
+		t.Fatal("expected key file to be encrypted")
+	}
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(kb, []byte(m.Encode())) {
+		t.Fatal("encrypted key file contains the private key")
+	}
+
+	m2, err := LoadSoftEncrypted(fallbackNetworkID, keyPath, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(m.Raw(), m2.Raw()) {
+		t.Fatalf("loaded key unexpected %v, expected %v", m2.Raw(), m.Raw())
+	}
+
+	if _, err := LoadSoftEncrypted(fallbackNetworkID, keyPath, []byte("wrong")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrWrongPassphrase)
+	}
+	if _, err := LoadSoft(fallbackNetworkID, keyPath); !errors.Is(err, ErrEncryptedKey) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrEncryptedKey)
+	}
+
+	addrs, err := LoadAddresses(fallbackNetworkID, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 1 {
+		t.Fatalf("unexpected number of accounts %d, expected 1", len(addrs))
+	}
+	if addrs[0].P != ewoqPChainAddr {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", addrs[0].P, ewoqPChainAddr)
+	}
+	if addrs[0].C != m.C() {
+		t.Fatalf("unexpected C-Chain address %q, expected %q", addrs[0].C, m.C())
+	}
+}
+
+func TestEncryptedKeyEmptyPassphrase(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := m.SaveEncrypted(keyPath, nil); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrEmptyPassphrase)
+	}
+}
+
+func TestPlainKeyAddresses(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(
+		fallbackNetworkID,
+		WithPrivateKeyEncoded(EwoqPrivateKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := m.Save(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSoftEncrypted(fallbackNetworkID, keyPath, []byte("passphrase")); !errors.Is(err, ErrKeyNotEncrypted) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrKeyNotEncrypted)
+	}
+	addrs, err := LoadAddresses(fallbackNetworkID, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addrs[0].P != ewoqPChainAddr {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", addrs[0].P, ewoqPChainAddr)
+	}
+}
+
+func TestEncryptedKeyCostLimits(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := m.encrypt([]byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, params := range []string{`"n": 262144`, `"r": 8`, `"p": 1`, `"dklen": 32`} {
+		crafted := bytes.Replace(kb, []byte(params), []byte(params[:strings.Index(params, ":")]+": 1073741824"), 1)
+		if bytes.Equal(crafted, kb) {
+			t.Fatalf("%s not found in the key file", params)
+		}
+		if _, err := LoadSoftFromEncryptedBytes(fallbackNetworkID, crafted, []byte("passphrase")); !errors.Is(err, errInvalidKeyFormat) {
+			t.Fatalf("unexpected error %v, expected %v", err, errInvalidKeyFormat)
+		}
+	}
+}
+
+func TestEncryptedKeyOverwrite(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(
+		fallbackNetworkID,
+		WithPrivateKeyEncoded(EwoqPrivateKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDir := t.TempDir()
+	keyPath := filepath.Join(keyDir, "key.pk")
+	if err := m.Save(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SaveEncrypted(keyPath, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(keyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("unexpected %d files in the key dir, expected only the key file", len(entries))
+	}
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != constants.WriteReadUserOnlyPerms {
+		t.Fatalf("unexpected key file permissions %v, expected %v", info.Mode().Perm(), constants.WriteReadUserOnlyPerms)
+	}
+	if _, err := LoadSoftEncrypted(fallbackNetworkID, keyPath, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+)
+
+// Formats a key can be exported to and imported from
+const (
+	// "PrivateKey-" prefixed CB58, as used by the Lux wallet
+	CB58Format = "cb58"
+	// 0x prefixed hex of the C-Chain key, as used by MetaMask, Foundry and Hardhat
+	HexFormat = "hex"
+	// encrypted Ethereum JSON keystore (v3)
+	EthKeystoreFormat = "keystore"
+)
+
+var (
+	ExportFormats = []string{CB58Format, HexFormat, EthKeystoreFormat}
+
+	ErrWrongKeystorePassphrase = errors.New("could not decrypt keystore with the given passphrase")
+)
+
+// ethKeystoreFile holds the fields used to recognize an Ethereum JSON keystore
+type ethKeystoreFile struct {
+	Address string `json:"address"`
+	Crypto  struct {
+		Cipher     string `json:"cipher"`
+		CipherText string `json:"ciphertext"`
+		MAC        string `json:"mac"`
+	} `json:"crypto"`
+}
+
+// Export encodes the private key in [format]. Hex and Ethereum keystores hold the
+// C-Chain key, the keystores encrypted with [passphrase]. CB58 holds the P/X-Chain key.
+// Both keys are the same but for the accounts of a mnemonic.
+func (m *SoftKey) Export(format string, passphrase []byte) ([]byte, error) {
+	switch format {
+	case CB58Format:
+		return []byte(m.Encode()), nil
+	case HexFormat:
+		return []byte("0x" + hex.EncodeToString(m.CKey().Bytes())), nil
+	case EthKeystoreFormat:
+		return m.ethKeystore(passphrase)
+	default:
+		return nil, fmt.Errorf("unsupported key format %q, expected one of %v", format, ExportFormats)
+	}
+}
+
+func (m *SoftKey) ethKeystore(passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPrv := m.CKey().ToECDSA()
+	k := &keystore.Key{
+		Id:         id,
+		Address:    eth_crypto.PubkeyToAddress(ecdsaPrv.PublicKey),
+		PrivateKey: ecdsaPrv,
+	}
+	return keystore.EncryptKey(k, string(passphrase), keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// IsEthKeystore returns true if [kb] is the content of an Ethereum JSON keystore
+func IsEthKeystore(kb []byte) bool {
+	var f ethKeystoreFile
+	return json.Unmarshal(kb, &f) == nil && f.Crypto.CipherText != "" && f.Crypto.MAC != ""
+}
+
+// LoadSoftFromEthKeystore decrypts an Ethereum JSON keystore and creates the
+// corresponding SoftKey.
+func LoadSoftFromEthKeystore(networkID uint32, kb []byte, passphrase []byte) (*SoftKey, error) {
+	k, err := keystore.DecryptKey(kb, string(passphrase))
+	if err != nil {
+		if errors.Is(err, keystore.ErrDecrypt) {
+			return nil, ErrWrongKeystorePassphrase
+		}
+		return nil, err
+	}
+	privKey, err := secp256k1.ToPrivateKey(eth_crypto.FromECDSA(k.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	return NewSoft(networkID, WithPrivateKey(privKey))
+}
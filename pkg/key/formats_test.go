@@ -0,0 +1,120 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestExportImportFormats(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{CB58Format, HexFormat} {
+		kb, err := m.Export(format, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m2, err := LoadSoftFromBytes(fallbackNetworkID, kb)
+		if err != nil {
+			t.Fatalf("failed to import %s key %q: %s", format, kb, err)
+		}
+		if !bytes.Equal(m.Raw(), m2.Raw()) {
+			t.Fatalf("imported %s key unexpected %v, expected %v", format, m2.Raw(), m.Raw())
+		}
+	}
+
+	kb, err := m.Export(HexFormat, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(kb) != "0x"+string(ewoqKeyBytes) {
+		t.Fatalf("unexpected hex key %q", kb)
+	}
+
+	if _, err := m.Export("pem", nil); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestHexExportMnemonic(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := h.Account(fallbackNetworkID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := m.Export(HexFormat, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// as MetaMask would derive it
+	ecdsaPrv, err := eth_crypto.HexToECDSA(strings.TrimPrefix(string(kb), "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := eth_crypto.PubkeyToAddress(ecdsaPrv.PublicKey).Hex()
+	if addr != testMnemonicCAddrs[0] {
+		t.Fatalf("unexpected C-Chain address %q of the hex key, expected %q", addr, testMnemonicCAddrs[0])
+	}
+}
+
+func TestEthKeystore(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := h.Account(fallbackNetworkID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Export(EthKeystoreFormat, nil); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrEmptyPassphrase)
+	}
+	kb, err := m.Export(EthKeystoreFormat, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEthKeystore(kb) || IsEncrypted(kb) || IsHD(kb) {
+		t.Fatal("keystore not recognized")
+	}
+
+	// keystores hold the C-Chain key
+	m2, err := LoadSoftFromEthKeystore(fallbackNetworkID, kb, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m2.C() != testMnemonicCAddrs[0] {
+		t.Fatalf("unexpected C-Chain address %q, expected %q", m2.C(), testMnemonicCAddrs[0])
+	}
+
+	if _, err := LoadSoftFromEthKeystore(fallbackNetworkID, kb, []byte("wrong")); !errors.Is(err, ErrWrongKeystorePassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrWrongKeystorePassphrase)
+	}
+
+	encrypted, err := m.encrypt([]byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsEthKeystore(encrypted) {
+		t.Fatal("encrypted key file recognized as an ethereum keystore")
+	}
+}
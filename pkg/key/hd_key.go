@@ -0,0 +1,215 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// BIP-44 paths of the accounts derived from a mnemonic. The account index
+	// is appended as the last (non hardened) element.
+	LuxDerivationPath = "m/44'/9000'/0'/0"
+	EthDerivationPath = "m/44'/60'/0'/0"
+
+	mnemonicEntropySize = 256
+	// key files kind for mnemonics
+	mnemonicKind = "mnemonic"
+)
+
+var (
+	luxDerivationPath = []uint32{
+		bip32.FirstHardenedChild + 44,
+		bip32.FirstHardenedChild + 9000,
+		bip32.FirstHardenedChild,
+		0,
+	}
+	ethDerivationPath = []uint32{
+		bip32.FirstHardenedChild + 44,
+		bip32.FirstHardenedChild + 60,
+		bip32.FirstHardenedChild,
+		0,
+	}
+
+	ErrInvalidMnemonic  = errors.New("invalid BIP-39 mnemonic")
+	ErrNotHDKey         = errors.New("key is not a mnemonic key, only account 0 is available")
+	errInvalidKeyRef    = errors.New("invalid key, expected <keyName> or <keyName>/<accountIndex>")
+	errInvalidNumAccnts = errors.New("a mnemonic key must have at least one account")
+)
+
+// HDKey is a BIP-39 mnemonic stored as a key. Several accounts can be derived
+// from it, each one with its own P/X-Chain and C-Chain keys.
+type HDKey struct {
+	mnemonic string
+	accounts uint32
+}
+
+// hdKeyFile is the on-disk format of a mnemonic key
+type hdKeyFile struct {
+	Mnemonic string `json:"mnemonic"`
+	Accounts uint32 `json:"accounts"`
+}
+
+// NewMnemonic generates a new 24 words BIP-39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropySize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewHD creates a mnemonic key with [accounts] derived accounts
+func NewHD(mnemonic string, accounts uint32) (*HDKey, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	if accounts == 0 {
+		return nil, errInvalidNumAccnts
+	}
+	return &HDKey{
+		mnemonic: mnemonic,
+		accounts: accounts,
+	}, nil
+}
+
+// IsHD returns true if [kb] is the content of a plain text mnemonic key file
+func IsHD(kb []byte) bool {
+	var f hdKeyFile
+	return json.Unmarshal(kb, &f) == nil && f.Mnemonic != ""
+}
+
+// LoadHD loads a plain text mnemonic key from disk
+func LoadHD(keyPath string) (*HDKey, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return LoadHDFromBytes(kb)
+}
+
+// LoadHDFromBytes loads a plain text mnemonic key from bytes
+func LoadHDFromBytes(kb []byte) (*HDKey, error) {
+	if IsEncrypted(kb) {
+		return nil, ErrEncryptedKey
+	}
+	var f hdKeyFile
+	if err := json.Unmarshal(kb, &f); err != nil || f.Mnemonic == "" {
+		return nil, ErrNotHDKey
+	}
+	return NewHD(f.Mnemonic, f.Accounts)
+}
+
+// Returns the BIP-39 mnemonic.
+func (h *HDKey) Mnemonic() string {
+	return h.mnemonic
+}
+
+// Returns the number of accounts stored for the mnemonic.
+func (h *HDKey) Accounts() uint32 {
+	return h.accounts
+}
+
+// Account derives the SoftKey of account [index]. Its P/X-Chain key follows
+// the Lux derivation path, and its C-Chain key the Ethereum one.
+func (h *HDKey) Account(networkID uint32, index uint32) (*SoftKey, error) {
+	if index >= h.accounts {
+		return nil, fmt.Errorf("account index %d out of range, the key has %d accounts", index, h.accounts)
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(h.mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	luxKey, err := derivePrivateKey(master, luxDerivationPath, index)
+	if err != nil {
+		return nil, err
+	}
+	ethKey, err := derivePrivateKey(master, ethDerivationPath, index)
+	if err != nil {
+		return nil, err
+	}
+	return NewSoft(networkID, WithPrivateKey(luxKey), WithCChainPrivateKey(ethKey))
+}
+
+// Saves the mnemonic to disk in plain text.
+func (h *HDKey) Save(p string) error {
+	kb, err := json.MarshalIndent(hdKeyFile{
+		Mnemonic: h.mnemonic,
+		Accounts: h.accounts,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, kb, constants.WriteReadUserOnlyPerms)
+}
+
+// SaveEncrypted saves the mnemonic to disk, encrypted with a key derived from [passphrase].
+// The addresses of all the accounts are kept in clear.
+func (h *HDKey) SaveEncrypted(p string, passphrase []byte) error {
+	kb, err := h.encrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	return writeKeyFile(p, kb)
+}
+
+func (h *HDKey) encrypt(passphrase []byte) ([]byte, error) {
+	accounts := make([]encryptedKeyAccount, h.accounts)
+	for i := range accounts {
+		sk, err := h.Account(0, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		accounts[i] = sk.encryptedKeyAccount()
+	}
+	return encryptKeyFile([]byte(h.mnemonic), mnemonicKind, accounts, passphrase)
+}
+
+// ParseKeyRef splits a key reference given by the user, either <keyName>
+// or <keyName>/<accountIndex>, into the key name and the account index.
+func ParseKeyRef(keyRef string) (string, uint32, error) {
+	keyName, indexStr, found := strings.Cut(keyRef, "/")
+	if keyName == "" {
+		return "", 0, errInvalidKeyRef
+	}
+	if !found {
+		return keyName, 0, nil
+	}
+	index, err := strconv.ParseUint(indexStr, 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", errInvalidKeyRef, keyRef)
+	}
+	return keyName, uint32(index), nil
+}
+
+func derivePrivateKey(master *bip32.Key, path []uint32, index uint32) (*secp256k1.PrivateKey, error) {
+	k := master
+	var err error
+	for _, i := range path {
+		k, err = k.NewChildKey(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	k, err = k.NewChildKey(index)
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.ToPrivateKey(k.Key)
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// well known test mnemonic, and the C-Chain addresses of its first accounts
+const testMnemonic = "test test test test test test test test test test test junk"
+
+var testMnemonicCAddrs = []string{
+	"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+}
+
+func TestHDKeyAccounts(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pAddrs := map[string]struct{}{}
+	for i, cAddr := range testMnemonicCAddrs {
+		sk, err := h.Account(fallbackNetworkID, uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sk.C() != cAddr {
+			t.Fatalf("unexpected C-Chain address %q for account %d, expected %q", sk.C(), i, cAddr)
+		}
+		// P-Chain keys are derived on a different path than C-Chain ones
+		if bytes.Equal(sk.Raw(), sk.CKey().Bytes()) {
+			t.Fatalf("account %d uses the same key on the P-Chain and the C-Chain", i)
+		}
+		pAddrs[sk.P()[0]] = struct{}{}
+	}
+	if len(pAddrs) != 2 {
+		t.Fatal("accounts share their P-Chain address")
+	}
+	if _, err := h.Account(fallbackNetworkID, 2); err == nil {
+		t.Fatal("expected an error for an account out of range")
+	}
+}
+
+func TestHDKeySave(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := h.Save(keyPath); err != nil {
+		t.Fatal(err)
+	}
+
+	h2, err := LoadHD(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h2.Mnemonic() != testMnemonic || h2.Accounts() != 2 {
+		t.Fatalf("loaded mnemonic key unexpected %q with %d accounts", h2.Mnemonic(), h2.Accounts())
+	}
+
+	// mnemonics are loaded as their default account
+	sk, err := LoadSoft(fallbackNetworkID, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk.C() != testMnemonicCAddrs[0] {
+		t.Fatalf("unexpected C-Chain address %q, expected %q", sk.C(), testMnemonicCAddrs[0])
+	}
+
+	addrs, err := LoadAddresses(fallbackNetworkID, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[1].C != testMnemonicCAddrs[1] {
+		t.Fatalf("unexpected accounts addresses %v", addrs)
+	}
+}
+
+func TestHDKeySaveEncrypted(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := h.SaveEncrypted(keyPath, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+
+	addrs, err := LoadAddresses(fallbackNetworkID, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[1].C != testMnemonicCAddrs[1] {
+		t.Fatalf("unexpected accounts addresses %v", addrs)
+	}
+
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk, err := LoadSoftAccountFromEncryptedBytes(fallbackNetworkID, kb, []byte("passphrase"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk.C() != testMnemonicCAddrs[1] {
+		t.Fatalf("unexpected C-Chain address %q, expected %q", sk.C(), testMnemonicCAddrs[1])
+	}
+	if addrs[1].P != sk.P()[0] {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", addrs[1].P, sk.P()[0])
+	}
+
+	h2, err := LoadHDFromEncryptedBytes(kb, []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h2.Mnemonic() != testMnemonic {
+		t.Fatalf("unexpected mnemonic %q", h2.Mnemonic())
+	}
+}
+
+func TestInvalidMnemonic(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewHD("test test test", 1); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidMnemonic)
+	}
+	if _, err := NewHD(testMnemonic, 0); err == nil {
+		t.Fatal("expected an error for a mnemonic key without accounts")
+	}
+}
+
+func TestParseKeyRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		keyRef  string
+		name    string
+		index   uint32
+		invalid bool
+	}{
+		{keyRef: "mykey", name: "mykey"},
+		{keyRef: "mykey/3", name: "mykey", index: 3},
+		{keyRef: "mykey/", invalid: true},
+		{keyRef: "mykey/-1", invalid: true},
+		{keyRef: "/1", invalid: true},
+	}
+	for _, tt := range tests {
+		name, index, err := ParseKeyRef(tt.keyRef)
+		if tt.invalid {
+			if err == nil {
+				t.Fatalf("expected an error for %q", tt.keyRef)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != tt.name || index != tt.index {
+			t.Fatalf("unexpected %q, %d for %q", name, index, tt.keyRef)
+		}
+	}
+}
+
+func TestHDKeyEncryptedAccountsTampered(t *testing.T) {
+	t.Parallel()
+
+	h, err := NewHD(testMnemonic, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := h.encrypt([]byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamperings := map[string]func(f *encryptedKeyFile){
+		"account removed": func(f *encryptedKeyFile) {
+			f.Accounts = f.Accounts[:1]
+		},
+		"account added": func(f *encryptedKeyFile) {
+			f.Accounts = append(f.Accounts, f.Accounts[1])
+		},
+		"account address changed": func(f *encryptedKeyFile) {
+			f.Accounts[1].CAddress = testMnemonicCAddrs[0]
+		},
+	}
+	for name, tamper := range tamperings {
+		var f encryptedKeyFile
+		if err := json.Unmarshal(kb, &f); err != nil {
+			t.Fatal(err)
+		}
+		tamper(&f)
+		tampered, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadHDFromEncryptedBytes(tampered, []byte("passphrase")); !errors.Is(err, ErrWrongPassphrase) {
+			t.Fatalf("%s: unexpected error %v, expected %v", name, err, ErrWrongPassphrase)
+		}
+		if _, err := LoadSoftAccountFromEncryptedBytes(fallbackNetworkID, tampered, []byte("passphrase"), 1); !errors.Is(err, ErrWrongPassphrase) {
+			t.Fatalf("%s: unexpected error %v, expected %v", name, err, ErrWrongPassphrase)
+		}
+	}
+}
@@ -40,6 +40,9 @@ type SoftKey struct {
 	privKeyRaw     []byte
 	privKeyEncoded string
 
+	// C-Chain private key, when it differs from the P/X-Chain one
+	cPrivKey *secp256k1.PrivateKey
+
 	pAddr string
 	xAddr string
 
@@ -59,6 +62,7 @@ var ewoqKeyBytes = []byte("56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5
 type SOp struct {
 	privKey        *secp256k1.PrivateKey
 	privKeyEncoded string
+	cPrivKey       *secp256k1.PrivateKey
 }
 
 type SOpOption func(*SOp)
@@ -83,6 +87,14 @@ func WithPrivateKeyEncoded(privKey string) SOpOption {
 	}
 }
 
+// To create a new key SoftKey with a different private key for the C-Chain,
+// as done for keys derived from a mnemonic.
+func WithCChainPrivateKey(privKey *secp256k1.PrivateKey) SOpOption {
+	return func(sop *SOp) {
+		sop.cPrivKey = privKey
+	}
+}
+
 func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 	ret := &SOp{}
 	ret.applyOpts(opts)
@@ -130,6 +142,8 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 		privKeyRaw:     privKey.Bytes(),
 		privKeyEncoded: privKeyEncoded,
 
+		cPrivKey: ret.cPrivKey,
+
 		keyChain: keyChain,
 	}
 
@@ -163,13 +177,25 @@ func LoadEwoq(networkID uint32) (*SoftKey, error) {
 
 // LoadSoftFromBytes loads the private key from bytes and creates the corresponding SoftKey.
 func LoadSoftFromBytes(networkID uint32, kb []byte) (*SoftKey, error) {
+	if IsEncrypted(kb) {
+		return nil, ErrEncryptedKey
+	}
+	// mnemonics load their default account
+	if IsHD(kb) {
+		h, err := LoadHDFromBytes(kb)
+		if err != nil {
+			return nil, err
+		}
+		return h.Account(networkID, 0)
+	}
 	// in case, it's already encoded
 	k, err := NewSoft(networkID, WithPrivateKeyEncoded(string(kb)))
 	if err == nil {
 		return k, nil
 	}
 
-	r := bufio.NewReader(bytes.NewBuffer(kb))
+	// hex keys from ethereum tools usually come 0x prefixed
+	r := bufio.NewReader(bytes.NewBuffer(bytes.TrimPrefix(kb, []byte("0x"))))
 	buf := make([]byte, privKeySize)
 	n, err := readASCII(buf, r)
 	if err != nil {
@@ -251,7 +277,7 @@ func decodePrivateKey(enc string) (*secp256k1.PrivateKey, error) {
 }
 
 func (m *SoftKey) C() string {
-	ecdsaPrv := m.privKey.ToECDSA()
+	ecdsaPrv := m.CKey().ToECDSA()
 	pub := ecdsaPrv.PublicKey
 
 	addr := eth_crypto.PubkeyToAddress(pub)
@@ -268,6 +294,14 @@ func (m *SoftKey) Key() *secp256k1.PrivateKey {
 	return m.privKey
 }
 
+// Returns the private key used on the C-Chain.
+func (m *SoftKey) CKey() *secp256k1.PrivateKey {
+	if m.cPrivKey != nil {
+		return m.cPrivKey
+	}
+	return m.privKey
+}
+
 // Returns the private key in raw bytes.
 func (m *SoftKey) Raw() []byte {
 	return m.privKeyRaw
@@ -211,7 +211,7 @@ func GetKeychain(
 		kc := sf.KeyChain()
 		return NewKeychain(network, kc, nil, nil), nil
 	}
-	sf, err := key.LoadSoft(network.ID, app.GetKeyPath(keyName))
+	sf, err := app.LoadKey(network.ID, keyName)
 	if err != nil {
 		return nil, err
 	}
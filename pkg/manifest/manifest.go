@@ -0,0 +1,201 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/subnet"
+	"github.com/luxdefi/node/utils/set"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultFileName is the manifest read by lux up and lux down when no file is given
+	DefaultFileName = "lux.yaml"
+	// CChain funds a key on the C-Chain instead of a subnet
+	CChain = "C"
+
+	latest = "latest"
+)
+
+var whitespaceRegex = regexp.MustCompile(`\s`)
+
+// Manifest describes a local environment: the local network, the subnets deployed
+// into it and the keys funded on their chains
+type Manifest struct {
+	Network Network  `yaml:"network"`
+	Subnets []Subnet `yaml:"subnets"`
+	Keys    []Key    `yaml:"keys"`
+}
+
+type Network struct {
+	// number of nodes of a fresh network, the snapshot size if not given
+	NumNodes    uint32 `yaml:"numNodes"`
+	NodeVersion string `yaml:"nodeVersion"`
+	// JSON file with luxd flags for all the nodes
+	NodeConfig string `yaml:"nodeConfig"`
+	// snapshot the network is started from, and saved into by lux down
+	Snapshot string `yaml:"snapshot"`
+}
+
+type Subnet struct {
+	Name string `yaml:"name"`
+	// Subnet-EVM (default) or Custom, case insensitive
+	VM        models.VMType `yaml:"vm"`
+	VMVersion string        `yaml:"vmVersion"`
+	Genesis   string        `yaml:"genesis"`
+	// source of a custom VM
+	CustomVMRepoURL     string `yaml:"customVMRepoURL"`
+	CustomVMBranch      string `yaml:"customVMBranch"`
+	CustomVMBuildScript string `yaml:"customVMBuildScript"`
+	// local nodes that validate the subnet, as <nodeName> or <nodeName>=<weight>
+	Validators []string `yaml:"validators"`
+	// upgrade bytes file applied to the deployed subnet
+	Upgrade string `yaml:"upgrade"`
+}
+
+type Key struct {
+	Name string `yaml:"name"`
+	Fund []Fund `yaml:"fund"`
+}
+
+// Fund is the minimum balance of a key on a chain, in units of the chain token
+type Fund struct {
+	// C or a subnet name
+	Chain  string  `yaml:"chain"`
+	Amount float64 `yaml:"amount"`
+}
+
+// Load reads a manifest, resolving its file paths relative to the manifest
+// directory, and validates it
+func Load(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	m.setDefaults(filepath.Dir(path))
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+func (m *Manifest) setDefaults(dir string) {
+	if m.Network.NodeVersion == "" {
+		m.Network.NodeVersion = latest
+	}
+	if m.Network.Snapshot == "" {
+		m.Network.Snapshot = constants.DefaultSnapshotName
+	}
+	m.Network.NodeConfig = resolvePath(dir, m.Network.NodeConfig)
+	for i := range m.Subnets {
+		s := &m.Subnets[i]
+		switch {
+		case s.VM == "" || strings.EqualFold(string(s.VM), models.SubnetEvm):
+			s.VM = models.SubnetEvm
+		case strings.EqualFold(string(s.VM), models.CustomVM):
+			s.VM = models.CustomVM
+		}
+		if s.VM == models.SubnetEvm && s.VMVersion == "" {
+			s.VMVersion = latest
+		}
+		s.Genesis = resolvePath(dir, s.Genesis)
+		s.Upgrade = resolvePath(dir, s.Upgrade)
+	}
+}
+
+func resolvePath(dir string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// Validate checks the manifest without looking at the local environment
+func (m *Manifest) Validate() error {
+	subnetNames := set.Set[string]{}
+	for i, s := range m.Subnets {
+		if s.Name == "" {
+			return fmt.Errorf("subnet %d has no name", i+1)
+		}
+		if subnetNames.Contains(s.Name) {
+			return fmt.Errorf("subnet %s given more than once", s.Name)
+		}
+		subnetNames.Add(s.Name)
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("subnet %s: %w", s.Name, err)
+		}
+	}
+	keyNames := set.Set[string]{}
+	for i, k := range m.Keys {
+		if k.Name == "" {
+			return fmt.Errorf("key %d has no name", i+1)
+		}
+		if whitespaceRegex.MatchString(k.Name) {
+			return fmt.Errorf("key name %q contains whitespace", k.Name)
+		}
+		if keyNames.Contains(k.Name) {
+			return fmt.Errorf("key %s given more than once", k.Name)
+		}
+		keyNames.Add(k.Name)
+		for _, fund := range k.Fund {
+			if fund.Chain != CChain && !subnetNames.Contains(fund.Chain) {
+				return fmt.Errorf("key %s: chain %s is neither %s nor a subnet of the manifest", k.Name, fund.Chain, CChain)
+			}
+			if fund.Amount <= 0 {
+				return fmt.Errorf("key %s: amount for chain %s must be positive", k.Name, fund.Chain)
+			}
+		}
+	}
+	return nil
+}
+
+func (s Subnet) validate() error {
+	if s.Genesis == "" {
+		return errors.New("genesis must be given")
+	}
+	switch s.VM {
+	case models.SubnetEvm:
+		if s.CustomVMRepoURL != "" || s.CustomVMBranch != "" || s.CustomVMBuildScript != "" {
+			return fmt.Errorf("custom VM fields are only valid with vm %s", models.CustomVM)
+		}
+	case models.CustomVM:
+		if s.CustomVMRepoURL == "" || s.CustomVMBranch == "" || s.CustomVMBuildScript == "" {
+			return errors.New("customVMRepoURL, customVMBranch and customVMBuildScript must be given")
+		}
+		if s.VMVersion != "" {
+			return fmt.Errorf("vmVersion is only valid with vm %s", models.SubnetEvm)
+		}
+	default:
+		return fmt.Errorf("unknown vm %s, expected %s or %s", s.VM, models.SubnetEvm, models.CustomVM)
+	}
+	if _, err := subnet.ParseLocalValidators(s.Validators); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSubnet returns the subnet of the manifest with the given name
+func (m *Manifest) GetSubnet(name string) (Subnet, bool) {
+	for _, s := range m.Subnets {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Subnet{}, false
+}
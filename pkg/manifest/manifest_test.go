@@ -0,0 +1,123 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), constants.DefaultPerms755))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	require := require.New(t)
+
+	path := writeManifest(t, `
+network:
+  numNodes: 3
+subnets:
+  - name: alpha
+    genesis: ./alpha.json
+    validators: [node1, node2=2000]
+    upgrade: upgrades/alpha.json
+  - name: beta
+    vm: custom
+    genesis: /genesis/beta.json
+    customVMRepoURL: https://github.com/luxdefi/beta
+    customVMBranch: main
+    customVMBuildScript: scripts/build.sh
+keys:
+  - name: alice
+    fund:
+      - chain: alpha
+        amount: 1000
+      - chain: C
+        amount: 0.5
+`)
+	dir := filepath.Dir(path)
+	m, err := Load(path)
+	require.NoError(err)
+	require.Equal(Network{
+		NumNodes:    3,
+		NodeVersion: "latest",
+		Snapshot:    constants.DefaultSnapshotName,
+	}, m.Network)
+	require.Len(m.Subnets, 2)
+	require.Equal(models.SubnetEvm, m.Subnets[0].VM)
+	require.Equal("latest", m.Subnets[0].VMVersion)
+	require.Equal(filepath.Join(dir, "alpha.json"), m.Subnets[0].Genesis)
+	require.Equal(filepath.Join(dir, "upgrades", "alpha.json"), m.Subnets[0].Upgrade)
+	require.Equal(models.CustomVM, m.Subnets[1].VM)
+	require.Empty(m.Subnets[1].VMVersion)
+	require.Equal("/genesis/beta.json", m.Subnets[1].Genesis)
+	require.Equal([]Fund{{Chain: "alpha", Amount: 1000}, {Chain: CChain, Amount: 0.5}}, m.Keys[0].Fund)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "unknown field",
+			manifest: "network:\n  nodes: 3\n",
+			err:      "field nodes not found",
+		},
+		{
+			name:     "duplicated subnet",
+			manifest: "subnets:\n  - {name: a, genesis: g.json}\n  - {name: a, genesis: g.json}\n",
+			err:      "subnet a given more than once",
+		},
+		{
+			name:     "missing genesis",
+			manifest: "subnets:\n  - name: a\n",
+			err:      "subnet a: genesis must be given",
+		},
+		{
+			name:     "unknown vm",
+			manifest: "subnets:\n  - {name: a, genesis: g.json, vm: other}\n",
+			err:      "unknown vm other",
+		},
+		{
+			name:     "incomplete custom vm",
+			manifest: "subnets:\n  - {name: a, genesis: g.json, vm: custom, customVMRepoURL: url}\n",
+			err:      "customVMRepoURL, customVMBranch and customVMBuildScript must be given",
+		},
+		{
+			name:     "invalid validator weight",
+			manifest: "subnets:\n  - {name: a, genesis: g.json, validators: [node1=x]}\n",
+			err:      `subnet a: invalid weight "x" for local validator node1`,
+		},
+		{
+			name:     "key name with whitespace",
+			manifest: "keys:\n  - name: my key\n",
+			err:      "contains whitespace",
+		},
+		{
+			name:     "fund of unknown chain",
+			manifest: "keys:\n  - {name: k, fund: [{chain: other, amount: 1}]}\n",
+			err:      "chain other is neither C nor a subnet of the manifest",
+		},
+		{
+			name:     "fund without amount",
+			manifest: "keys:\n  - {name: k, fund: [{chain: C}]}\n",
+			err:      "amount for chain C must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeManifest(t, tt.manifest))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
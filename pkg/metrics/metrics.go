@@ -15,6 +15,7 @@ import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/constants"
 
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 
 	"github.com/posthog/posthog-go"
@@ -62,7 +63,7 @@ func HandleUserMetricsPreference(app *application.Lux) error {
 	txt := "Press [Enter] to opt-in, or opt out by choosing 'No'"
 	yes, err := app.Prompt.CaptureYesNo(txt)
 	if err != nil {
-		return err
+		return prompts.WithHint(err, "lux config metrics enable or disable")
 	}
 	if !yes {
 		ux.Logger.PrintToUser("Lux CLI usage metrics will not be collected")
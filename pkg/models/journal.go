@@ -0,0 +1,39 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/ids"
+)
+
+type JournalTxStatus string
+
+const (
+	// the tx was built and signed, and is about to be issued
+	JournalTxIssued JournalTxStatus = "Issued"
+	// the tx was accepted by the network
+	JournalTxConfirmed JournalTxStatus = "Confirmed"
+	// issuing or awaiting the tx failed. It may still have been accepted, as
+	// when the CLI times out awaiting it
+	JournalTxFailed JournalTxStatus = "Failed"
+)
+
+// JournalEntry records a change in the status of a tx issued for a subnet.
+// A tx has an Issued entry, followed by a Confirmed or Failed one, unless the
+// CLI was interrupted while awaiting it
+type JournalEntry struct {
+	Time    time.Time
+	Network string
+	// P or X
+	Chain  string
+	TxType string
+	TxID   ids.ID
+	// subnet the tx creates or operates on, if any
+	SubnetID ids.ID
+	// name of the blockchain created by a CreateChainTx
+	BlockchainName string `json:",omitempty"`
+	Status         JournalTxStatus
+	Error          string `json:",omitempty"`
+}
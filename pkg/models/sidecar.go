@@ -46,6 +46,12 @@ type Sidecar struct {
 	SubnetEVMMainnetChainID uint
 }
 
+// IsChildChain tells if the chain was added with subnet add-chain to the
+// subnet named [Subnet], instead of being created with it
+func (sc Sidecar) IsChildChain() bool {
+	return sc.Subnet != "" && sc.Subnet != sc.Name
+}
+
 func (sc Sidecar) GetVMID() (string, error) {
 	// get vmid
 	var vmid string
@@ -13,6 +13,7 @@ import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 )
 
@@ -31,7 +32,7 @@ func EditConfigFile(
 		ux.Logger.PrintToUser(warn)
 		yes, err := app.Prompt.CaptureYesNo("Proceed?")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--force-write")
 		}
 		if !yes {
 			ux.Logger.PrintToUser("Canceled by user")
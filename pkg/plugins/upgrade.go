@@ -6,6 +6,7 @@ package plugins
 import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/node/utils/logging"
 )
@@ -36,7 +37,7 @@ func AutomatedUpgrade(app *application.Lux, sc models.Sidecar, targetVersion str
 			ux.Logger.PrintToUser(logging.Bold.Wrap(logging.Green.Wrap("Found the VM plugin directory at %s")), pluginDir)
 			yes, err := app.Prompt.CaptureYesNo("Is this where we should upgrade the VM?")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--plugin-dir")
 			}
 			if yes {
 				ux.Logger.PrintToUser("Will use plugin directory at %s to upgrade the VM", pluginDir)
@@ -47,7 +48,7 @@ func AutomatedUpgrade(app *application.Lux, sc models.Sidecar, targetVersion str
 		if pluginDir == "" {
 			pluginDir, err = app.Prompt.CaptureString("Path to your node plugin dir (likely ~/.node/build/plugins)")
 			if err != nil {
-				return err
+				return prompts.WithHint(err, "--plugin-dir")
 			}
 		}
 	}
@@ -0,0 +1,193 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/ids"
+)
+
+// ErrNonInteractive is wrapped by every error returned from the non interactive prompter
+var ErrNonInteractive = errors.New("user input required, but running in non-interactive mode")
+
+// NonInteractiveError is returned by every Capture* call of the non interactive
+// prompter. Hint names the flag or spec field that answers the prompt, and is
+// filled by the caller through WithHint, as only the caller knows it.
+type NonInteractiveError struct {
+	Prompt string
+	Hint   string
+}
+
+func (e *NonInteractiveError) Error() string {
+	if e.Hint == "" {
+		return fmt.Sprintf("%s: no answer available for %q", ErrNonInteractive, e.Prompt)
+	}
+	return fmt.Sprintf("%s: no answer available for %q, provide it with %s", ErrNonInteractive, e.Prompt, e.Hint)
+}
+
+func (*NonInteractiveError) Unwrap() error {
+	return ErrNonInteractive
+}
+
+// WithHint sets the flag or spec field that would have answered the prompt
+// that generated err, if err comes from the non interactive prompter.
+// Hints are not overridden, so the innermost (more specific) one is kept.
+// err is always returned, so it can be used directly on return statements.
+func WithHint(err error, hint string) error {
+	var nonInteractiveErr *NonInteractiveError
+	if errors.As(err, &nonInteractiveErr) && nonInteractiveErr.Hint == "" {
+		nonInteractiveErr.Hint = hint
+	}
+	return err
+}
+
+type nonInteractivePrompter struct{}
+
+// NewNonInteractivePrompter creates a prompter that never blocks waiting for
+// user input: every Capture* call fails with a NonInteractiveError
+func NewNonInteractivePrompter() Prompter {
+	return &nonInteractivePrompter{}
+}
+
+func newNonInteractiveError(promptStr string) error {
+	return &NonInteractiveError{Prompt: promptStr}
+}
+
+func (*nonInteractivePrompter) CapturePositiveBigInt(promptStr string) (*big.Int, error) {
+	return nil, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureAddress(promptStr string) (common.Address, error) {
+	return common.Address{}, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureNewFilepath(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureExistingFilepath(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureYesNo(promptStr string) (bool, error) {
+	return false, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureNoYes(promptStr string) (bool, error) {
+	return false, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureList(promptStr string, _ []string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureString(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CapturePassword(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureValidatedString(promptStr string, _ func(string) error) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureURL(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureRepoBranch(promptStr string, _ string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureRepoFile(promptStr string, _ string, _ string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureGitURL(promptStr string) (*url.URL, error) {
+	return nil, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureStringAllowEmpty(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureEmail(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureIndex(promptStr string, _ []any) (int, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureVersion(promptStr string) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureFujiDuration(promptStr string) (time.Duration, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureMainnetDuration(promptStr string) (time.Duration, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureDate(promptStr string) (time.Time, error) {
+	return time.Time{}, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureNodeID(promptStr string) (ids.NodeID, error) {
+	return ids.EmptyNodeID, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureID(promptStr string) (ids.ID, error) {
+	return ids.Empty, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureWeight(promptStr string) (uint64, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CapturePositiveInt(promptStr string, _ []Comparator) (int, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureInt(promptStr string) (int, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureUint32(promptStr string) (uint32, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureUint64(promptStr string) (uint64, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureFloat(promptStr string, _ func(float64) error) (float64, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureUint64Compare(promptStr string, _ []Comparator) (uint64, error) {
+	return 0, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CapturePChainAddress(promptStr string, _ models.Network) (string, error) {
+	return "", newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) CaptureFutureDate(promptStr string, _ time.Time) (time.Time, error) {
+	return time.Time{}, newNonInteractiveError(promptStr)
+}
+
+func (*nonInteractivePrompter) ChooseKeyOrLedger(goal string) (bool, error) {
+	return false, newNonInteractiveError(fmt.Sprintf("Which key source should be used to %s?", goal))
+}
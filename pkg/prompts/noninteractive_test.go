@@ -0,0 +1,43 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonInteractivePrompter(t *testing.T) {
+	require := require.New(t)
+	prompter := NewNonInteractivePrompter()
+
+	_, err := prompter.CaptureList("Choose network", []string{"Fuji", "Mainnet"})
+	require.ErrorIs(err, ErrNonInteractive)
+	require.Contains(err.Error(), "Choose network")
+
+	// only non interactive errors get a hint
+	otherErr := errors.New("other")
+	require.Equal(otherErr, WithHint(otherErr, "--fuji"))
+	require.Nil(WithHint(nil, "--fuji"))
+
+	err = WithHint(err, "--fuji or --mainnet")
+	require.ErrorIs(err, ErrNonInteractive)
+	require.Contains(err.Error(), "--fuji or --mainnet")
+
+	// the innermost hint is kept, also through wrapping
+	err = WithHint(fmt.Errorf("failed: %w", err), "--network")
+	require.Contains(err.Error(), "failed: ")
+	require.Contains(err.Error(), "--fuji or --mainnet")
+	require.NotContains(err.Error(), "--network")
+
+	_, err = prompter.CaptureYesNo("Continue?")
+	err = WithHint(fmt.Errorf("failed: %w", err), "--force")
+	require.Contains(err.Error(), "failed: ")
+	require.Contains(err.Error(), "--force")
+
+	_, _, err = CaptureListDecision(prompter, "Add addresses", prompter.CaptureAddress, "Address", "address", "")
+	require.ErrorIs(err, ErrNonInteractive)
+}
@@ -77,6 +77,7 @@ type Prompter interface {
 	CaptureNoYes(promptStr string) (bool, error)
 	CaptureList(promptStr string, options []string) (string, error)
 	CaptureString(promptStr string) (string, error)
+	CapturePassword(promptStr string) (string, error)
 	CaptureValidatedString(promptStr string, validator func(string) error) (string, error)
 	CaptureURL(promptStr string) (string, error)
 	CaptureRepoBranch(promptStr string, repo string) (string, error)
@@ -593,6 +594,22 @@ func (*realPrompter) CaptureString(promptStr string) (string, error) {
 	return str, nil
 }
 
+// CapturePassword asks for a non empty string, without echoing what is typed
+func (*realPrompter) CapturePassword(promptStr string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    promptStr,
+		Validate: validateNonEmpty,
+		Mask:     '*',
+	}
+
+	str, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
+
 func (*realPrompter) CaptureValidatedString(promptStr string, validator func(string) error) (string, error) {
 	prompt := promptui.Prompt{
 		Label:    promptStr,
@@ -774,7 +791,7 @@ func GetSubnetAuthKeys(prompt Prompter, walletKeys []string, controlKeys []strin
 			filteredControlKeys,
 		)
 		if err != nil {
-			return nil, err
+			return nil, WithHint(err, "--subnet-auth-keys")
 		}
 		index, err := getIndexInSlice(filteredControlKeys, subnetAuthKey)
 		if err != nil {
@@ -789,7 +806,7 @@ func GetSubnetAuthKeys(prompt Prompter, walletKeys []string, controlKeys []strin
 func GetFujiKeyOrLedger(prompt Prompter, goal string, keyDir string) (bool, string, error) {
 	useStoredKey, err := prompt.ChooseKeyOrLedger(goal)
 	if err != nil {
-		return false, "", err
+		return false, "", WithHint(err, "--key or --ledger")
 	}
 	if !useStoredKey {
 		return true, "", nil
@@ -799,7 +816,7 @@ func GetFujiKeyOrLedger(prompt Prompter, goal string, keyDir string) (bool, stri
 		if errors.Is(err, errNoKeys) {
 			ux.Logger.PrintToUser("No private keys have been found. Create a new one with `lux key create`")
 		}
-		return false, "", err
+		return false, "", WithHint(err, "--key")
 	}
 	return false, keyName, nil
 }
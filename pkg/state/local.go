@@ -0,0 +1,206 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/luxdefi/cli/pkg/constants"
+)
+
+// LocalBackend keeps the state in a directory. Shared through a network
+// mount, it lets a team work on the same state without an object store.
+// The version of an object is the sha256 sum of its content
+type LocalBackend struct {
+	rootDir string
+	// called by deleteVersion once the object is renamed, to test interleavings
+	onRenamed func(key string)
+}
+
+func NewLocalBackend(rootDir string) (*LocalBackend, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(rootDir, constants.DefaultPerms755); err != nil {
+		return nil, err
+	}
+	return &LocalBackend{rootDir: rootDir}, nil
+}
+
+func (b *LocalBackend) URL() string {
+	return FileScheme + "://" + filepath.ToSlash(b.rootDir)
+}
+
+func (b *LocalBackend) path(key string) string {
+	return filepath.Join(b.rootDir, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func getVersion(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func (b *LocalBackend) Read(key string) ([]byte, error) {
+	return os.ReadFile(b.path(key))
+}
+
+func (b *LocalBackend) ReadVersion(key string) ([]byte, string, error) {
+	data, err := b.Read(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, getVersion(data), nil
+}
+
+// Write replaces the object through a rename, so that readers never see it
+// partially written
+func (b *LocalBackend) Write(key string, data []byte) error {
+	objectPath := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(objectPath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(objectPath), filepath.Base(objectPath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), constants.WriteReadReadPerms); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), objectPath)
+}
+
+func (b *LocalBackend) Delete(key string) error {
+	if err := os.Remove(b.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *LocalBackend) List(prefix string) ([]string, error) {
+	keys := []string{}
+	err := b.walk(prefix, func(key string, _ string) error {
+		keys = append(keys, key)
+		return nil
+	})
+	return keys, err
+}
+
+// ListVersions reads every listed object, as their versions are not kept apart
+func (b *LocalBackend) ListVersions(prefix string) (map[string]string, error) {
+	versions := map[string]string{}
+	err := b.walk(prefix, func(key string, objectPath string) error {
+		data, err := os.ReadFile(objectPath)
+		if err != nil {
+			return err
+		}
+		versions[key] = getVersion(data)
+		return nil
+	})
+	return versions, err
+}
+
+// calls [f] with the key and the path of each object whose key starts with
+// [prefix], lock objects excluded
+func (b *LocalBackend) walk(prefix string, f func(key string, objectPath string) error) error {
+	return filepath.WalkDir(b.rootDir, func(objectPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(b.rootDir, objectPath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relPath)
+		if strings.HasPrefix(key, prefix) && !isLockKey(key) {
+			return f(key, objectPath)
+		}
+		return nil
+	})
+}
+
+func (b *LocalBackend) Lock(key string) (func() error, error) {
+	return acquireLock(b, key)
+}
+
+// createExclusive relies on O_EXCL, which is atomic also on NFS v3 and later
+func (b *LocalBackend) createExclusive(key string, data []byte) (string, bool, error) {
+	objectPath := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(objectPath), constants.DefaultPerms755); err != nil {
+		return "", false, err
+	}
+	f, err := os.OpenFile(objectPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, constants.WriteReadReadPerms)
+	if errors.Is(err, fs.ErrExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(objectPath)
+		return "", false, err
+	}
+	return getVersion(data), true, f.Close()
+}
+
+// deleteVersion first renames the object to a name of its own, which is
+// atomic, and only then checks its version, so that an object replaced by
+// someone else in the meantime is never removed. Such an object is put back.
+// If yet another one was created since, it can't be, and ErrLockLost is returned
+func (b *LocalBackend) deleteVersion(key string, version string) (bool, error) {
+	objectPath := b.path(key)
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, err
+	}
+	// named as a lock object, so that it is never listed
+	removedPath := fmt.Sprintf("%s.removed-%s%s", objectPath, hex.EncodeToString(nonce), lockSuffix)
+	if err := os.Rename(objectPath, removedPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if b.onRenamed != nil {
+		b.onRenamed(key)
+	}
+	data, err := os.ReadFile(removedPath)
+	if err != nil {
+		return false, err
+	}
+	if getVersion(data) != version {
+		_, created, err := b.createExclusive(key, data)
+		if err != nil {
+			return false, err
+		}
+		if err := os.Remove(removedPath); err != nil {
+			return false, err
+		}
+		if !created {
+			return false, fmt.Errorf("%w: %s was replaced while being removed, and created again before it could be put back", ErrLockLost, key)
+		}
+		return false, nil
+	}
+	return true, os.Remove(removedPath)
+}
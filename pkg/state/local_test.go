@@ -0,0 +1,62 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend(t *testing.T) {
+	b, err := NewLocalBackend(t.TempDir())
+	require.NoError(t, err)
+	testBackend(t, b)
+}
+
+func TestLocalBackendLock(t *testing.T) {
+	b, err := NewLocalBackend(t.TempDir())
+	require.NoError(t, err)
+	testBackendLock(t, b)
+}
+
+// a lock replaced while being removed that can't be put back, as it was taken
+// again in the meantime, is reported as lost
+func TestLocalBackendLockLost(t *testing.T) {
+	require := require.New(t)
+
+	b, err := NewLocalBackend(t.TempDir())
+	require.NoError(err)
+	lockKey := "subnets/a/sidecar.json" + lockSuffix
+	expired, err := json.Marshal(lockInfo{Owner: "crashed", Expires: time.Now().Add(-time.Minute)})
+	require.NoError(err)
+	held, err := json.Marshal(lockInfo{Owner: "holder", Expires: time.Now().Add(time.Minute)})
+	require.NoError(err)
+	third, err := json.Marshal(lockInfo{Owner: "third", Expires: time.Now().Add(time.Minute)})
+	require.NoError(err)
+
+	require.NoError(b.Write(lockKey, expired))
+	_, expiredVersion, err := b.ReadVersion(lockKey)
+	require.NoError(err)
+	// the expired lock is replaced by its new holder before it is removed
+	require.NoError(b.Write(lockKey, held))
+	b.onRenamed = func(key string) {
+		// and a third process takes the lock while it is renamed
+		_, created, err := b.createExclusive(key, third)
+		require.NoError(err)
+		require.True(created)
+	}
+	deleted, err := b.deleteVersion(lockKey, expiredVersion)
+	require.ErrorIs(err, ErrLockLost)
+	require.False(deleted)
+	data, err := b.Read(lockKey)
+	require.NoError(err)
+	require.Equal(third, data)
+	// the renamed lock is not left behind
+	removed, err := filepath.Glob(b.path(lockKey) + ".removed-*")
+	require.NoError(err)
+	require.Empty(removed)
+}
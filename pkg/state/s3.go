@@ -0,0 +1,216 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const defaultS3Region = "us-east-1"
+
+// S3Backend keeps the state in an S3 compatible bucket, as AWS S3 or MinIO.
+// The version of an object is its ETag. Locks rely on conditional writes
+// (If-None-Match: *) and deletes (If-Match), which the object store must support
+type S3Backend struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Backend returns a backend for the objects under [prefix] in [bucket].
+// Credentials are taken from the AWS environment variables or shared
+// config files. A non empty [endpoint] selects an S3 compatible server,
+// addressed with path style requests
+func NewS3Backend(bucket string, prefix string, endpoint string, region string) (*S3Backend, error) {
+	if region == "" {
+		region = defaultS3Region
+	}
+	awsConfig := &aws.Config{
+		Region: aws.String(region),
+	}
+	if endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Backend{
+		client: s3.New(sess),
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+func (b *S3Backend) URL() string {
+	if b.prefix == "" {
+		return S3Scheme + "://" + b.bucket
+	}
+	return S3Scheme + "://" + b.bucket + "/" + b.prefix
+}
+
+func (b *S3Backend) objectKey(key string) string {
+	return path.Join(b.prefix, key)
+}
+
+func (b *S3Backend) Read(key string) ([]byte, error) {
+	data, _, err := b.ReadVersion(key)
+	return data, err
+}
+
+func (b *S3Backend) ReadVersion(key string) ([]byte, string, error) {
+	output, err := b.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, "", fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+		}
+		return nil, "", err
+	}
+	defer output.Body.Close()
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, aws.StringValue(output.ETag), nil
+}
+
+func (b *S3Backend) Write(key string, data []byte) error {
+	_, err := b.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (b *S3Backend) Delete(key string) error {
+	_, err := b.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (b *S3Backend) List(prefix string) ([]string, error) {
+	versions, err := b.ListVersions(prefix)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *S3Backend) ListVersions(prefix string) (map[string]string, error) {
+	versions := map[string]string{}
+	objectPrefix := b.objectKey(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		objectPrefix += "/"
+	}
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.bucket),
+		Prefix: aws.String(objectPrefix),
+	}
+	err := b.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			key := aws.StringValue(object.Key)
+			if b.prefix != "" {
+				key = strings.TrimPrefix(key, b.prefix+"/")
+			}
+			if !isLockKey(key) {
+				versions[key] = aws.StringValue(object.ETag)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (b *S3Backend) Lock(key string) (func() error, error) {
+	return acquireLock(b, key)
+}
+
+// createExclusive issues a conditional put, which fails with 412 (or 409 for
+// a concurrent conditional request) if the object already exists
+func (b *S3Backend) createExclusive(key string, data []byte) (string, bool, error) {
+	req, output := b.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+		Body:   bytes.NewReader(data),
+	})
+	req.HTTPRequest.Header.Set("If-None-Match", "*")
+	if err := req.Send(); err != nil {
+		if isS3PreconditionFailed(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return aws.StringValue(output.ETag), true, nil
+}
+
+// deleteVersion issues a conditional delete, which fails with 412 (or 409 for
+// a concurrent conditional request) if the ETag of the object is not [version]
+func (b *S3Backend) deleteVersion(key string, version string) (bool, error) {
+	req, _ := b.client.DeleteObjectRequest(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	req.HTTPRequest.Header.Set("If-Match", version)
+	if err := req.Send(); err != nil {
+		if isS3PreconditionFailed(err) || isS3NotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func isS3PreconditionFailed(err error) bool {
+	var reqErr awserr.RequestFailure
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	switch reqErr.StatusCode() {
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return true
+	}
+	return false
+}
+
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
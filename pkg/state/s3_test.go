@@ -0,0 +1,146 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBucket = "lux-state"
+
+// fakeS3 serves the subset of the S3 API used by S3Backend on a single bucket,
+// with path style addressing, as a MinIO server would. ETags are derived from
+// the content of the objects
+type fakeS3 struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+type fakeS3Object struct {
+	Key  string `xml:"Key"`
+	ETag string `xml:"ETag"`
+}
+
+type fakeS3ListResult struct {
+	XMLName     xml.Name       `xml:"ListBucketResult"`
+	Name        string         `xml:"Name"`
+	Prefix      string         `xml:"Prefix"`
+	KeyCount    int            `xml:"KeyCount"`
+	IsTruncated bool           `xml:"IsTruncated"`
+	Contents    []fakeS3Object `xml:"Contents"`
+}
+
+func (s *fakeS3) writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func fakeETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// checks the If-Match and If-None-Match conditions of [r] on [key]
+func (s *fakeS3) checkConditions(w http.ResponseWriter, r *http.Request, key string) bool {
+	data, ok := s.objects[key]
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ok && ifNoneMatch == "*" {
+		s.writeError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+		return false
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return false
+		}
+		if ifMatch != fakeETag(data) {
+			s.writeError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return false
+		}
+	}
+	return true
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != testBucket {
+		s.writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		prefix := r.URL.Query().Get("prefix")
+		result := fakeS3ListResult{Name: bucket, Prefix: prefix}
+		for objectKey, data := range s.objects {
+			if strings.HasPrefix(objectKey, prefix) {
+				result.Contents = append(result.Contents, fakeS3Object{Key: objectKey, ETag: fakeETag(data)})
+			}
+		}
+		sort.Slice(result.Contents, func(i, j int) bool {
+			return result.Contents[i].Key < result.Contents[j].Key
+		})
+		result.KeyCount = len(result.Contents)
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", fakeETag(data))
+		_, _ = w.Write(data)
+	case r.Method == http.MethodPut:
+		if !s.checkConditions(w, r, key) {
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		s.objects[key] = data
+		w.Header().Set("ETag", fakeETag(data))
+	case r.Method == http.MethodDelete:
+		if !s.checkConditions(w, r, key) {
+			return
+		}
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func newTestS3Backend(t *testing.T, prefix string) *S3Backend {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	t.Cleanup(server.Close)
+	b, err := NewS3Backend(testBucket, prefix, server.URL, "")
+	require.NoError(t, err)
+	return b
+}
+
+func TestS3Backend(t *testing.T) {
+	testBackend(t, newTestS3Backend(t, ""))
+	testBackend(t, newTestS3Backend(t, "team/state"))
+}
+
+func TestS3BackendLock(t *testing.T) {
+	testBackendLock(t, newTestS3Backend(t, "team"))
+}
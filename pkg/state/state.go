@@ -0,0 +1,185 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	FileScheme = "file"
+	S3Scheme   = "s3"
+
+	lockSuffix = ".lock"
+)
+
+var (
+	// ErrLocked is returned when a lock is held by someone else for longer than the lock timeout
+	ErrLocked = errors.New("state object is locked")
+	// ErrLockLost is returned when a lock replaced while being removed can't be put
+	// back, as it was taken again in the meantime. Its holder no longer holds it
+	ErrLockLost = errors.New("state lock was lost")
+
+	// a lock not released before expiring is taken as left behind by a crashed process
+	lockTTL = 10 * time.Minute
+	// how long to wait for a lock held by someone else
+	lockTimeout       = 30 * time.Second
+	lockRetryInterval = time.Second
+)
+
+// Backend stores the CLI state shared by a team as objects addressed by
+// slash separated keys, as "subnets/mysubnet/sidecar.json"
+type Backend interface {
+	// Read returns the content of [key]. The error wraps fs.ErrNotExist if there is no such object
+	Read(key string) ([]byte, error)
+	// ReadVersion returns the content of [key] together with its version, as
+	// given by ListVersions
+	ReadVersion(key string) ([]byte, string, error)
+	// Write sets the content of [key]
+	Write(key string, data []byte) error
+	// Delete removes [key]. Deleting a missing object is not an error
+	Delete(key string) error
+	// List returns the keys starting with [prefix], lock objects excluded
+	List(prefix string) ([]string, error)
+	// ListVersions returns the keys starting with [prefix], lock objects
+	// excluded, with the versions of their objects. A version changes
+	// whenever the content of its object does
+	ListVersions(prefix string) (map[string]string, error)
+	// Lock gives exclusive access to [key] to its caller, waiting for any other
+	// holder to release it. The returned function releases the lock
+	Lock(key string) (func() error, error)
+	// URL identifies the backend
+	URL() string
+}
+
+// Config selects the backend to use
+type Config struct {
+	// file:///path/to/dir for a directory, possibly on a shared mount, or
+	// s3://bucket/prefix for an S3 compatible bucket
+	URL string
+	// S3 compatible endpoint, as a MinIO server. Empty for AWS S3
+	Endpoint string
+	// S3 region
+	Region string
+}
+
+// New returns the backend described by [config]
+func New(config Config) (Backend, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state backend URL %q: %w", config.URL, err)
+	}
+	switch u.Scheme {
+	case FileScheme:
+		return NewLocalBackend(filepath.FromSlash(u.Host + u.Path))
+	case S3Scheme:
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid state backend URL %q: missing bucket", config.URL)
+		}
+		return NewS3Backend(u.Host, strings.Trim(u.Path, "/"), config.Endpoint, config.Region)
+	default:
+		return nil, fmt.Errorf("unsupported state backend URL %q: expected %s:// or %s://", config.URL, FileScheme, S3Scheme)
+	}
+}
+
+// lockInfo is the content of a lock object
+type lockInfo struct {
+	Owner   string
+	Expires time.Time
+}
+
+// objectStore is implemented by the backends to share the lock protocol
+type objectStore interface {
+	ReadVersion(key string) ([]byte, string, error)
+	// writes [key] only if it does not exist yet, returning its version, or
+	// false if it already exists
+	createExclusive(key string, data []byte) (string, bool, error)
+	// deletes [key] only if it is still at [version], returning false if it
+	// was changed or deleted by someone else
+	deleteVersion(key string, version string) (bool, error)
+}
+
+// acquireLock takes the lock of [key] in [store] by creating its lock object,
+// retrying until [lockTimeout] while someone else holds it. Expired locks are
+// removed
+func acquireLock(store objectStore, key string) (func() error, error) {
+	lockKey := key + lockSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lock := lockInfo{
+			Owner:   lockOwner(),
+			Expires: time.Now().Add(lockTTL).UTC(),
+		}
+		lockBytes, err := json.Marshal(lock)
+		if err != nil {
+			return nil, err
+		}
+		version, created, err := store.createExclusive(lockKey, lockBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock %s: %w", key, err)
+		}
+		if created {
+			return func() error {
+				return releaseLock(store, lockKey, version)
+			}, nil
+		}
+		heldBytes, heldVersion, err := store.ReadVersion(lockKey)
+		if errors.Is(err, fs.ErrNotExist) {
+			// released in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lock of %s: %w", key, err)
+		}
+		// a lock being written can't be parsed yet, and is waited for as a held one
+		var held lockInfo
+		if err := json.Unmarshal(heldBytes, &held); err == nil && time.Now().After(held.Expires) {
+			// someone else may have removed it and taken the lock since it
+			// was read, in which case the new lock is kept
+			if _, err := store.deleteVersion(lockKey, heldVersion); err != nil {
+				return nil, fmt.Errorf("failed to remove expired lock of %s: %w", key, err)
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			if held.Owner == "" {
+				return nil, fmt.Errorf("%w: %s has an invalid lock, remove %s if nobody is working on it", ErrLocked, key, lockKey)
+			}
+			return nil, fmt.Errorf("%w: %s is locked by %s until %s", ErrLocked, key, held.Owner, held.Expires.Local().Format(time.RFC3339))
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removes the lock object [lockKey] if it is still at the [version] it was
+// created with, as once expired it may have been taken by someone else
+func releaseLock(store objectStore, lockKey string, version string) error {
+	_, err := store.deleteVersion(lockKey, version)
+	return err
+}
+
+// lockOwner identifies the lock holder to the users waiting for it
+func lockOwner() string {
+	userName := "unknown"
+	if u, err := user.Current(); err == nil {
+		userName = u.Username
+	}
+	hostName, err := os.Hostname()
+	if err != nil {
+		hostName = "unknown"
+	}
+	return fmt.Sprintf("%s@%s (pid %d)", userName, hostName, os.Getpid())
+}
+
+func isLockKey(key string) bool {
+	return strings.HasSuffix(key, lockSuffix)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// checks the behavior common to all backends
+func testBackend(t *testing.T, b Backend) {
+	require := require.New(t)
+
+	_, err := b.Read("subnets/a/sidecar.json")
+	require.ErrorIs(err, fs.ErrNotExist)
+
+	require.NoError(b.Write("subnets/a/sidecar.json", []byte("a")))
+	require.NoError(b.Write("subnets/b/sidecar.json", []byte("b")))
+	require.NoError(b.Write("nodes/cluster_config.json", []byte("c")))
+	require.NoError(b.Write("subnets/a/sidecar.json", []byte("a2")))
+
+	data, err := b.Read("subnets/a/sidecar.json")
+	require.NoError(err)
+	require.Equal([]byte("a2"), data)
+
+	keys, err := b.List("subnets/")
+	require.NoError(err)
+	require.ElementsMatch([]string{"subnets/a/sidecar.json", "subnets/b/sidecar.json"}, keys)
+
+	// versions change with the content of their object only
+	versions, err := b.ListVersions("subnets/")
+	require.NoError(err)
+	require.Len(versions, 2)
+	data, version, err := b.ReadVersion("subnets/a/sidecar.json")
+	require.NoError(err)
+	require.Equal([]byte("a2"), data)
+	require.Equal(versions["subnets/a/sidecar.json"], version)
+	require.NoError(b.Write("subnets/a/sidecar.json", []byte("a3")))
+	newVersions, err := b.ListVersions("subnets/")
+	require.NoError(err)
+	require.NotEqual(versions["subnets/a/sidecar.json"], newVersions["subnets/a/sidecar.json"])
+	require.Equal(versions["subnets/b/sidecar.json"], newVersions["subnets/b/sidecar.json"])
+
+	require.NoError(b.Delete("subnets/b/sidecar.json"))
+	require.NoError(b.Delete("subnets/b/sidecar.json"))
+	_, err = b.Read("subnets/b/sidecar.json")
+	require.ErrorIs(err, fs.ErrNotExist)
+
+	keys, err = b.List("")
+	require.NoError(err)
+	require.ElementsMatch([]string{"subnets/a/sidecar.json", "nodes/cluster_config.json"}, keys)
+}
+
+// checks the locking common to all backends
+func testBackendLock(t *testing.T, b Backend) {
+	require := require.New(t)
+
+	prevTimeout, prevInterval := lockTimeout, lockRetryInterval
+	lockTimeout, lockRetryInterval = 100*time.Millisecond, 10*time.Millisecond
+	defer func() {
+		lockTimeout, lockRetryInterval = prevTimeout, prevInterval
+	}()
+
+	unlock, err := b.Lock("subnets/a/sidecar.json")
+	require.NoError(err)
+	// the lock is not listed as state
+	keys, err := b.List("subnets/")
+	require.NoError(err)
+	require.Empty(keys)
+
+	_, err = b.Lock("subnets/a/sidecar.json")
+	require.ErrorIs(err, ErrLocked)
+	// other objects are not affected
+	unlockOther, err := b.Lock("subnets/b/sidecar.json")
+	require.NoError(err)
+	require.NoError(unlockOther())
+
+	require.NoError(unlock())
+	unlock, err = b.Lock("subnets/a/sidecar.json")
+	require.NoError(err)
+	require.NoError(unlock())
+
+	// a lock left behind by a crashed process is taken over once expired
+	expired, err := json.Marshal(lockInfo{Owner: "someone", Expires: time.Now().Add(-time.Minute)})
+	require.NoError(err)
+	require.NoError(b.Write("subnets/a/sidecar.json"+lockSuffix, expired))
+	unlock, err = b.Lock("subnets/a/sidecar.json")
+	require.NoError(err)
+	require.NoError(unlock())
+	_, err = b.Read("subnets/a/sidecar.json" + lockSuffix)
+	require.True(errors.Is(err, fs.ErrNotExist))
+
+	// an expired lock is only removed while it is the one that was read
+	store, ok := b.(objectStore)
+	require.True(ok)
+	lockKey := "subnets/a/sidecar.json" + lockSuffix
+	taken, err := json.Marshal(lockInfo{Owner: "someone else", Expires: time.Now().Add(time.Minute)})
+	require.NoError(err)
+	require.NoError(b.Write(lockKey, expired))
+	_, expiredVersion, err := store.ReadVersion(lockKey)
+	require.NoError(err)
+	require.NoError(b.Write(lockKey, taken))
+	deleted, err := store.deleteVersion(lockKey, expiredVersion)
+	require.NoError(err)
+	require.False(deleted)
+	data, takenVersion, err := store.ReadVersion(lockKey)
+	require.NoError(err)
+	require.Equal(taken, data)
+	require.NoError(b.Delete(lockKey))
+
+	// a lock taken by someone else once expired is not released by its former holder
+	unlock, err = b.Lock("subnets/a/sidecar.json")
+	require.NoError(err)
+	require.NoError(b.Write(lockKey, taken))
+	require.NoError(unlock())
+	data, err = b.Read(lockKey)
+	require.NoError(err)
+	require.Equal(taken, data)
+	deleted, err = store.deleteVersion(lockKey, takenVersion)
+	require.NoError(err)
+	require.True(deleted)
+	keys, err = b.List("")
+	require.NoError(err)
+	require.Empty(keys)
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	b, err := New(Config{URL: "file://" + dir})
+	require.NoError(err)
+	require.IsType(&LocalBackend{}, b)
+
+	b, err = New(Config{URL: "s3://bucket/team/state", Endpoint: "http://localhost:9000"})
+	require.NoError(err)
+	require.IsType(&S3Backend{}, b)
+	require.Equal("s3://bucket/team/state", b.URL())
+
+	_, err = New(Config{URL: "s3:///state"})
+	require.Error(err)
+	_, err = New(Config{URL: "gs://bucket"})
+	require.Error(err)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+)
+
+// GetSubnetChains returns the chains of [subnetName]: first the chain created
+// with the subnet, followed by the chains added to it with subnet add-chain,
+// sorted by name
+func GetSubnetChains(app *application.Lux, subnetName string) ([]string, error) {
+	subnets, err := os.ReadDir(app.GetSubnetDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseDir: %w", err)
+	}
+
+	chains := []string{}
+
+	for _, s := range subnets {
+		if !s.IsDir() {
+			continue
+		}
+		sidecarFile := filepath.Join(app.GetSubnetDir(), s.Name(), constants.SidecarFileName)
+		if _, err := os.Stat(sidecarFile); err == nil {
+			// read in sidecar file
+			jsonBytes, err := os.ReadFile(sidecarFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed reading file %s: %w", sidecarFile, err)
+			}
+
+			var sc models.Sidecar
+			err = json.Unmarshal(jsonBytes, &sc)
+			if err != nil {
+				return nil, fmt.Errorf("failed unmarshaling file %s: %w", sidecarFile, err)
+			}
+			if sc.Subnet == subnetName {
+				chains = append(chains, sc.Name)
+			}
+		}
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		if chains[i] == subnetName || chains[j] == subnetName {
+			return chains[i] == subnetName
+		}
+		return chains[i] < chains[j]
+	})
+	return chains, nil
+}
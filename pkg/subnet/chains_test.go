@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"os"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/config"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubnetChains(t *testing.T) {
+	require := require.New(t)
+
+	testDir, err := os.MkdirTemp(os.TempDir(), "chains-test")
+	require.NoError(err)
+	defer os.RemoveAll(testDir)
+
+	app := &application.Lux{}
+	app.Setup(testDir, logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	sidecars := []models.Sidecar{
+		{Name: "zeta", Subnet: "alpha", VM: models.SubnetEvm},
+		{Name: "alpha", Subnet: "alpha", VM: models.SubnetEvm},
+		{Name: "beta", Subnet: "alpha", VM: models.CustomVM},
+		{Name: "other", Subnet: "other", VM: models.SubnetEvm},
+	}
+	for i := range sidecars {
+		require.NoError(app.CreateSidecar(&sidecars[i]))
+	}
+
+	chains, err := GetSubnetChains(app, "alpha")
+	require.NoError(err)
+	require.Equal([]string{"alpha", "beta", "zeta"}, chains)
+
+	chains, err = GetSubnetChains(app, "other")
+	require.NoError(err)
+	require.Equal([]string{"other"}, chains)
+
+	chains, err = GetSubnetChains(app, "zeta")
+	require.NoError(err)
+	require.Empty(chains)
+}
@@ -0,0 +1,330 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/key"
+	"github.com/luxdefi/cli/pkg/txutils"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/choices"
+	luxdconstants "github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/formatting/address"
+	"github.com/luxdefi/node/utils/rpc"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/avm"
+	avmtxs "github.com/luxdefi/node/vms/avm/txs"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/status"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/chain/c"
+	"github.com/luxdefi/node/wallet/chain/p"
+	"github.com/luxdefi/node/wallet/chain/x"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+)
+
+// dryRunState is the wallet used by a dry run. It is loaded once from the
+// network, and then the txs of the dry run are accepted into it instead of
+// being issued, so that the txs depending on previous ones can also be built
+type dryRunState struct {
+	wallet  primary.Wallet
+	pClient platformvm.Client
+	// P-Chain txs known by the wallet, either fetched or accepted by the dry run
+	pChainTxs map[ids.ID]*txs.Tx
+}
+
+// SetDryRun makes the deployer build and sign its txs, but print them instead
+// of issuing them: the tx type and ID, its fee, and the subnet control keys
+// that signed it or still have to. The funding addresses and their balances
+// are printed when the wallet is loaded
+func (d *PublicDeployer) SetDryRun(dryRun bool) {
+	d.dryRun = dryRun
+}
+
+// GetOwners returns the control keys and threshold of [subnetID]. On a dry
+// run, the subnet may have been created by the dry run itself
+func (d *PublicDeployer) GetOwners(subnetID ids.ID) ([]string, uint32, error) {
+	if !d.dryRun {
+		return txutils.GetOwners(d.network, subnetID)
+	}
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	if _, err := d.loadDryRunWallet(ctx, nil); err != nil {
+		return nil, 0, err
+	}
+	subnetTx, err := d.dryRunState.getPChainTx(ctx, subnetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return txutils.GetSubnetTxOwners(d.network.ID, subnetTx)
+}
+
+func (d *PublicDeployer) printSuccess(format string, args ...interface{}) {
+	// on a dry run, the tx has already been printed as planned
+	if d.dryRun {
+		return
+	}
+	ux.Logger.PrintToUser(format, args...)
+}
+
+func (d *PublicDeployer) loadDryRunWallet(ctx context.Context, preloadTxs []ids.ID) (primary.Wallet, error) {
+	if d.dryRunState == nil {
+		state, err := d.newDryRunState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.dryRunState = state
+	}
+	for _, txID := range preloadTxs {
+		if _, err := d.dryRunState.getPChainTx(ctx, txID); err != nil {
+			return nil, err
+		}
+	}
+	return d.dryRunState.wallet, nil
+}
+
+// builds the wallet as primary.MakeWallet does, but with clients that don't
+// issue the txs
+func (d *PublicDeployer) newDryRunState(ctx context.Context) (*dryRunState, error) {
+	luxAddrs := d.kc.Addresses()
+	luxState, err := primary.FetchState(ctx, d.network.Endpoint, luxAddrs)
+	if err != nil {
+		return nil, err
+	}
+	ethKeychain := secp256k1fx.NewKeychain()
+	ethAddrs := ethKeychain.EthAddresses()
+	ethState, err := primary.FetchEthState(ctx, d.network.Endpoint, ethAddrs)
+	if err != nil {
+		return nil, err
+	}
+	state := &dryRunState{
+		pClient:   luxState.PClient,
+		pChainTxs: map[ids.ID]*txs.Tx{},
+	}
+
+	pUTXOs := primary.NewChainUTXOs(luxdconstants.PlatformChainID, luxState.UTXOs)
+	pBackend := p.NewBackend(luxState.PCTX, pUTXOs, state.pChainTxs)
+	pClient := &dryRunPClient{Client: luxState.PClient, d: d}
+
+	xChainID := luxState.XCTX.BlockchainID()
+	xUTXOs := primary.NewChainUTXOs(xChainID, luxState.UTXOs)
+	xBackend := x.NewBackend(luxState.XCTX, xUTXOs)
+	xClient := &dryRunXClient{Client: luxState.XClient, d: d, luxAssetID: luxState.XCTX.LUXAssetID()}
+
+	cChainID := luxState.CCTX.BlockchainID()
+	cUTXOs := primary.NewChainUTXOs(cChainID, luxState.UTXOs)
+	cBackend := c.NewBackend(luxState.CCTX, cUTXOs, ethState.Accounts)
+
+	state.wallet = primary.NewWallet(
+		p.NewWallet(p.NewBuilder(luxAddrs, pBackend), p.NewSigner(d.kc.Keychain, pBackend), pClient, pBackend),
+		x.NewWallet(x.NewBuilder(luxAddrs, xBackend), x.NewSigner(d.kc.Keychain, xBackend), xClient, xBackend),
+		c.NewWallet(
+			c.NewBuilder(luxAddrs, ethAddrs, cBackend),
+			c.NewSigner(d.kc.Keychain, ethKeychain, cBackend),
+			luxState.CClient,
+			ethState.Client,
+			cBackend,
+		),
+	)
+
+	if err := d.printFundingAddresses(ctx, luxState.PClient, luxState.XClient, luxState.XCTX.LUXAssetID()); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *dryRunState) getPChainTx(ctx context.Context, txID ids.ID) (*txs.Tx, error) {
+	if tx, ok := s.pChainTxs[txID]; ok {
+		return tx, nil
+	}
+	txBytes, err := s.pClient.GetTx(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("tx %s query error: %w", txID, err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal tx %s: %w", txID, err)
+	}
+	s.pChainTxs[txID] = tx
+	return tx, nil
+}
+
+func (d *PublicDeployer) printFundingAddresses(
+	ctx context.Context,
+	pClient platformvm.Client,
+	xClient avm.Client,
+	luxAssetID ids.ID,
+) error {
+	ux.Logger.PrintToUser("Dry run on %s: transactions are built and signed, but not issued", d.network.Name())
+	ux.Logger.PrintToUser("Funding addresses:")
+	hrp := key.GetHRP(d.network.ID)
+	for _, addr := range d.kc.Addresses().List() {
+		pBalance, err := pClient.GetBalance(ctx, []ids.ShortID{addr})
+		if err != nil {
+			return err
+		}
+		xBalance, err := xClient.GetBalance(ctx, addr, luxAssetID.String(), false)
+		if err != nil {
+			return err
+		}
+		pAddr, err := address.Format("P", hrp, addr[:])
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("  %s: P-Chain %s, X-Chain %s",
+			pAddr, formatLUX(uint64(pBalance.Unlocked)), formatLUX(uint64(xBalance.Balance)))
+	}
+	return nil
+}
+
+func (d *PublicDeployer) printDryRunPTx(tx *txs.Tx) error {
+	fee, err := txutils.GetFee(tx)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("[dry-run] %s on P-Chain, tx ID %s", txutils.GetTxTypeName(tx), tx.ID())
+	ux.Logger.PrintToUser("  Fee: %s", formatLUX(fee))
+	if !txutils.NeedsSubnetAuth(tx) {
+		ux.Logger.PrintToUser("  Control key signatures: not required")
+		return nil
+	}
+	subnetID, err := txutils.GetSubnetID(tx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	subnetTx, err := d.dryRunState.getPChainTx(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+	controlKeys, threshold, err := txutils.GetSubnetTxOwners(d.network.ID, subnetTx)
+	if err != nil {
+		return err
+	}
+	info, err := txutils.Inspect(tx, controlKeys, threshold)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("  Control key signatures: %d of %s", threshold, strings.Join(controlKeys, ", "))
+	ux.Logger.PrintToUser("    Signed: %s", formatSigners(info.Signers.Signed))
+	ux.Logger.PrintToUser("    Missing: %s", formatSigners(info.Signers.Remaining))
+	return nil
+}
+
+func (*PublicDeployer) printDryRunXTx(tx *avmtxs.Tx, luxAssetID ids.ID) error {
+	var (
+		baseTx    *avmtxs.BaseTx
+		extraIns  []*lux.TransferableInput
+		extraOuts []*lux.TransferableOutput
+	)
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *avmtxs.BaseTx:
+		baseTx = unsignedTx
+	case *avmtxs.CreateAssetTx:
+		baseTx = &unsignedTx.BaseTx
+	case *avmtxs.ExportTx:
+		baseTx = &unsignedTx.BaseTx
+		extraOuts = unsignedTx.ExportedOuts
+	case *avmtxs.ImportTx:
+		baseTx = &unsignedTx.BaseTx
+		extraIns = unsignedTx.ImportedIns
+	default:
+		return fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	}
+	// the fee is paid in LUX, any other asset is just moved
+	consumed := uint64(0)
+	for _, ins := range [][]*lux.TransferableInput{baseTx.Ins, extraIns} {
+		for _, in := range ins {
+			if in.AssetID() == luxAssetID {
+				consumed += in.In.Amount()
+			}
+		}
+	}
+	produced := uint64(0)
+	for _, outs := range [][]*lux.TransferableOutput{baseTx.Outs, extraOuts} {
+		for _, out := range outs {
+			if out.AssetID() == luxAssetID {
+				produced += out.Out.Amount()
+			}
+		}
+	}
+	if produced > consumed {
+		return fmt.Errorf("tx produces %d more than it consumes", produced-consumed)
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("[dry-run] %s on X-Chain, tx ID %s", getXTxTypeName(tx), tx.ID())
+	ux.Logger.PrintToUser("  Fee: %s", formatLUX(consumed-produced))
+	ux.Logger.PrintToUser("  Control key signatures: not required")
+	return nil
+}
+
+func formatLUX(amount uint64) string {
+	return fmt.Sprintf("%.9f LUX", float64(amount)/float64(units.Lux))
+}
+
+func formatSigners(addrs []string) string {
+	if len(addrs) == 0 {
+		return "-"
+	}
+	return strings.Join(addrs, ", ")
+}
+
+// dryRunPClient prints the P-Chain txs instead of issuing them, and reports
+// them as committed so that the wallet accepts them
+type dryRunPClient struct {
+	platformvm.Client
+	d *PublicDeployer
+}
+
+func (c *dryRunPClient) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if err := c.d.printDryRunPTx(tx); err != nil {
+		return ids.Empty, err
+	}
+	return tx.ID(), nil
+}
+
+func (*dryRunPClient) AwaitTxDecided(
+	context.Context,
+	ids.ID,
+	time.Duration,
+	...rpc.Option,
+) (*platformvm.GetTxStatusResponse, error) {
+	return &platformvm.GetTxStatusResponse{Status: status.Committed}, nil
+}
+
+// dryRunXClient prints the X-Chain txs instead of issuing them, and reports
+// them as accepted so that the wallet accepts them
+type dryRunXClient struct {
+	avm.Client
+	d          *PublicDeployer
+	luxAssetID ids.ID
+}
+
+func (c *dryRunXClient) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	tx, err := x.Parser.ParseTx(txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if err := c.d.printDryRunXTx(tx, c.luxAssetID); err != nil {
+		return ids.Empty, err
+	}
+	return tx.ID(), nil
+}
+
+func (*dryRunXClient) ConfirmTx(context.Context, ids.ID, time.Duration, ...rpc.Option) (choices.Status, error) {
+	return choices.Accepted, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/logging"
+	avmtxs "github.com/luxdefi/node/vms/avm/txs"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/status"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunPClientIssueTx(t *testing.T) {
+	require := require.New(t)
+
+	var out bytes.Buffer
+	ux.NewUserLog(logging.NoLog{}, &out)
+
+	assetID := ids.GenerateTestID()
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: lux.BaseTx{
+					NetworkID:    models.FujiNetwork.ID,
+					BlockchainID: ids.Empty,
+					Ins: []*lux.TransferableInput{{
+						UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+						Asset:  lux.Asset{ID: assetID},
+						In:     &secp256k1fx.TransferInput{Amt: 1_000_000_000},
+					}},
+					Outs: []*lux.TransferableOutput{{
+						Asset: lux.Asset{ID: assetID},
+						Out:   &secp256k1fx.TransferOutput{Amt: 900_000_000},
+					}},
+				},
+			},
+			Owner: &secp256k1fx.OutputOwners{},
+		},
+	}
+	require.NoError(tx.Initialize(txs.Codec))
+
+	d := &PublicDeployer{network: models.FujiNetwork, dryRun: true}
+	client := &dryRunPClient{d: d}
+
+	txID, err := client.IssueTx(context.Background(), tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), txID)
+	require.Contains(out.String(), "CreateSubnetTx on P-Chain, tx ID "+tx.ID().String())
+	require.Contains(out.String(), "Fee: 0.100000000 LUX")
+	require.Contains(out.String(), "Control key signatures: not required")
+
+	resp, err := client.AwaitTxDecided(context.Background(), txID, 0)
+	require.NoError(err)
+	require.Equal(status.Committed, resp.Status)
+}
+
+func TestDryRunXTxFee(t *testing.T) {
+	require := require.New(t)
+
+	var out bytes.Buffer
+	ux.NewUserLog(logging.NoLog{}, &out)
+
+	luxAssetID := ids.GenerateTestID()
+	subnetAssetID := ids.GenerateTestID()
+	exportTx := &avmtxs.ExportTx{
+		BaseTx: avmtxs.BaseTx{
+			BaseTx: lux.BaseTx{
+				Ins: []*lux.TransferableInput{
+					{
+						Asset: lux.Asset{ID: luxAssetID},
+						In:    &secp256k1fx.TransferInput{Amt: 1_000},
+					},
+					{
+						Asset: lux.Asset{ID: subnetAssetID},
+						In:    &secp256k1fx.TransferInput{Amt: 5_000},
+					},
+				},
+				Outs: []*lux.TransferableOutput{{
+					Asset: lux.Asset{ID: luxAssetID},
+					Out:   &secp256k1fx.TransferOutput{Amt: 400},
+				}},
+			},
+		},
+		ExportedOuts: []*lux.TransferableOutput{
+			{
+				Asset: lux.Asset{ID: luxAssetID},
+				Out:   &secp256k1fx.TransferOutput{Amt: 500},
+			},
+			{
+				Asset: lux.Asset{ID: subnetAssetID},
+				Out:   &secp256k1fx.TransferOutput{Amt: 5_000},
+			},
+		},
+	}
+
+	d := &PublicDeployer{network: models.FujiNetwork, dryRun: true}
+	require.NoError(d.printDryRunXTx(&avmtxs.Tx{Unsigned: exportTx}, luxAssetID))
+	require.Contains(out.String(), "ExportTx on X-Chain")
+	require.Contains(out.String(), "Fee: 0.000000100 LUX")
+
+	exportTx.Outs[0].Out = &secp256k1fx.TransferOutput{Amt: 600}
+	require.Error(d.printDryRunXTx(&avmtxs.Tx{Unsigned: exportTx}, luxAssetID))
+}
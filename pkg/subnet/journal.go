@@ -0,0 +1,250 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/txutils"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/ids"
+	avmtxs "github.com/luxdefi/node/vms/avm/txs"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/status"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/wallet/chain/p"
+	"github.com/luxdefi/node/wallet/chain/x"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+	"github.com/luxdefi/node/wallet/subnet/primary/common"
+)
+
+const (
+	createSubnetTxType = "CreateSubnetTx"
+	createChainTxType  = "CreateChainTx"
+)
+
+// SetJournal makes the deployer record every tx it issues in the journal of
+// [subnetName]: once before issuing it, and once it is confirmed or fails.
+// Txs of a dry run are not recorded
+func (d *PublicDeployer) SetJournal(subnetName string) {
+	d.journalSubnet = subnetName
+}
+
+func (d *PublicDeployer) journaledWallet(wallet primary.Wallet) primary.Wallet {
+	if d.journalSubnet == "" {
+		return wallet
+	}
+	return &journalWallet{Wallet: wallet, d: d}
+}
+
+// issues the tx described by [entry] with [issue], recording it in the journal
+// before and after. If the tx can't be recorded, it is not issued
+func (d *PublicDeployer) journalIssue(entry models.JournalEntry, issue func() error) error {
+	entry.Network = d.network.Name()
+	entry.Status = models.JournalTxIssued
+	entry.Time = time.Now().UTC()
+	if err := d.app.AppendJournalEntry(d.journalSubnet, entry); err != nil {
+		return fmt.Errorf("failed to record tx %s in the journal of %s: %w", entry.TxID, d.journalSubnet, err)
+	}
+	issueErr := issue()
+	entry.Status = models.JournalTxConfirmed
+	if issueErr != nil {
+		entry.Status = models.JournalTxFailed
+		entry.Error = issueErr.Error()
+	}
+	entry.Time = time.Now().UTC()
+	if err := d.app.AppendJournalEntry(d.journalSubnet, entry); err != nil {
+		if issueErr != nil {
+			return issueErr
+		}
+		return fmt.Errorf("tx %s was confirmed, but failed to record it in the journal of %s: %w", entry.TxID, d.journalSubnet, err)
+	}
+	return issueErr
+}
+
+// journalWallet records the txs issued through its P and X wallets
+type journalWallet struct {
+	primary.Wallet
+	d *PublicDeployer
+}
+
+func (w *journalWallet) P() p.Wallet {
+	return &journalPWallet{Wallet: w.Wallet.P(), d: w.d}
+}
+
+func (w *journalWallet) X() x.Wallet {
+	return &journalXWallet{Wallet: w.Wallet.X(), d: w.d}
+}
+
+type journalPWallet struct {
+	p.Wallet
+	d *PublicDeployer
+}
+
+func (w *journalPWallet) IssueTx(tx *txs.Tx, options ...common.Option) error {
+	entry := models.JournalEntry{
+		Chain:  "P",
+		TxType: txutils.GetTxTypeName(tx),
+		TxID:   tx.ID(),
+	}
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *txs.CreateSubnetTx:
+		entry.SubnetID = tx.ID()
+	case *txs.CreateChainTx:
+		entry.SubnetID = unsignedTx.SubnetID
+		entry.BlockchainName = unsignedTx.ChainName
+	default:
+		// txs not bound to a subnet, as imports, keep an empty subnet ID
+		entry.SubnetID, _ = txutils.GetSubnetID(tx)
+	}
+	return w.d.journalIssue(entry, func() error {
+		return w.Wallet.IssueTx(tx, options...)
+	})
+}
+
+type journalXWallet struct {
+	x.Wallet
+	d *PublicDeployer
+}
+
+func (w *journalXWallet) IssueTx(tx *avmtxs.Tx, options ...common.Option) error {
+	entry := models.JournalEntry{
+		Chain:  "X",
+		TxType: getXTxTypeName(tx),
+		TxID:   tx.ID(),
+	}
+	return w.d.journalIssue(entry, func() error {
+		return w.Wallet.IssueTx(tx, options...)
+	})
+}
+
+func getXTxTypeName(tx *avmtxs.Tx) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", tx.Unsigned), "*txs.")
+}
+
+// DeployProgress is the part of the deploys to a network that was confirmed,
+// according to the subnet journal
+type DeployProgress struct {
+	// subnet of the last confirmed subnet or chain creation, if any
+	SubnetID ids.ID
+	// blockchain IDs of the chains created into SubnetID, by chain name
+	Blockchains map[string]ids.ID
+}
+
+// GetDeployProgress returns the last subnet deployed for [subnetName] on
+// [network], and the chains created into it, from the subnet journal. The outcome of txs that
+// failed or were not awaited until the end is checked on the P-Chain, as a tx
+// can be accepted after the CLI stops awaiting it, and it is then recorded
+func GetDeployProgress(app *application.Lux, network models.Network, subnetName string) (DeployProgress, error) {
+	entries, err := app.LoadJournal(subnetName)
+	if err != nil {
+		return DeployProgress{}, err
+	}
+	pClient := platformvm.NewClient(network.Endpoint)
+	getTxStatus := func(txID ids.ID) (status.Status, error) {
+		ctx, cancel := utils.GetAPIContext()
+		defer cancel()
+		resp, err := pClient.GetTxStatus(ctx, txID)
+		if err != nil {
+			return status.Unknown, fmt.Errorf("failed to get status of tx %s: %w", txID, err)
+		}
+		return resp.Status, nil
+	}
+	progress, confirmed, err := getDeployProgress(entries, network, getTxStatus)
+	if err != nil {
+		return DeployProgress{}, err
+	}
+	for _, entry := range confirmed {
+		entry.Status = models.JournalTxConfirmed
+		entry.Error = ""
+		entry.Time = time.Now().UTC()
+		if err := app.AppendJournalEntry(subnetName, entry); err != nil {
+			return DeployProgress{}, err
+		}
+	}
+	return progress, nil
+}
+
+// computes the deploy progress from the journal [entries], using [getTxStatus]
+// for the txs without a confirmation entry. Also returns the entries of the txs
+// that were found to be confirmed that way
+func getDeployProgress(
+	entries []models.JournalEntry,
+	network models.Network,
+	getTxStatus func(ids.ID) (status.Status, error),
+) (DeployProgress, []models.JournalEntry, error) {
+	// last entry of each deploy tx, in the order the txs were issued
+	lastEntries := map[ids.ID]models.JournalEntry{}
+	txIDs := []ids.ID{}
+	for _, entry := range entries {
+		if entry.Network != network.Name() || entry.Chain != "P" {
+			continue
+		}
+		if entry.TxType != createSubnetTxType && entry.TxType != createChainTxType {
+			continue
+		}
+		if _, ok := lastEntries[entry.TxID]; !ok {
+			txIDs = append(txIDs, entry.TxID)
+		}
+		lastEntries[entry.TxID] = entry
+	}
+
+	progress := DeployProgress{Blockchains: map[string]ids.ID{}}
+	newlyConfirmed := []models.JournalEntry{}
+	for _, txID := range txIDs {
+		entry := lastEntries[txID]
+		if entry.Status != models.JournalTxConfirmed {
+			txStatus, err := getTxStatus(txID)
+			if err != nil {
+				return DeployProgress{}, nil, err
+			}
+			switch txStatus {
+			case status.Committed:
+				ux.Logger.PrintToUser("%s %s was accepted after the deploy stopped awaiting it", entry.TxType, txID)
+				newlyConfirmed = append(newlyConfirmed, entry)
+			case status.Processing:
+				return DeployProgress{}, nil, fmt.Errorf("%s %s is still being processed, try again later", entry.TxType, txID)
+			default:
+				continue
+			}
+		}
+		switch entry.TxType {
+		case createSubnetTxType:
+			// a newer subnet replaces the previous one, with no chains yet
+			progress.SubnetID = txID
+			progress.Blockchains = map[string]ids.ID{}
+		case createChainTxType:
+			// chains may also be deployed into a subnet created elsewhere
+			if entry.SubnetID != progress.SubnetID {
+				progress.SubnetID = entry.SubnetID
+				progress.Blockchains = map[string]ids.ID{}
+			}
+			progress.Blockchains[entry.BlockchainName] = txID
+		}
+	}
+	return progress, newlyConfirmed, nil
+}
+
+// AddSidecarBlockchains adds to the progress the chains of [sidecars] that, according
+// to their sidecar, were created into SubnetID on [network] without being journaled,
+// as a CreateChainTx signed by several keys and issued with transaction commit
+func (p *DeployProgress) AddSidecarBlockchains(network models.Network, sidecars []models.Sidecar) {
+	if p.SubnetID == ids.Empty {
+		return
+	}
+	for _, sc := range sidecars {
+		if _, ok := p.Blockchains[sc.Name]; ok {
+			continue
+		}
+		networkData, ok := sc.Networks[network.Name()]
+		if !ok || networkData.SubnetID != p.SubnetID || networkData.BlockchainID == ids.Empty {
+			continue
+		}
+		p.Blockchains[sc.Name] = networkData.BlockchainID
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/config"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/vms/platformvm/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployProgressFromJournal(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+
+	testDir, err := os.MkdirTemp(os.TempDir(), "journal-test")
+	require.NoError(err)
+	defer os.RemoveAll(testDir)
+
+	app := &application.Lux{}
+	app.Setup(testDir, logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	entries, err := app.LoadJournal("test")
+	require.NoError(err)
+	require.Empty(entries)
+
+	subnetID := ids.GenerateTestID()
+	chainAID := ids.GenerateTestID()
+	chainBID := ids.GenerateTestID()
+	chainCID := ids.GenerateTestID()
+	fuji := models.FujiNetwork.Name()
+	journal := []models.JournalEntry{
+		{Network: fuji, Chain: "P", TxType: "CreateSubnetTx", TxID: subnetID, SubnetID: subnetID, Status: models.JournalTxIssued},
+		{Network: fuji, Chain: "P", TxType: "CreateSubnetTx", TxID: subnetID, SubnetID: subnetID, Status: models.JournalTxConfirmed},
+		{Network: fuji, Chain: "P", TxType: "CreateChainTx", TxID: chainAID, SubnetID: subnetID, BlockchainName: "a", Status: models.JournalTxIssued},
+		{Network: fuji, Chain: "P", TxType: "CreateChainTx", TxID: chainAID, SubnetID: subnetID, BlockchainName: "a", Status: models.JournalTxConfirmed},
+		// timed out awaiting it, but accepted afterwards
+		{Network: fuji, Chain: "P", TxType: "CreateChainTx", TxID: chainBID, SubnetID: subnetID, BlockchainName: "b", Status: models.JournalTxIssued},
+		{Network: fuji, Chain: "P", TxType: "CreateChainTx", TxID: chainBID, SubnetID: subnetID, BlockchainName: "b", Status: models.JournalTxFailed, Error: "timeout"},
+		// interrupted before being issued
+		{Network: fuji, Chain: "P", TxType: "CreateChainTx", TxID: chainCID, SubnetID: subnetID, BlockchainName: "c", Status: models.JournalTxIssued},
+		// other networks are ignored
+		{Network: models.MainnetNetwork.Name(), Chain: "P", TxType: "CreateSubnetTx", TxID: ids.GenerateTestID(), Status: models.JournalTxConfirmed},
+	}
+	for _, entry := range journal {
+		require.NoError(app.AppendJournalEntry("test", entry))
+	}
+	entries, err = app.LoadJournal("test")
+	require.NoError(err)
+	require.Len(entries, len(journal))
+	require.Equal(journal[5], entries[5])
+
+	queried := []ids.ID{}
+	getTxStatus := func(txID ids.ID) (status.Status, error) {
+		queried = append(queried, txID)
+		if txID == chainBID {
+			return status.Committed, nil
+		}
+		return status.Unknown, nil
+	}
+	progress, confirmed, err := getDeployProgress(entries, models.FujiNetwork, getTxStatus)
+	require.NoError(err)
+	require.Equal(subnetID, progress.SubnetID)
+	require.Equal(map[string]ids.ID{"a": chainAID, "b": chainBID}, progress.Blockchains)
+	require.Equal([]ids.ID{chainBID, chainCID}, queried)
+	require.Len(confirmed, 1)
+	require.Equal(chainBID, confirmed[0].TxID)
+
+	// a chain committed with transaction commit is recorded in its sidecar only
+	progress.AddSidecarBlockchains(models.FujiNetwork, []models.Sidecar{
+		{Name: "a", Networks: map[string]models.NetworkData{fuji: {SubnetID: subnetID, BlockchainID: ids.GenerateTestID()}}},
+		{Name: "c", Networks: map[string]models.NetworkData{fuji: {SubnetID: subnetID, BlockchainID: chainCID}}},
+		// created into another subnet
+		{Name: "d", Networks: map[string]models.NetworkData{fuji: {SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()}}},
+		// not deployed to the network
+		{Name: "e", Networks: map[string]models.NetworkData{models.MainnetNetwork.Name(): {SubnetID: subnetID, BlockchainID: ids.GenerateTestID()}}},
+		{Name: "f", Networks: map[string]models.NetworkData{fuji: {SubnetID: subnetID}}},
+	})
+	require.Equal(map[string]ids.ID{"a": chainAID, "b": chainBID, "c": chainCID}, progress.Blockchains)
+
+	// a tx still being processed can't be resumed yet
+	_, _, err = getDeployProgress(entries, models.FujiNetwork, func(ids.ID) (status.Status, error) {
+		return status.Processing, nil
+	})
+	require.Error(err)
+
+	progress, _, err = getDeployProgress(entries, models.LocalNetwork, getTxStatus)
+	require.NoError(err)
+	require.Equal(ids.Empty, progress.SubnetID)
+}
@@ -0,0 +1,460 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package lint checks the configuration files of a subnet against each other,
+// to catch before deploying what would otherwise only fail once the chain runs.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/subnet-evm/core"
+	"github.com/luxdefi/subnet-evm/params"
+	"github.com/luxdefi/subnet-evm/precompile/allowlist"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/feemanager"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/luxdefi/subnet-evm/precompile/precompileconfig"
+)
+
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return "unknown"
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+const (
+	// minimum gas needed by a plain transfer
+	minGasLimit = 21_000
+	// above this, blocks take long enough to build and verify to
+	// put the target block rate at risk on regular hardware
+	maxSaneGasLimit = 100_000_000
+)
+
+// Files holds the raw content of the configuration files of a subnet.
+// A nil entry means the file does not exist.
+type Files struct {
+	Genesis      []byte
+	ChainConfig  []byte
+	SubnetConfig []byte
+	NodeConfig   []byte
+	Upgrades     []byte
+}
+
+type Finding struct {
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Check    string   `json:"check"`
+	Message  string   `json:"message"`
+}
+
+type Report struct {
+	Subnet   string    `json:"subnet"`
+	Findings []Finding `json:"findings"`
+}
+
+func (r *Report) add(severity Severity, file string, check string, format string, args ...any) {
+	r.Findings = append(r.Findings, Finding{
+		Severity: severity,
+		File:     file,
+		Check:    check,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *Report) HasErrors() bool {
+	return r.Count(Error) > 0
+}
+
+// sort puts the most severe findings first, keeping the check order otherwise
+func (r *Report) sort() {
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		return r.Findings[i].Severity > r.Findings[j].Severity
+	})
+}
+
+// LoadFiles reads all the configuration files stored for [subnetName]
+func LoadFiles(app *application.Lux, subnetName string) (Files, error) {
+	var (
+		files Files
+		err   error
+	)
+	if files.Genesis, err = app.LoadRawGenesis(subnetName); err != nil {
+		return files, err
+	}
+	if app.ChainConfigExists(subnetName) {
+		if files.ChainConfig, err = app.LoadRawChainConfig(subnetName); err != nil {
+			return files, err
+		}
+	}
+	if app.LuxdSubnetConfigExists(subnetName) {
+		if files.SubnetConfig, err = app.LoadRawLuxdSubnetConfig(subnetName); err != nil {
+			return files, err
+		}
+	}
+	if app.LuxdNodeConfigExists(subnetName) {
+		if files.NodeConfig, err = app.LoadRawLuxdNodeConfig(subnetName); err != nil {
+			return files, err
+		}
+	}
+	if app.NetworkUpgradeExists(subnetName) {
+		if files.Upgrades, err = app.LoadRawNetworkUpgrades(subnetName); err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
+
+// Subnet lints the configuration of [subnetName], as it would be deployed to [network].
+// [network] may be models.UndefinedNetwork if the target is not known yet.
+func Subnet(app *application.Lux, subnetName string, network models.Network) (*Report, error) {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := LoadFiles(app, subnetName)
+	if err != nil {
+		return nil, err
+	}
+	otherChainIDs := map[string]*big.Int{}
+	if sc.VM == models.SubnetEvm {
+		sidecarNames, err := app.GetSidecarNames()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range sidecarNames {
+			if name == subnetName {
+				continue
+			}
+			otherSc, err := app.LoadSidecar(name)
+			if err != nil || otherSc.VM != models.SubnetEvm {
+				continue
+			}
+			// a broken genesis of another subnet is reported when linting that subnet
+			otherGenesis, err := app.LoadEvmGenesis(name)
+			if err != nil || otherGenesis.Config == nil || otherGenesis.Config.ChainID == nil {
+				continue
+			}
+			otherChainIDs[name] = otherGenesis.Config.ChainID
+		}
+	}
+	return Check(subnetName, sc.VM, files, network, otherChainIDs), nil
+}
+
+// Check runs all checks over [files]. [otherChainIDs] maps the names of the other
+// Subnet-EVM subnets to their chain IDs.
+func Check(
+	subnetName string,
+	vmType models.VMType,
+	files Files,
+	network models.Network,
+	otherChainIDs map[string]*big.Int,
+) *Report {
+	r := &Report{Subnet: subnetName}
+	checkJSON(r, files)
+	if vmType == models.SubnetEvm {
+		checkEvm(r, files, network, otherChainIDs)
+	}
+	r.sort()
+	return r
+}
+
+// checkJSON validates the node side config files. The genesis format
+// depends on the VM, so it is only parsed by the VM specific checks.
+func checkJSON(r *Report, files Files) {
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{constants.ChainConfigFileName, files.ChainConfig},
+		{constants.SubnetConfigFileName, files.SubnetConfig},
+		{constants.NodeConfigFileName, files.NodeConfig},
+	} {
+		if file.content != nil && !json.Valid(file.content) {
+			r.add(Error, file.name, "json", "file is not valid JSON")
+		}
+	}
+}
+
+func checkEvm(r *Report, files Files, network models.Network, otherChainIDs map[string]*big.Int) {
+	var genesis core.Genesis
+	if err := json.Unmarshal(files.Genesis, &genesis); err != nil {
+		r.add(Error, constants.GenesisFileName, "genesis", "failed to parse Subnet-EVM genesis: %s", err)
+		return
+	}
+	if genesis.Config == nil {
+		r.add(Error, constants.GenesisFileName, "genesis", "genesis has no chain config")
+		return
+	}
+
+	var upgrades []params.PrecompileUpgrade
+	if files.Upgrades != nil {
+		var upgradeConfig params.UpgradeConfig
+		if err := json.Unmarshal(files.Upgrades, &upgradeConfig); err != nil {
+			r.add(Error, constants.UpgradeBytesFileName, "json", "failed to parse upgrades: %s", err)
+		} else {
+			upgrades = upgradeConfig.PrecompileUpgrades
+		}
+	}
+
+	checkPrecompileUpgrades(r, genesis.Config, upgrades)
+	checkAllowListFunding(r, genesis, upgrades)
+	checkGasLimit(r, genesis)
+	checkEwoqAllocation(r, genesis.Alloc, network)
+	checkChainID(r, genesis.Config.ChainID, otherChainIDs)
+	checkFeeRecipient(r, genesis.Config, files.ChainConfig)
+}
+
+// checkPrecompileUpgrades verifies that upgrades are sorted by timestamp, and that
+// they alternate enabling and disabling each precompile, taking into account the
+// precompiles enabled at genesis
+func checkPrecompileUpgrades(r *Report, conf *params.ChainConfig, upgrades []params.PrecompileUpgrade) {
+	const check = "precompile-upgrades"
+	type precompileState struct {
+		enabled   bool
+		timestamp uint64
+	}
+	state := map[string]precompileState{}
+	for key := range conf.GenesisPrecompiles {
+		state[key] = precompileState{enabled: true}
+	}
+
+	var lastTimestamp uint64
+	for i, upgrade := range upgrades {
+		if upgrade.Config == nil {
+			r.add(Error, constants.UpgradeBytesFileName, check, "upgrade %d has no precompile config", i)
+			continue
+		}
+		key := upgrade.Key()
+		ts := upgrade.Timestamp()
+		if ts == nil {
+			r.add(Error, constants.UpgradeBytesFileName, check, "upgrade %d (%s) has no blockTimestamp", i, key)
+			continue
+		}
+		if *ts == 0 {
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d (%s) has blockTimestamp 0, precompiles active from genesis must be set in the genesis", i, key)
+			continue
+		}
+		if *ts < lastTimestamp {
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d (%s) at %d is before the previous upgrade at %d, upgrades must be ordered by blockTimestamp", i, key, *ts, lastTimestamp)
+		}
+		lastTimestamp = *ts
+		if time.Unix(int64(*ts), 0).Before(time.Now()) {
+			r.add(Warning, constants.UpgradeBytesFileName, check,
+				"upgrade %d (%s) at %d is in the past, it must already be applied on every node of the chain", i, key, *ts)
+		}
+
+		current := state[key]
+		switch {
+		case current.enabled && current.timestamp == 0 && !upgrade.IsDisabled():
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d enables %s, which is already enabled in the genesis. Disable it first", i, key)
+		case current.enabled && !upgrade.IsDisabled():
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d enables %s, which is already enabled by the upgrade at %d. Disable it first", i, key, current.timestamp)
+		case !current.enabled && upgrade.IsDisabled():
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d disables %s, which is not enabled at that time", i, key)
+		case current.timestamp != 0 && *ts <= current.timestamp:
+			r.add(Error, constants.UpgradeBytesFileName, check,
+				"upgrade %d of %s at %d collides with its previous upgrade at %d", i, key, *ts, current.timestamp)
+		}
+		state[key] = precompileState{enabled: !upgrade.IsDisabled(), timestamp: *ts}
+	}
+}
+
+// checkAllowListFunding ensures that the allow list admins can pay for the
+// transactions needed to manage the precompiles
+func checkAllowListFunding(r *Report, genesis core.Genesis, upgrades []params.PrecompileUpgrade) {
+	const check = "allowlist-funding"
+	for key, precompile := range genesis.Config.GenesisPrecompiles {
+		allowList := getAllowListConfig(precompile)
+		if allowList == nil || anyFunded(genesis.Alloc, allowList.AdminAddresses) {
+			continue
+		}
+		if key == txallowlist.ConfigKey {
+			// without a funded admin, nobody is ever able to transact
+			if !anyFunded(genesis.Alloc, allowList.EnabledAddresses) {
+				r.add(Error, constants.GenesisFileName, check,
+					"none of the addresses in the %s allow list have tokens allocated. No address can transact on the network", key)
+			} else {
+				r.add(Warning, constants.GenesisFileName, check,
+					"none of the %s admins have tokens allocated, so they can't manage the allow list", key)
+			}
+			continue
+		}
+		r.add(Warning, constants.GenesisFileName, check,
+			"none of the %s admins have tokens allocated, so they can't use the precompile", key)
+	}
+	for i, upgrade := range upgrades {
+		if upgrade.Config == nil || upgrade.IsDisabled() {
+			continue
+		}
+		allowList := getAllowListConfig(upgrade.Config)
+		if allowList == nil || anyFunded(genesis.Alloc, allowList.AdminAddresses) {
+			continue
+		}
+		r.add(Warning, constants.UpgradeBytesFileName, check,
+			"none of the admins of upgrade %d (%s) have tokens allocated in the genesis. Make sure they are funded before the upgrade activates", i, upgrade.Key())
+	}
+}
+
+func getAllowListConfig(config precompileconfig.Config) *allowlist.AllowListConfig {
+	switch c := config.(type) {
+	case *txallowlist.Config:
+		return &c.AllowListConfig
+	case *deployerallowlist.Config:
+		return &c.AllowListConfig
+	case *nativeminter.Config:
+		return &c.AllowListConfig
+	case *feemanager.Config:
+		return &c.AllowListConfig
+	case *rewardmanager.Config:
+		return &c.AllowListConfig
+	}
+	return nil
+}
+
+func anyFunded(alloc core.GenesisAlloc, addrs []common.Address) bool {
+	for _, addr := range addrs {
+		if account, ok := alloc[addr]; ok && account.Balance != nil && account.Balance.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func checkGasLimit(r *Report, genesis core.Genesis) {
+	const check = "gas-limit"
+	feeConfig := genesis.Config.FeeConfig
+	if err := feeConfig.Verify(); err != nil {
+		r.add(Error, constants.GenesisFileName, check, "invalid fee config: %s", err)
+		return
+	}
+	gasLimit := feeConfig.GasLimit.Uint64()
+	if genesis.GasLimit != gasLimit {
+		r.add(Error, constants.GenesisFileName, check,
+			"genesis gasLimit %d does not match the fee config gasLimit %d", genesis.GasLimit, gasLimit)
+	}
+	if gasLimit < minGasLimit {
+		r.add(Error, constants.GenesisFileName, check,
+			"gasLimit %d is not enough for a single transfer (%d)", gasLimit, minGasLimit)
+	}
+	if gasLimit > maxSaneGasLimit {
+		r.add(Warning, constants.GenesisFileName, check,
+			"gasLimit %d is above %d, blocks may be too slow to build and verify", gasLimit, maxSaneGasLimit)
+	}
+	if feeConfig.TargetGas.Cmp(feeConfig.GasLimit) < 0 {
+		r.add(Warning, constants.GenesisFileName, check,
+			"targetGas %s is lower than the gasLimit of a single block %d, fees rise as soon as a block is full", feeConfig.TargetGas, gasLimit)
+	}
+	if feeConfig.MinBlockGasCost.Cmp(feeConfig.MaxBlockGasCost) > 0 {
+		r.add(Error, constants.GenesisFileName, check,
+			"minBlockGasCost %s is greater than maxBlockGasCost %s", feeConfig.MinBlockGasCost, feeConfig.MaxBlockGasCost)
+	}
+}
+
+func checkEwoqAllocation(r *Report, alloc core.GenesisAlloc, network models.Network) {
+	const check = "ewoq-alloc"
+	if _, ok := alloc[vm.PrefundedEwoqAddress]; !ok {
+		return
+	}
+	switch network.Kind {
+	case models.Fuji, models.Mainnet:
+		r.add(Error, constants.GenesisFileName, check,
+			"the well known ewoq address %s is funded, anybody can spend its tokens on %s", vm.PrefundedEwoqAddress.Hex(), network.Kind)
+	case models.Undefined:
+		r.add(Warning, constants.GenesisFileName, check,
+			"the well known ewoq address %s is funded, do not deploy this genesis to a public network", vm.PrefundedEwoqAddress.Hex())
+	default:
+		r.add(Info, constants.GenesisFileName, check,
+			"the well known ewoq address %s is funded, which is only fine for test networks", vm.PrefundedEwoqAddress.Hex())
+	}
+}
+
+func checkChainID(r *Report, chainID *big.Int, otherChainIDs map[string]*big.Int) {
+	const check = "chain-id"
+	if chainID == nil || chainID.Sign() <= 0 {
+		r.add(Error, constants.GenesisFileName, check, "chainId must be a positive integer")
+		return
+	}
+	names := make([]string, 0, len(otherChainIDs))
+	for name := range otherChainIDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if otherChainIDs[name].Cmp(chainID) == 0 {
+			r.add(Error, constants.GenesisFileName, check, "chainId %s is also used by subnet %s", chainID, name)
+		}
+	}
+}
+
+// checkFeeRecipient warns when the chain config sets a fee recipient that the
+// genesis does not allow to receive fees
+func checkFeeRecipient(r *Report, conf *params.ChainConfig, chainConfig []byte) {
+	if chainConfig == nil {
+		return
+	}
+	var cfg struct {
+		FeeRecipient string `json:"feeRecipient"`
+	}
+	if err := json.Unmarshal(chainConfig, &cfg); err != nil || cfg.FeeRecipient == "" {
+		return
+	}
+	if !common.IsHexAddress(cfg.FeeRecipient) {
+		r.add(Error, constants.ChainConfigFileName, "fee-recipient", "feeRecipient %q is not a valid address", cfg.FeeRecipient)
+		return
+	}
+	if conf.AllowFeeRecipients {
+		return
+	}
+	if rewardConfig, ok := conf.GenesisPrecompiles[rewardmanager.ConfigKey].(*rewardmanager.Config); ok &&
+		rewardConfig.InitialRewardConfig != nil && rewardConfig.InitialRewardConfig.AllowFeeRecipients {
+		return
+	}
+	r.add(Warning, constants.ChainConfigFileName, "fee-recipient",
+		"feeRecipient is set, but the genesis does not allow fee recipients, so fees are not sent to it")
+}
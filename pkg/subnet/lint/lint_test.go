@@ -0,0 +1,135 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package lint
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/internal/testutils"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/vm"
+	"github.com/luxdefi/subnet-evm/core"
+	"github.com/luxdefi/subnet-evm/params"
+	"github.com/luxdefi/subnet-evm/precompile/allowlist"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/luxdefi/subnet-evm/precompile/precompileconfig"
+	"github.com/luxdefi/subnet-evm/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGenesis(chainID int64, alloc core.GenesisAlloc) core.Genesis {
+	conf := *params.SubnetEVMDefaultChainConfig
+	conf.GenesisPrecompiles = params.Precompiles{}
+	conf.ChainID = big.NewInt(chainID)
+	conf.FeeConfig = vm.StarterFeeConfig
+	return core.Genesis{
+		Config:     &conf,
+		Alloc:      alloc,
+		Difficulty: vm.Difficulty,
+		GasLimit:   conf.FeeConfig.GasLimit.Uint64(),
+	}
+}
+
+func checkGenesis(t *testing.T, genesis core.Genesis, network models.Network, otherChainIDs map[string]*big.Int) *Report {
+	genesisBytes, err := json.Marshal(genesis)
+	require.NoError(t, err)
+	return Check("testSubnet", models.SubnetEvm, Files{Genesis: genesisBytes}, network, otherChainIDs)
+}
+
+func findChecks(r *Report, severity Severity) []string {
+	checks := []string{}
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			checks = append(checks, f.Check)
+		}
+	}
+	return checks
+}
+
+func TestCleanGenesis(t *testing.T) {
+	addrs, err := testutils.GenerateEthAddrs(1)
+	require.NoError(t, err)
+	alloc := core.GenesisAlloc{addrs[0]: {Balance: big.NewInt(42)}}
+
+	r := checkGenesis(t, newTestGenesis(1234, alloc), models.MainnetNetwork, map[string]*big.Int{"other": big.NewInt(1)})
+	require.Empty(t, r.Findings)
+	require.False(t, r.HasErrors())
+}
+
+func TestEwoqAllocation(t *testing.T) {
+	alloc := core.GenesisAlloc{vm.PrefundedEwoqAddress: {Balance: big.NewInt(42)}}
+	genesis := newTestGenesis(1234, alloc)
+
+	r := checkGenesis(t, genesis, models.FujiNetwork, nil)
+	require.Equal(t, []string{"ewoq-alloc"}, findChecks(r, Error))
+
+	r = checkGenesis(t, genesis, models.UndefinedNetwork, nil)
+	require.False(t, r.HasErrors())
+	require.Equal(t, []string{"ewoq-alloc"}, findChecks(r, Warning))
+
+	r = checkGenesis(t, genesis, models.LocalNetwork, nil)
+	require.False(t, r.HasErrors())
+	require.Equal(t, []string{"ewoq-alloc"}, findChecks(r, Info))
+}
+
+func TestDuplicatedChainID(t *testing.T) {
+	r := checkGenesis(t, newTestGenesis(1234, core.GenesisAlloc{}), models.LocalNetwork, map[string]*big.Int{
+		"other":     big.NewInt(1234),
+		"different": big.NewInt(4321),
+	})
+	require.Equal(t, []string{"chain-id"}, findChecks(r, Error))
+	require.Contains(t, r.Findings[0].Message, "other")
+}
+
+func TestGasLimit(t *testing.T) {
+	genesis := newTestGenesis(1234, core.GenesisAlloc{})
+	genesis.GasLimit = 1_000_000
+	r := checkGenesis(t, genesis, models.LocalNetwork, nil)
+	require.Equal(t, []string{"gas-limit"}, findChecks(r, Error))
+
+	genesis = newTestGenesis(1234, core.GenesisAlloc{})
+	genesis.Config.FeeConfig.TargetGas = big.NewInt(1_000_000)
+	r = checkGenesis(t, genesis, models.LocalNetwork, nil)
+	require.False(t, r.HasErrors())
+	require.Equal(t, []string{"gas-limit"}, findChecks(r, Warning))
+}
+
+func TestTxAllowListFunding(t *testing.T) {
+	addrs, err := testutils.GenerateEthAddrs(2)
+	require.NoError(t, err)
+	admin, other := addrs[0], addrs[1]
+
+	genesis := newTestGenesis(1234, core.GenesisAlloc{other: {Balance: big.NewInt(42)}})
+	genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey] = &txallowlist.Config{
+		AllowListConfig: allowlist.AllowListConfig{AdminAddresses: []common.Address{admin}},
+		Upgrade:         precompileconfig.Upgrade{BlockTimestamp: utils.NewUint64(0)},
+	}
+	r := checkGenesis(t, genesis, models.LocalNetwork, nil)
+	require.Equal(t, []string{"allowlist-funding"}, findChecks(r, Error))
+
+	genesis.Alloc[admin] = core.GenesisAccount{Balance: big.NewInt(42)}
+	r = checkGenesis(t, genesis, models.LocalNetwork, nil)
+	require.Empty(t, r.Findings)
+}
+
+func TestFindingsSortedBySeverity(t *testing.T) {
+	alloc := core.GenesisAlloc{vm.PrefundedEwoqAddress: {Balance: big.NewInt(42)}}
+	genesis := newTestGenesis(1234, alloc)
+	genesis.Config.FeeConfig.TargetGas = big.NewInt(1_000_000)
+	r := checkGenesis(t, genesis, models.MainnetNetwork, nil)
+	require.Len(t, r.Findings, 2)
+	require.Equal(t, Error, r.Findings[0].Severity)
+	require.Equal(t, Warning, r.Findings[1].Severity)
+}
+
+func TestInvalidJSON(t *testing.T) {
+	r := Check("testSubnet", models.CustomVM, Files{
+		Genesis:     []byte("anything goes for custom VMs"),
+		ChainConfig: []byte("{"),
+	}, models.LocalNetwork, nil)
+	require.Equal(t, []string{"json"}, findChecks(r, Error))
+	require.Equal(t, "chain.json", r.Findings[0].File)
+}
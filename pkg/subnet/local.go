@@ -5,7 +5,6 @@ package subnet
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -57,6 +56,10 @@ type LocalDeployer struct {
 	setDefaultSnapshot setDefaultSnapshotFunc
 	luxdVersion       string
 	vmBin              string
+	// nodes chosen to validate the deployed subnet, all of them if empty
+	validators []LocalValidator
+	// subnet to deploy the blockchain into, if not empty
+	subnetID ids.ID
 }
 
 func NewLocalDeployer(app *application.Lux, luxdVersion string, vmBin string) *LocalDeployer {
@@ -418,21 +421,6 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 		return ids.Empty, ids.Empty, nil
 	}
 
-	numBlockchains := len(clusterInfo.CustomChains)
-
-	subnetIDs := maps.Keys(clusterInfo.Subnets)
-
-	// in order to make subnet deploy faster, a set of validated subnet IDs is preloaded
-	// in the bootstrap snapshot
-	// we select one to be used for creating the next blockchain, for that we use the
-	// number of currently created blockchains as the index to select the next subnet ID,
-	// so we get incremental selection
-	sort.Strings(subnetIDs)
-	if len(subnetIDs) == 0 {
-		return ids.Empty, ids.Empty, errors.New("the network has not preloaded subnet IDs")
-	}
-	subnetIDStr := subnetIDs[numBlockchains%len(subnetIDs)]
-
 	// if a chainConfig has been configured
 	var (
 		chainConfig            string
@@ -463,19 +451,47 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 
 	// create a new blockchain on the already started network, associated to
 	// the given VM ID, genesis, and available subnet ID
-	blockchainSpecs := []*rpcpb.BlockchainSpec{
-		{
-			VmName:   chain,
-			Genesis:  genesisPath,
-			SubnetId: &subnetIDStr,
-			SubnetSpec: &rpcpb.SubnetSpec{
-				SubnetConfig: subnetConfig,
-			},
-			ChainConfig:        chainConfig,
-			BlockchainAlias:    chain,
-			PerNodeChainConfig: perNodeChainConfig,
+	blockchainSpec := &rpcpb.BlockchainSpec{
+		VmName:  chain,
+		Genesis: genesisPath,
+		SubnetSpec: &rpcpb.SubnetSpec{
+			SubnetConfig: subnetConfig,
 		},
+		ChainConfig:        chainConfig,
+		BlockchainAlias:    chain,
+		PerNodeChainConfig: perNodeChainConfig,
 	}
+
+	subnetIDs := maps.Keys(clusterInfo.Subnets)
+	var subnetIDStr string
+	switch {
+	case d.subnetID != ids.Empty:
+		if len(d.validators) > 0 {
+			return ids.Empty, ids.Empty, fmt.Errorf("validators can't be chosen for a blockchain deployed into subnet %s", d.subnetID)
+		}
+		subnetIDStr = d.subnetID.String()
+		blockchainSpec.SubnetId = &subnetIDStr
+	case len(d.validators) > 0:
+		// a new subnet is created, validated only by the chosen nodes
+		blockchainSpec.SubnetSpec, err = d.validatorsSubnetSpec(clusterInfo, subnetConfig)
+		if err != nil {
+			return ids.Empty, ids.Empty, err
+		}
+	case len(subnetIDs) == 0:
+		// networks not started from the bootstrap snapshot have no preloaded subnets,
+		// so a new subnet is created, validated by all nodes
+	default:
+		// in order to make subnet deploy faster, a set of validated subnet IDs is preloaded
+		// in the bootstrap snapshot
+		// we select one to be used for creating the next blockchain, for that we use the
+		// number of currently created blockchains as the index to select the next subnet ID,
+		// so we get incremental selection
+		numBlockchains := len(clusterInfo.CustomChains)
+		sort.Strings(subnetIDs)
+		subnetIDStr = subnetIDs[numBlockchains%len(subnetIDs)]
+		blockchainSpec.SubnetId = &subnetIDStr
+	}
+	blockchainSpecs := []*rpcpb.BlockchainSpec{blockchainSpec}
 	deployBlockchainsInfo, err := cli.CreateBlockchains(
 		ctx,
 		blockchainSpecs,
@@ -525,8 +541,15 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 	for _, info := range clusterInfo.CustomChains {
 		if info.VmId == chainVMID.String() {
 			blockchainID, _ = ids.FromString(info.ChainId)
+			if subnetIDStr == "" {
+				// new subnet created by the network runner
+				subnetID, _ = ids.FromString(info.SubnetId)
+			}
 		}
 	}
+	if err := d.setValidatorWeights(clusterInfo, subnetID); err != nil {
+		return ids.Empty, ids.Empty, fmt.Errorf("blockchain was deployed, but failed to set validator weights: %w", err)
+	}
 	return subnetID, blockchainID, nil
 }
 
@@ -727,7 +750,7 @@ func SetDefaultSnapshot(snapshotsDir string, force bool, isSingleNode bool) erro
 			return fmt.Errorf("failed writing down bootstrap snapshot: %w", err)
 		}
 	}
-	defaultSnapshotPath := filepath.Join(snapshotsDir, "anr-snapshot-"+constants.DefaultSnapshotName)
+	defaultSnapshotPath := GetSnapshotPath(snapshotsDir, constants.DefaultSnapshotName)
 	if force {
 		if err := os.RemoveAll(defaultSnapshotPath); err != nil {
 			return fmt.Errorf("failed removing default snapshot: %w", err)
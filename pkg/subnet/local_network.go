@@ -0,0 +1,366 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/utils"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/netrunner/client"
+	"github.com/luxdefi/netrunner/rpcpb"
+	"github.com/luxdefi/node/genesis"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+	"github.com/luxdefi/node/wallet/subnet/primary/common"
+)
+
+const (
+	// weight given by the network runner to the subnet validators it adds
+	DefaultLocalValidatorWeight = 1000
+
+	// time from now for a re-added validator to start validating
+	localValidatorStartDelay = 20 * time.Second
+	localValidatorPollPeriod = time.Second
+	localValidatorTimeout    = 2 * time.Minute
+)
+
+// LocalValidator is a local network node chosen to validate a locally deployed subnet
+type LocalValidator struct {
+	NodeName string
+	Weight   uint64
+}
+
+// ParseLocalValidators parses validators given by the user as either
+// <nodeName> or <nodeName>=<weight>
+func ParseLocalValidators(validatorStrs []string) ([]LocalValidator, error) {
+	validators := []LocalValidator{}
+	seen := set.Set[string]{}
+	for _, validatorStr := range validatorStrs {
+		nodeName, weightStr, found := strings.Cut(validatorStr, "=")
+		nodeName = strings.TrimSpace(nodeName)
+		if nodeName == "" {
+			return nil, fmt.Errorf("invalid local validator %q, expected <nodeName> or <nodeName>=<weight>", validatorStr)
+		}
+		if seen.Contains(nodeName) {
+			return nil, fmt.Errorf("local validator %s given more than once", nodeName)
+		}
+		seen.Add(nodeName)
+		weight := uint64(DefaultLocalValidatorWeight)
+		if found {
+			var err error
+			weight, err = strconv.ParseUint(strings.TrimSpace(weightStr), 10, 64)
+			if err != nil || weight == 0 {
+				return nil, fmt.Errorf("invalid weight %q for local validator %s", weightStr, nodeName)
+			}
+		}
+		validators = append(validators, LocalValidator{NodeName: nodeName, Weight: weight})
+	}
+	return validators, nil
+}
+
+// LoadNodeConfigFile loads a JSON file with luxd flags, eg
+// {"log-level": "debug", "staking-enabled": true}
+func LoadNodeConfigFile(configPath string) (map[string]interface{}, error) {
+	configBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, fmt.Errorf("invalid node config file %s: %w", configPath, err)
+	}
+	return config, nil
+}
+
+// MergeNodeConfigs adds the flags in [override] to the JSON node config [base],
+// replacing the ones already set
+func MergeNodeConfigs(base string, override map[string]interface{}) (string, error) {
+	config := map[string]interface{}{}
+	if base != "" {
+		if err := json.Unmarshal([]byte(base), &config); err != nil {
+			return "", err
+		}
+	}
+	for k, v := range override {
+		config[k] = v
+	}
+	if len(config) == 0 {
+		return "", nil
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(configBytes), nil
+}
+
+// LoadPerNodeConfigFile loads a JSON file mapping node names to the luxd flags
+// only applied to that node, eg {"node3": {"log-level": "debug"}}.
+// Node names go from node1 to node<numNodes>.
+func LoadPerNodeConfigFile(configPath string, numNodes uint32) (map[string]string, error) {
+	configBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	perNodeConfig := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(configBytes, &perNodeConfig); err != nil {
+		return nil, fmt.Errorf("invalid per node config file %s: %w", configPath, err)
+	}
+	validNames := set.Set[string]{}
+	for i := uint32(1); i <= numNodes; i++ {
+		validNames.Add(fmt.Sprintf("node%d", i))
+	}
+	customNodeConfigs := map[string]string{}
+	for nodeName, config := range perNodeConfig {
+		if !validNames.Contains(nodeName) {
+			return nil, fmt.Errorf("unknown node %s in per node config, expected node1 to node%d", nodeName, numNodes)
+		}
+		configBytes, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		customNodeConfigs[nodeName] = string(configBytes)
+	}
+	return customNodeConfigs, nil
+}
+
+// StartNewNetwork starts a fresh local network with [numNodes] nodes, instead of
+// loading a snapshot. [nodeConfig] is applied to all nodes, and [customNodeConfigs]
+// to each named node.
+func (d *LocalDeployer) StartNewNetwork(
+	ctx context.Context,
+	cli client.Client,
+	luxdBinPath string,
+	runDir string,
+	numNodes uint32,
+	nodeConfig string,
+	customNodeConfigs map[string]string,
+) (*rpcpb.ClusterInfo, error) {
+	startOpts := []client.OpOption{
+		client.WithNumNodes(numNodes),
+		client.WithRootDataDir(runDir),
+		client.WithReassignPortsIfUsed(true),
+		client.WithPluginDir(d.app.GetPluginsDir()),
+	}
+	if nodeConfig != "" {
+		startOpts = append(startOpts, client.WithGlobalNodeConfig(nodeConfig))
+	}
+	if len(customNodeConfigs) > 0 {
+		startOpts = append(startOpts, client.WithCustomNodeConfigs(customNodeConfigs))
+	}
+
+	ux.Logger.PrintToUser("Booting Network with %d nodes. Wait until healthy...", numNodes)
+	if _, err := cli.Start(ctx, luxdBinPath, startOpts...); err != nil {
+		return nil, fmt.Errorf("failed to start network: %w", err)
+	}
+	clusterInfo, err := WaitForHealthy(ctx, cli)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network health: %w", err)
+	}
+	ux.Logger.PrintToUser("Node logs directory: %s/node<i>/logs", clusterInfo.RootDataDir)
+	ux.Logger.PrintToUser("Network ready to use.")
+	return clusterInfo, nil
+}
+
+// SetValidators chooses the local nodes that are going to validate the deployed
+// subnet, and their weights. By default all nodes are validators.
+func (d *LocalDeployer) SetValidators(validators []LocalValidator) {
+	d.validators = validators
+}
+
+// SetSubnetID makes the blockchain to be deployed into an already deployed
+// subnet, instead of a new or preloaded one
+func (d *LocalDeployer) SetSubnetID(subnetID ids.ID) {
+	d.subnetID = subnetID
+}
+
+// returns the subnet spec for a new subnet validated by the chosen nodes
+func (d *LocalDeployer) validatorsSubnetSpec(clusterInfo *rpcpb.ClusterInfo, subnetConfig string) (*rpcpb.SubnetSpec, error) {
+	participants := []string{}
+	for _, validator := range d.validators {
+		if _, ok := clusterInfo.NodeInfos[validator.NodeName]; !ok {
+			return nil, fmt.Errorf("node %s is not part of the local network, available nodes: %s",
+				validator.NodeName, strings.Join(clusterInfo.NodeNames, ", "))
+		}
+		participants = append(participants, validator.NodeName)
+	}
+	return &rpcpb.SubnetSpec{
+		Participants: participants,
+		SubnetConfig: subnetConfig,
+	}, nil
+}
+
+// a subnet validator to be added back with a weight other than the default one
+type reweightedValidator struct {
+	LocalValidator
+	nodeID  ids.NodeID
+	endTime uint64
+}
+
+// the network runner adds subnet validators with a fixed weight. Validators
+// with a different weight are removed and added back with the requested one.
+func (d *LocalDeployer) setValidatorWeights(clusterInfo *rpcpb.ClusterInfo, subnetID ids.ID) error {
+	toUpdate := []LocalValidator{}
+	for _, validator := range d.validators {
+		if validator.Weight != DefaultLocalValidatorWeight {
+			toUpdate = append(toUpdate, validator)
+		}
+	}
+	if len(toUpdate) == 0 {
+		return nil
+	}
+	if len(d.validators) == 1 {
+		ux.Logger.PrintToUser("Keeping the default weight for validator %s, as changing it would leave the subnet without validators",
+			toUpdate[0].NodeName)
+		return nil
+	}
+
+	ctx := context.Background()
+	api := constants.LocalAPIEndpoint
+	wallet, err := primary.MakeWallet(
+		ctx,
+		&primary.WalletConfig{
+			URI:              api,
+			LUXKeychain:      secp256k1fx.NewKeychain(genesis.EWOQKey),
+			EthKeychain:      secp256k1fx.NewKeychain(),
+			PChainTxsToFetch: set.Of(subnetID),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	pClient := platformvm.NewClient(api)
+
+	validators := []reweightedValidator{}
+	for _, validator := range toUpdate {
+		nodeID, err := ids.NodeIDFromString(clusterInfo.NodeInfos[validator.NodeName].Id)
+		if err != nil {
+			return err
+		}
+		// subnet validation can't last longer than the primary network one
+		apiCtx, cancel := utils.GetAPIContext()
+		primaryValidators, err := pClient.GetCurrentValidators(apiCtx, ids.Empty, []ids.NodeID{nodeID})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if len(primaryValidators) == 0 {
+			return fmt.Errorf("node %s is not a primary network validator", validator.NodeName)
+		}
+		validators = append(validators, reweightedValidator{
+			LocalValidator: validator,
+			nodeID:         nodeID,
+			endTime:        primaryValidators[0].EndTime,
+		})
+	}
+
+	removeValidator := func(validator reweightedValidator) error {
+		txCtx, cancel := context.WithTimeout(ctx, constants.DefaultConfirmTxTimeout)
+		defer cancel()
+		_, err := wallet.P().IssueRemoveSubnetValidatorTx(validator.nodeID, subnetID, common.WithContext(txCtx))
+		return err
+	}
+	addValidator := func(validator reweightedValidator) error {
+		txCtx, cancel := context.WithTimeout(ctx, constants.DefaultConfirmTxTimeout)
+		defer cancel()
+		_, err := wallet.P().IssueAddSubnetValidatorTx(
+			&txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: validator.nodeID,
+					Start:  uint64(time.Now().Add(localValidatorStartDelay).Unix()),
+					End:    validator.endTime,
+					Wght:   validator.Weight,
+				},
+				Subnet: subnetID,
+			},
+			common.WithContext(txCtx),
+		)
+		return err
+	}
+	for _, round := range getReweightRounds(validators, len(d.validators)) {
+		if err := reweightValidators(round, removeValidator, addValidator); err != nil {
+			return err
+		}
+		nodeIDs := []ids.NodeID{}
+		for _, validator := range round {
+			nodeIDs = append(nodeIDs, validator.nodeID)
+		}
+		if err := waitForCurrentValidators(ctx, pClient, subnetID, nodeIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splits [validators] into rounds so that the subnet, validated by [numValidators]
+// nodes, keeps at least one current validator while they are reweighted. When every
+// validator of the subnet is reweighted, the last one is left for a second round,
+// started once the others are validating again
+func getReweightRounds(validators []reweightedValidator, numValidators int) [][]reweightedValidator {
+	if len(validators) < numValidators || len(validators) < 2 {
+		return [][]reweightedValidator{validators}
+	}
+	last := len(validators) - 1
+	return [][]reweightedValidator{validators[:last], validators[last:]}
+}
+
+// removes [validators] from the subnet and then adds them back with their weight.
+// Validators removed before a failed removal are still added back. On a failed
+// addition, the error tells which nodes were left out of the subnet
+func reweightValidators(
+	validators []reweightedValidator,
+	removeValidator func(reweightedValidator) error,
+	addValidator func(reweightedValidator) error,
+) error {
+	var removeErr error
+	removed := []reweightedValidator{}
+	for _, validator := range validators {
+		if err := removeValidator(validator); err != nil {
+			removeErr = fmt.Errorf("failed to remove validator %s: %w", validator.NodeName, err)
+			break
+		}
+		removed = append(removed, validator)
+	}
+	for i, validator := range removed {
+		ux.Logger.PrintToUser("Setting weight %d for validator %s", validator.Weight, validator.NodeName)
+		if err := addValidator(validator); err != nil {
+			leftOut := []string{}
+			for _, validator := range removed[i:] {
+				leftOut = append(leftOut, validator.NodeName)
+			}
+			return fmt.Errorf("failed to add validator %s back, nodes %s were left out of the subnet: %w",
+				validator.NodeName, strings.Join(leftOut, ", "), err)
+		}
+	}
+	return removeErr
+}
+
+// waits until all [nodeIDs] are current validators of [subnetID]
+func waitForCurrentValidators(ctx context.Context, pClient platformvm.Client, subnetID ids.ID, nodeIDs []ids.NodeID) error {
+	ux.Logger.PrintToUser("Waiting for validators to start validating...")
+	ctx, cancel := context.WithTimeout(ctx, localValidatorTimeout)
+	defer cancel()
+	for {
+		validators, err := pClient.GetCurrentValidators(ctx, subnetID, nodeIDs)
+		if err == nil && len(validators) == len(nodeIDs) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for subnet validators to start validating")
+		case <-time.After(localValidatorPollPeriod):
+		}
+	}
+}
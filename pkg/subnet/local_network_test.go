@@ -0,0 +1,144 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocalValidators(t *testing.T) {
+	require := require.New(t)
+
+	validators, err := ParseLocalValidators([]string{"node1", "node3=2000", " node4 = 10 "})
+	require.NoError(err)
+	require.Equal([]LocalValidator{
+		{NodeName: "node1", Weight: DefaultLocalValidatorWeight},
+		{NodeName: "node3", Weight: 2000},
+		{NodeName: "node4", Weight: 10},
+	}, validators)
+
+	validators, err = ParseLocalValidators(nil)
+	require.NoError(err)
+	require.Empty(validators)
+
+	for _, invalid := range [][]string{
+		{"=100"},
+		{"node1=abc"},
+		{"node1=0"},
+		{"node1", "node1=20"},
+	} {
+		_, err := ParseLocalValidators(invalid)
+		require.Error(err, invalid)
+	}
+}
+
+func TestMergeNodeConfigs(t *testing.T) {
+	require := require.New(t)
+
+	merged, err := MergeNodeConfigs(`{"log-level":"info","network-id":"local"}`, map[string]interface{}{
+		"log-level":       "debug",
+		"staking-enabled": true,
+	})
+	require.NoError(err)
+	config := map[string]interface{}{}
+	require.NoError(json.Unmarshal([]byte(merged), &config))
+	require.Equal(map[string]interface{}{
+		"log-level":       "debug",
+		"network-id":      "local",
+		"staking-enabled": true,
+	}, config)
+
+	merged, err = MergeNodeConfigs("", nil)
+	require.NoError(err)
+	require.Empty(merged)
+
+	_, err = MergeNodeConfigs("not json", nil)
+	require.Error(err)
+}
+
+func TestLoadPerNodeConfigFile(t *testing.T) {
+	require := require.New(t)
+
+	configPath := filepath.Join(t.TempDir(), "per-node.json")
+	require.NoError(os.WriteFile(configPath, []byte(`{"node2": {"log-level": "debug"}}`), 0o600))
+	customNodeConfigs, err := LoadPerNodeConfigFile(configPath, 3)
+	require.NoError(err)
+	require.Equal(map[string]string{"node2": `{"log-level":"debug"}`}, customNodeConfigs)
+
+	_, err = LoadPerNodeConfigFile(configPath, 1)
+	require.ErrorContains(err, "unknown node node2")
+
+	require.NoError(os.WriteFile(configPath, []byte(`["node1"]`), 0o600))
+	_, err = LoadPerNodeConfigFile(configPath, 3)
+	require.Error(err)
+}
+
+func TestGetReweightRounds(t *testing.T) {
+	require := require.New(t)
+
+	validators := []reweightedValidator{
+		{LocalValidator: LocalValidator{NodeName: "node1", Weight: 10}},
+		{LocalValidator: LocalValidator{NodeName: "node2", Weight: 20}},
+		{LocalValidator: LocalValidator{NodeName: "node3", Weight: 30}},
+	}
+	// node4 keeps validating with the default weight
+	require.Equal([][]reweightedValidator{validators}, getReweightRounds(validators, 4))
+	// node3 keeps validating until the others are added back
+	require.Equal([][]reweightedValidator{validators[:2], validators[2:]}, getReweightRounds(validators, 3))
+}
+
+func TestReweightValidators(t *testing.T) {
+	require := require.New(t)
+
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	validators := []reweightedValidator{
+		{LocalValidator: LocalValidator{NodeName: "node1", Weight: 10}},
+		{LocalValidator: LocalValidator{NodeName: "node2", Weight: 20}},
+		{LocalValidator: LocalValidator{NodeName: "node3", Weight: 30}},
+	}
+	errTx := errors.New("tx failed")
+	steps := []string{}
+	failOn := ""
+	removeValidator := func(validator reweightedValidator) error {
+		if failOn == "remove "+validator.NodeName {
+			return errTx
+		}
+		steps = append(steps, "remove "+validator.NodeName)
+		return nil
+	}
+	addValidator := func(validator reweightedValidator) error {
+		if failOn == "add "+validator.NodeName {
+			return errTx
+		}
+		steps = append(steps, "add "+validator.NodeName)
+		return nil
+	}
+
+	require.NoError(reweightValidators(validators, removeValidator, addValidator))
+	require.Equal([]string{"remove node1", "remove node2", "remove node3", "add node1", "add node2", "add node3"}, steps)
+
+	// the validators already removed are added back
+	steps = []string{}
+	failOn = "remove node2"
+	err := reweightValidators(validators, removeValidator, addValidator)
+	require.ErrorIs(err, errTx)
+	require.ErrorContains(err, "failed to remove validator node2")
+	require.Equal([]string{"remove node1", "add node1"}, steps)
+
+	// the nodes left out of the subnet are reported
+	steps = []string{}
+	failOn = "add node2"
+	err = reweightValidators(validators, removeValidator, addValidator)
+	require.ErrorIs(err, errTx)
+	require.ErrorContains(err, "nodes node2, node3 were left out of the subnet")
+	require.Equal([]string{"remove node1", "remove node2", "remove node3", "add node1"}, steps)
+}
@@ -25,10 +25,12 @@ import (
 	"github.com/luxdefi/node/utils/formatting/address"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/avm"
 	avmtxs "github.com/luxdefi/node/vms/avm/txs"
 	"github.com/luxdefi/node/vms/platformvm"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/chain/p"
 	"github.com/luxdefi/node/wallet/subnet/primary"
 	"github.com/luxdefi/node/wallet/subnet/primary/common"
 )
@@ -40,6 +42,11 @@ type PublicDeployer struct {
 	kc      *keychain.Keychain
 	network models.Network
 	app     *application.Lux
+	// txs are built and signed, but not issued
+	dryRun      bool
+	dryRunState *dryRunState
+	// name of the subnet whose journal records the issued txs, if any
+	journalSubnet string
 }
 
 func NewPublicDeployer(app *application.Lux, kc *keychain.Keychain, network models.Network) *PublicDeployer {
@@ -96,6 +103,14 @@ func (d *PublicDeployer) AddValidator(
 	}
 	isFullySigned := len(remainingSubnetAuthKeys) == 0
 
+	if d.dryRun {
+		// partially signed txs are also part of the plan, with their missing signatures
+		if _, err := d.Commit(tx); err != nil {
+			return false, nil, nil, err
+		}
+		return isFullySigned, tx, remainingSubnetAuthKeys, nil
+	}
+
 	if isFullySigned {
 		id, err := d.Commit(tx)
 		if err != nil {
@@ -152,7 +167,7 @@ func (d *PublicDeployer) CreateAssetTx(
 		return ids.Empty, err
 	}
 
-	ux.Logger.PrintToUser("Create Asset Transaction successful, transaction ID: %s", tx.ID())
+	d.printSuccess("Create Asset Transaction successful, transaction ID: %s", tx.ID())
 	ux.Logger.PrintToUser("Now exporting asset to P-Chain ...")
 	return tx.ID(), err
 }
@@ -178,7 +193,7 @@ func (d *PublicDeployer) ExportToPChainTx(
 	if err != nil {
 		return txID, err
 	}
-	ux.Logger.PrintToUser("Export to P-Chain Transaction successful, transaction ID: %s", txID)
+	d.printSuccess("Export to P-Chain Transaction successful, transaction ID: %s", txID)
 	ux.Logger.PrintToUser("Now importing asset from X-Chain ...")
 	return txID, nil
 }
@@ -200,7 +215,7 @@ func (d *PublicDeployer) ImportFromXChain(
 	if err != nil {
 		return txID, err
 	}
-	ux.Logger.PrintToUser("Import from X Chain Transaction successful, transaction ID: %s", txID)
+	d.printSuccess("Import from X Chain Transaction successful, transaction ID: %s", txID)
 	ux.Logger.PrintToUser("Now transforming subnet into elastic subnet ...")
 	return txID, nil
 }
@@ -233,6 +248,15 @@ func (d *PublicDeployer) TransformSubnetTx(
 	}
 	isFullySigned := len(remainingSubnetAuthKeys) == 0
 
+	if d.dryRun {
+		// partially signed txs are also part of the plan, with their missing signatures
+		txID, err := d.Commit(tx)
+		if err != nil {
+			return false, ids.Empty, nil, nil, err
+		}
+		return isFullySigned, txID, tx, remainingSubnetAuthKeys, nil
+	}
+
 	if isFullySigned {
 		txID, err := d.Commit(tx)
 		if err != nil {
@@ -282,6 +306,14 @@ func (d *PublicDeployer) RemoveValidator(
 	}
 	isFullySigned := len(remainingSubnetAuthKeys) == 0
 
+	if d.dryRun {
+		// partially signed txs are also part of the plan, with their missing signatures
+		if _, err := d.Commit(tx); err != nil {
+			return false, nil, nil, err
+		}
+		return isFullySigned, tx, remainingSubnetAuthKeys, nil
+	}
+
 	if isFullySigned {
 		id, err := d.Commit(tx)
 		if err != nil {
@@ -319,7 +351,7 @@ func (d *PublicDeployer) AddPermissionlessValidator(
 	if err != nil {
 		return ids.Empty, err
 	}
-	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	d.printSuccess("Transaction successful, transaction ID: %s", txID)
 	return txID, nil
 }
 
@@ -340,7 +372,7 @@ func (d *PublicDeployer) AddPermissionlessDelegator(
 	if err != nil {
 		return ids.Empty, err
 	}
-	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	d.printSuccess("Transaction successful, transaction ID: %s", txID)
 	return txID, nil
 }
 
@@ -357,6 +389,9 @@ func (d *PublicDeployer) DeploySubnet(
 	if err != nil {
 		return ids.Empty, err
 	}
+	if d.dryRun {
+		return subnetID, nil
+	}
 	ux.Logger.PrintToUser("Subnet has been created with ID: %s", subnetID.String())
 	time.Sleep(2 * time.Second)
 	return subnetID, nil
@@ -406,7 +441,8 @@ func (d *PublicDeployer) DeployBlockchain(
 	isFullySigned := len(remainingSubnetAuthKeys) == 0
 
 	id := ids.Empty
-	if isFullySigned {
+	if isFullySigned || d.dryRun {
+		// partially signed txs are also part of the plan of a dry run
 		id, err = d.Commit(tx)
 		if err != nil {
 			return false, ids.Empty, nil, nil, err
@@ -445,6 +481,24 @@ func (d *PublicDeployer) Sign(
 	if err != nil {
 		return err
 	}
+	return d.sign(tx, subnetAuthKeysStrs, wallet.P().Signer())
+}
+
+// SignOffline signs [tx] without network access, getting the consumed UTXOs
+// and the subnet owners from [backend] (eg a signing kit)
+func (d *PublicDeployer) SignOffline(
+	tx *txs.Tx,
+	subnetAuthKeysStrs []string,
+	backend p.SignerBackend,
+) error {
+	return d.sign(tx, subnetAuthKeysStrs, p.NewSigner(d.kc.Keychain, backend))
+}
+
+func (d *PublicDeployer) sign(
+	tx *txs.Tx,
+	subnetAuthKeysStrs []string,
+	signer p.Signer,
+) error {
 	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
 	if err != nil {
 		return fmt.Errorf("failure parsing subnet auth keys: %w", err)
@@ -460,7 +514,7 @@ func (d *PublicDeployer) Sign(
 			showLedgerSignatureMsg(d.kc.UsesLedger, d.kc.HasOnlyOneKey(), fmt.Sprintf("%s transaction", txName))
 		}
 	}
-	if err := d.signTx(tx, wallet); err != nil {
+	if err := d.signTx(tx, signer); err != nil {
 		return err
 	}
 	return nil
@@ -470,6 +524,9 @@ func (d *PublicDeployer) loadWallet(preloadTxs ...ids.ID) (primary.Wallet, error
 	ctx := context.Background()
 	// filter out ids.Empty txs
 	filteredTxs := utils.Filter(preloadTxs, func(e ids.ID) bool { return e != ids.Empty })
+	if d.dryRun {
+		return d.loadDryRunWallet(ctx, filteredTxs)
+	}
 	wallet, err := primary.MakeWallet(
 		ctx,
 		&primary.WalletConfig{
@@ -482,7 +539,7 @@ func (d *PublicDeployer) loadWallet(preloadTxs ...ids.ID) (primary.Wallet, error
 	if err != nil {
 		return nil, err
 	}
-	return wallet, nil
+	return d.journaledWallet(wallet), nil
 }
 
 func (d *PublicDeployer) getMultisigTxOptions(subnetAuthKeys []ids.ShortID) []common.Option {
@@ -744,9 +801,9 @@ func (d *PublicDeployer) issueAddPermissionlessDelegatorTX(
 
 func (*PublicDeployer) signTx(
 	tx *txs.Tx,
-	wallet primary.Wallet,
+	signer p.Signer,
 ) error {
-	if err := wallet.P().Signer().Sign(context.Background(), tx); err != nil {
+	if err := signer.Sign(context.Background(), tx); err != nil {
 		return fmt.Errorf("error signing tx: %w", err)
 	}
 	return nil
@@ -813,6 +870,19 @@ func (d *PublicDeployer) checkWalletHasSubnetAuthAddresses(subnetAuth []ids.Shor
 	return len(addrs) != 0
 }
 
+// GetAssetDescription queries the X-Chain for the name and symbol of [assetID]
+func GetAssetDescription(network models.Network, assetID ids.ID) (string, string, error) {
+	xClient := avm.NewClient(network.Endpoint, "X")
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+
+	asset, err := xClient.GetAssetDescription(ctx, assetID.String())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get description of asset %s: %w", assetID, err)
+	}
+	return asset.Name, asset.Symbol, nil
+}
+
 func IsSubnetValidator(subnetID ids.ID, nodeID ids.NodeID, network models.Network) (bool, error) {
 	pClient := platformvm.NewClient(network.Endpoint)
 	ctx, cancel := utils.GetAPIContext()
@@ -0,0 +1,366 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/cli/pkg/binutils"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/utils"
+)
+
+const (
+	// network runner snapshots are saved in the snapshots dir with this prefix
+	snapshotDirPrefix = "anr-snapshot-"
+	// archive dir holding the VM binaries needed by the snapshot
+	snapshotArchivePluginsDir = "plugins"
+	// suffix of the file holding the sha256 sum of a snapshot archive
+	SnapshotSHA256Suffix = ".sha256"
+)
+
+var (
+	ErrSnapshotNotFound    = errors.New("snapshot not found")
+	ErrInvalidSnapshotName = errors.New("invalid snapshot name")
+)
+
+// SnapshotInfo describes a snapshot saved in the snapshots dir
+type SnapshotInfo struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// ValidateSnapshotName checks that [snapshotName] can be used as the name of a
+// snapshot dir, that is, it is a single path element
+func ValidateSnapshotName(snapshotName string) error {
+	if snapshotName == "" {
+		return fmt.Errorf("%w: it can't be empty", ErrInvalidSnapshotName)
+	}
+	if snapshotName == "." || strings.Contains(snapshotName, "..") || strings.ContainsAny(snapshotName, `/\`) ||
+		filepath.Base(snapshotName) != snapshotName {
+		return fmt.Errorf("%w: %q must be a single path element", ErrInvalidSnapshotName, snapshotName)
+	}
+	return nil
+}
+
+// GetSnapshotPath returns the dir of snapshot [snapshotName]
+func GetSnapshotPath(snapshotsDir string, snapshotName string) string {
+	return filepath.Join(snapshotsDir, snapshotDirPrefix+snapshotName)
+}
+
+// SnapshotExists returns true if snapshot [snapshotName] is saved in [snapshotsDir]
+func SnapshotExists(snapshotsDir string, snapshotName string) bool {
+	info, err := os.Stat(GetSnapshotPath(snapshotsDir, snapshotName))
+	return err == nil && info.IsDir()
+}
+
+// ListSnapshots returns the snapshots saved in [snapshotsDir], sorted by name
+func ListSnapshots(snapshotsDir string) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(snapshotsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []SnapshotInfo{}, nil
+		}
+		return nil, err
+	}
+	snapshots := []SnapshotInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), snapshotDirPrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshotPath := filepath.Join(snapshotsDir, entry.Name())
+		size, err := getDirSize(snapshotPath)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, SnapshotInfo{
+			Name:    strings.TrimPrefix(entry.Name(), snapshotDirPrefix),
+			Path:    snapshotPath,
+			Size:    size,
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+func getDirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// DeleteSnapshot removes snapshot [snapshotName] from [snapshotsDir]
+func DeleteSnapshot(snapshotsDir string, snapshotName string) error {
+	if err := ValidateSnapshotName(snapshotName); err != nil {
+		return err
+	}
+	if !SnapshotExists(snapshotsDir, snapshotName) {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, snapshotName)
+	}
+	return os.RemoveAll(GetSnapshotPath(snapshotsDir, snapshotName))
+}
+
+// ReplaceSnapshot moves snapshot [fromName] to [toName], replacing it if it exists.
+// The replaced snapshot is only removed once the new one is in place
+func ReplaceSnapshot(snapshotsDir string, fromName string, toName string) error {
+	for _, snapshotName := range []string{fromName, toName} {
+		if err := ValidateSnapshotName(snapshotName); err != nil {
+			return err
+		}
+	}
+	if !SnapshotExists(snapshotsDir, fromName) {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, fromName)
+	}
+	toPath := GetSnapshotPath(snapshotsDir, toName)
+	if !SnapshotExists(snapshotsDir, toName) {
+		return os.Rename(GetSnapshotPath(snapshotsDir, fromName), toPath)
+	}
+	replacedPath, err := os.MkdirTemp(snapshotsDir, "replaced-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(replacedPath)
+	replacedSnapshotPath := filepath.Join(replacedPath, filepath.Base(toPath))
+	if err := os.Rename(toPath, replacedSnapshotPath); err != nil {
+		return err
+	}
+	if err := os.Rename(GetSnapshotPath(snapshotsDir, fromName), toPath); err != nil {
+		if restoreErr := os.Rename(replacedSnapshotPath, toPath); restoreErr != nil {
+			return fmt.Errorf("%w, and failed restoring snapshot %s: %v", err, toName, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// ExportSnapshot writes snapshot [snapshotName] into the tar.gz archive [archivePath],
+// together with the VM binaries at [pluginsDir], so it can be loaded on other machines.
+// The sha256 sum of the archive is returned, and also written to archivePath.sha256
+func ExportSnapshot(snapshotsDir string, pluginsDir string, snapshotName string, archivePath string, force bool) (string, error) {
+	if err := ValidateSnapshotName(snapshotName); err != nil {
+		return "", err
+	}
+	if !SnapshotExists(snapshotsDir, snapshotName) {
+		return "", fmt.Errorf("%w: %s", ErrSnapshotNotFound, snapshotName)
+	}
+	if _, err := os.Stat(archivePath); err == nil && !force {
+		return "", fmt.Errorf("archive file %s already exists", archivePath)
+	}
+	sum, err := writeSnapshotArchive(snapshotsDir, pluginsDir, snapshotName, archivePath)
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return "", err
+	}
+	sumFileContent := fmt.Sprintf("%s  %s\n", sum, filepath.Base(archivePath))
+	if err := os.WriteFile(archivePath+SnapshotSHA256Suffix, []byte(sumFileContent), constants.WriteReadReadPerms); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// writes the snapshot archive, returning its sha256 sum
+func writeSnapshotArchive(snapshotsDir string, pluginsDir string, snapshotName string, archivePath string) (string, error) {
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archiveFile.Close()
+	hasher := sha256.New()
+	gzipWriter := gzip.NewWriter(io.MultiWriter(archiveFile, hasher))
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := addDirToTar(tarWriter, GetSnapshotPath(snapshotsDir, snapshotName), snapshotDirPrefix+snapshotName); err != nil {
+		return "", fmt.Errorf("failed archiving snapshot: %w", err)
+	}
+	if _, err := os.Stat(pluginsDir); err == nil {
+		if err := addDirToTar(tarWriter, pluginsDir, snapshotArchivePluginsDir); err != nil {
+			return "", fmt.Errorf("failed archiving plugins: %w", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return "", err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", err
+	}
+	if err := archiveFile.Close(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// adds the dirs and regular files at [srcDir] to the archive, under [archiveDir]
+func addDirToTar(tarWriter *tar.Writer, srcDir string, archiveDir string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(archiveDir, relPath))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tarWriter, f)
+		return err
+	})
+}
+
+// ImportSnapshot installs the snapshot archive [archivePath] into [snapshotsDir], and
+// its VM binaries into [pluginsDir]. If [expectedSum] is not given, it is read from
+// archivePath.sha256 when present. The snapshot is saved as [snapshotName], or with
+// its original name if empty. Returns the name and the sha256 sum of the archive
+func ImportSnapshot(
+	snapshotsDir string,
+	pluginsDir string,
+	archivePath string,
+	snapshotName string,
+	expectedSum string,
+	force bool,
+) (string, string, error) {
+	if snapshotName != "" {
+		if err := ValidateSnapshotName(snapshotName); err != nil {
+			return "", "", err
+		}
+	}
+	if expectedSum == "" {
+		if sumFileBytes, err := os.ReadFile(archivePath + SnapshotSHA256Suffix); err == nil {
+			expectedSum, err = utils.SearchSHA256File(sumFileBytes, filepath.Base(archivePath))
+			if err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(snapshotsDir, constants.DefaultPerms755); err != nil {
+		return "", "", err
+	}
+	// extract into the snapshots dir, so the snapshot can be moved to its final place
+	tmpDir, err := os.MkdirTemp(snapshotsDir, "import-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	sum, err := extractSnapshotArchive(archivePath, tmpDir)
+	if err != nil {
+		return "", "", err
+	}
+	// the extracted files are only moved into place once the sum is checked
+	if expectedSum != "" && !strings.EqualFold(sum, expectedSum) {
+		return "", "", fmt.Errorf("sha256 sum of %s is %s, expected %s", archivePath, sum, expectedSum)
+	}
+
+	snapshotDirs, err := filepath.Glob(filepath.Join(tmpDir, snapshotDirPrefix+"*"))
+	if err != nil {
+		return "", "", err
+	}
+	if len(snapshotDirs) != 1 {
+		return "", "", fmt.Errorf("expected one snapshot in archive %s, found %d", archivePath, len(snapshotDirs))
+	}
+	if snapshotName == "" {
+		snapshotName = strings.TrimPrefix(filepath.Base(snapshotDirs[0]), snapshotDirPrefix)
+		if err := ValidateSnapshotName(snapshotName); err != nil {
+			return "", "", err
+		}
+	}
+	snapshotPath := GetSnapshotPath(snapshotsDir, snapshotName)
+	if SnapshotExists(snapshotsDir, snapshotName) {
+		if !force {
+			return "", "", fmt.Errorf("snapshot %s already exists", snapshotName)
+		}
+		if err := os.RemoveAll(snapshotPath); err != nil {
+			return "", "", err
+		}
+	}
+	if err := os.Rename(snapshotDirs[0], snapshotPath); err != nil {
+		return "", "", err
+	}
+
+	archivedPlugins, err := os.ReadDir(filepath.Join(tmpDir, snapshotArchivePluginsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", err
+	}
+	if len(archivedPlugins) > 0 {
+		if err := os.MkdirAll(pluginsDir, constants.DefaultPerms755); err != nil {
+			return "", "", err
+		}
+	}
+	for _, plugin := range archivedPlugins {
+		pluginPath := filepath.Join(pluginsDir, plugin.Name())
+		// installed plugins are kept, as they may be in use by the running network
+		if _, err := os.Stat(pluginPath); err == nil && !force {
+			continue
+		}
+		if err := os.Rename(filepath.Join(tmpDir, snapshotArchivePluginsDir, plugin.Name()), pluginPath); err != nil {
+			return "", "", err
+		}
+	}
+	return snapshotName, sum, nil
+}
+
+// extracts the snapshot archive [archivePath] into [dir], streaming it from disk,
+// and returns its sha256 sum
+func extractSnapshotArchive(archivePath string, dir string) (string, error) {
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archiveFile.Close()
+	hasher := sha256.New()
+	archiveReader := io.TeeReader(archiveFile, hasher)
+	if err := binutils.InstallTarGzArchiveFromReader(archiveReader, dir); err != nil {
+		return "", fmt.Errorf("failed extracting snapshot archive: %w", err)
+	}
+	// the sum covers the whole file, also what follows the end of the tar stream
+	if _, err := io.Copy(io.Discard, archiveReader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
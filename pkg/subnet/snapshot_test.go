@@ -0,0 +1,158 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestSnapshot(t *testing.T, snapshotsDir string, name string) {
+	snapshotPath := GetSnapshotPath(snapshotsDir, name)
+	require.NoError(t, os.MkdirAll(filepath.Join(snapshotPath, "db", "node1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotPath, "network.json"), []byte(`{"nodes":1}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotPath, "db", "node1", "data"), []byte("state"), 0o600))
+}
+
+func TestListDeleteSnapshots(t *testing.T) {
+	require := require.New(t)
+
+	snapshotsDir := t.TempDir()
+	snapshots, err := ListSnapshots(filepath.Join(snapshotsDir, "missing"))
+	require.NoError(err)
+	require.Empty(snapshots)
+
+	writeTestSnapshot(t, snapshotsDir, "b")
+	writeTestSnapshot(t, snapshotsDir, "a")
+	require.NoError(os.WriteFile(filepath.Join(snapshotsDir, "bootstrapSnapshot.tar.gz"), []byte{}, 0o600))
+
+	snapshots, err = ListSnapshots(snapshotsDir)
+	require.NoError(err)
+	require.Len(snapshots, 2)
+	require.Equal("a", snapshots[0].Name)
+	require.Equal("b", snapshots[1].Name)
+	require.Equal(int64(len(`{"nodes":1}`)+len("state")), snapshots[0].Size)
+
+	require.NoError(DeleteSnapshot(snapshotsDir, "a"))
+	require.False(SnapshotExists(snapshotsDir, "a"))
+	require.ErrorIs(DeleteSnapshot(snapshotsDir, "a"), ErrSnapshotNotFound)
+}
+
+func TestReplaceSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	snapshotsDir := t.TempDir()
+	require.ErrorIs(ReplaceSnapshot(snapshotsDir, "new", "old"), ErrSnapshotNotFound)
+
+	writeTestSnapshot(t, snapshotsDir, "new")
+	require.NoError(ReplaceSnapshot(snapshotsDir, "new", "old"))
+	require.False(SnapshotExists(snapshotsDir, "new"))
+	require.True(SnapshotExists(snapshotsDir, "old"))
+
+	writeTestSnapshot(t, snapshotsDir, "new")
+	require.NoError(os.WriteFile(filepath.Join(GetSnapshotPath(snapshotsDir, "new"), "network.json"), []byte(`{"nodes":2}`), 0o600))
+	require.NoError(ReplaceSnapshot(snapshotsDir, "new", "old"))
+	networkBytes, err := os.ReadFile(filepath.Join(GetSnapshotPath(snapshotsDir, "old"), "network.json"))
+	require.NoError(err)
+	require.Equal(`{"nodes":2}`, string(networkBytes))
+	// nothing else is left in the snapshots dir
+	entries, err := os.ReadDir(snapshotsDir)
+	require.NoError(err)
+	require.Len(entries, 1)
+}
+
+func TestExportImportSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	snapshotsDir := t.TempDir()
+	pluginsDir := t.TempDir()
+	writeTestSnapshot(t, snapshotsDir, "custom")
+	require.NoError(os.WriteFile(filepath.Join(pluginsDir, "vmid"), []byte("vm binary"), 0o755))
+
+	archivePath := filepath.Join(t.TempDir(), "custom.tar.gz")
+	sum, err := ExportSnapshot(snapshotsDir, pluginsDir, "custom", archivePath, false)
+	require.NoError(err)
+	require.FileExists(archivePath + SnapshotSHA256Suffix)
+	_, err = ExportSnapshot(snapshotsDir, pluginsDir, "custom", archivePath, false)
+	require.Error(err)
+	_, err = ExportSnapshot(snapshotsDir, pluginsDir, "missing", archivePath, true)
+	require.ErrorIs(err, ErrSnapshotNotFound)
+
+	// import on another machine
+	otherSnapshotsDir := t.TempDir()
+	otherPluginsDir := t.TempDir()
+	name, importedSum, err := ImportSnapshot(otherSnapshotsDir, otherPluginsDir, archivePath, "", "", false)
+	require.NoError(err)
+	require.Equal("custom", name)
+	require.Equal(sum, importedSum)
+	data, err := os.ReadFile(filepath.Join(GetSnapshotPath(otherSnapshotsDir, "custom"), "db", "node1", "data"))
+	require.NoError(err)
+	require.Equal("state", string(data))
+	pluginBytes, err := os.ReadFile(filepath.Join(otherPluginsDir, "vmid"))
+	require.NoError(err)
+	require.Equal("vm binary", string(pluginBytes))
+
+	_, _, err = ImportSnapshot(otherSnapshotsDir, otherPluginsDir, archivePath, "", "", false)
+	require.Error(err)
+	name, _, err = ImportSnapshot(otherSnapshotsDir, otherPluginsDir, archivePath, "renamed", sum, false)
+	require.NoError(err)
+	require.Equal("renamed", name)
+	require.True(SnapshotExists(otherSnapshotsDir, "renamed"))
+
+	_, _, err = ImportSnapshot(otherSnapshotsDir, otherPluginsDir, archivePath, "other", "00", false)
+	require.ErrorContains(err, "sha256")
+	require.False(SnapshotExists(otherSnapshotsDir, "other"))
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "custom", valid: true},
+		{name: "my-snapshot_1.2", valid: true},
+		{name: "", valid: false},
+		{name: ".", valid: false},
+		{name: "..", valid: false},
+		{name: "a..b", valid: false},
+		{name: "../custom", valid: false},
+		{name: "dir/custom", valid: false},
+		{name: "/custom", valid: false},
+		{name: `dir\custom`, valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSnapshotName(tt.name)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidSnapshotName)
+			}
+		})
+	}
+}
+
+func TestSnapshotNameOutsideSnapshotsDir(t *testing.T) {
+	require := require.New(t)
+
+	baseDir := t.TempDir()
+	snapshotsDir := filepath.Join(baseDir, "snapshots")
+	writeTestSnapshot(t, snapshotsDir, "custom")
+	// a dir that a path traversal would reach
+	require.NoError(os.MkdirAll(filepath.Join(baseDir, snapshotDirPrefix), 0o755))
+
+	require.ErrorIs(DeleteSnapshot(snapshotsDir, "../"+snapshotDirPrefix), ErrInvalidSnapshotName)
+	require.DirExists(filepath.Join(baseDir, snapshotDirPrefix))
+
+	archivePath := filepath.Join(t.TempDir(), "custom.tar.gz")
+	_, err := ExportSnapshot(snapshotsDir, t.TempDir(), "../snapshots/"+snapshotDirPrefix+"custom", archivePath, false)
+	require.ErrorIs(err, ErrInvalidSnapshotName)
+	_, err = ExportSnapshot(snapshotsDir, t.TempDir(), "custom", archivePath, false)
+	require.NoError(err)
+	_, _, err = ImportSnapshot(snapshotsDir, t.TempDir(), archivePath, "../imported", "", false)
+	require.ErrorIs(err, ErrInvalidSnapshotName)
+	require.NoDirExists(filepath.Join(baseDir, "imported"))
+}
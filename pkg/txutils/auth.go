@@ -21,6 +21,7 @@ import (
 // - txs.CreateChainTx
 // - txs.AddSubnetValidatorTx
 // - txs.RemoveSubnetValidatorTx
+// - txs.TransformSubnetTx
 //
 // controlKeys must be in the same order as in the subnet creation tx (as obtained by GetOwners)
 func GetAuthSigners(tx *txs.Tx, controlKeys []string) ([]string, error) {
@@ -61,7 +62,7 @@ func GetAuthSigners(tx *txs.Tx, controlKeys []string) ([]string, error) {
 //     authSigners by using the index) to the remaining signers list
 //
 // if the tx is fully signed, returns empty slice
-// expect tx.Unsigned type to be in [txs.AddSubnetValidatorTx, txs.CreateChainTx]
+// expect tx.Unsigned type to be one of the ones accepted by GetAuthSigners
 //
 // controlKeys must be in the same order as in the subnet creation tx (as obtained by GetOwners)
 func GetRemainingSigners(tx *txs.Tx, controlKeys []string) ([]string, []string, error) {
@@ -5,6 +5,7 @@ package txutils
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/luxdefi/cli/pkg/key"
 	"github.com/luxdefi/cli/pkg/models"
@@ -68,6 +69,10 @@ func GetLedgerDisplayName(tx *txs.Tx) string {
 		return "SubnetValidator"
 	case *txs.CreateChainTx:
 		return "CreateChain"
+	case *txs.RemoveSubnetValidatorTx:
+		return "RemoveSubnetValidator"
+	case *txs.TransformSubnetTx:
+		return "TransformSubnet"
 	default:
 		return ""
 	}
@@ -78,6 +83,30 @@ func IsCreateChainTx(tx *txs.Tx) bool {
 	return ok
 }
 
+// get the elastic subnet config set by a TransformSubnetTx
+func GetElasticSubnetConfig(tx *txs.Tx) (models.ElasticSubnetConfig, error) {
+	transformSubnetTx, ok := tx.Unsigned.(*txs.TransformSubnetTx)
+	if !ok {
+		return models.ElasticSubnetConfig{}, fmt.Errorf("expected TransformSubnetTx, got %T", tx.Unsigned)
+	}
+	return models.ElasticSubnetConfig{
+		SubnetID:                 transformSubnetTx.Subnet,
+		AssetID:                  transformSubnetTx.AssetID,
+		InitialSupply:            transformSubnetTx.InitialSupply,
+		MaxSupply:                transformSubnetTx.MaximumSupply,
+		MinConsumptionRate:       transformSubnetTx.MinConsumptionRate,
+		MaxConsumptionRate:       transformSubnetTx.MaxConsumptionRate,
+		MinValidatorStake:        transformSubnetTx.MinValidatorStake,
+		MaxValidatorStake:        transformSubnetTx.MaxValidatorStake,
+		MinStakeDuration:         time.Duration(transformSubnetTx.MinStakeDuration) * time.Second,
+		MaxStakeDuration:         time.Duration(transformSubnetTx.MaxStakeDuration) * time.Second,
+		MinDelegationFee:         transformSubnetTx.MinDelegationFee,
+		MinDelegatorStake:        transformSubnetTx.MinDelegatorStake,
+		MaxValidatorWeightFactor: transformSubnetTx.MaxValidatorWeightFactor,
+		UptimeRequirement:        transformSubnetTx.UptimeRequirement,
+	}, nil
+}
+
 func GetOwners(network models.Network, subnetID ids.ID) ([]string, uint32, error) {
 	pClient := platformvm.NewClient(network.Endpoint)
 	ctx := context.Background()
@@ -85,28 +114,41 @@ func GetOwners(network models.Network, subnetID ids.ID) ([]string, uint32, error
 	if err != nil {
 		return nil, 0, fmt.Errorf("subnet tx %s query error: %w", subnetID, err)
 	}
-	var tx txs.Tx
-	if _, err := txs.Codec.Unmarshal(txBytes, &tx); err != nil {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
 		return nil, 0, fmt.Errorf("couldn't unmarshal tx %s: %w", subnetID, err)
 	}
+	return GetSubnetTxOwners(network.ID, tx)
+}
+
+// GetSubnetTxOwners returns the control keys and threshold defined by the
+// CreateSubnetTx [tx]
+func GetSubnetTxOwners(networkID uint32, tx *txs.Tx) ([]string, uint32, error) {
 	createSubnetTx, ok := tx.Unsigned.(*txs.CreateSubnetTx)
 	if !ok {
-		return nil, 0, fmt.Errorf("got unexpected type %T for subnet tx %s", tx.Unsigned, subnetID)
+		return nil, 0, fmt.Errorf("got unexpected type %T for subnet tx %s", tx.Unsigned, tx.ID())
 	}
 	owner, ok := createSubnetTx.Owner.(*secp256k1fx.OutputOwners)
 	if !ok {
-		return nil, 0, fmt.Errorf("got unexpected type %T for subnet owners tx %s", createSubnetTx.Owner, subnetID)
+		return nil, 0, fmt.Errorf("got unexpected type %T for subnet owners tx %s", createSubnetTx.Owner, tx.ID())
+	}
+	controlKeysStrs, err := formatPChainAddrs(networkID, owner.Addrs)
+	if err != nil {
+		return nil, 0, err
 	}
-	controlKeys := owner.Addrs
-	threshold := owner.Threshold
-	hrp := key.GetHRP(network.ID)
-	controlKeysStrs := []string{}
-	for _, addr := range controlKeys {
+	return controlKeysStrs, owner.Threshold, nil
+}
+
+// formats [addrs] as P-Chain addresses of [networkID]
+func formatPChainAddrs(networkID uint32, addrs []ids.ShortID) ([]string, error) {
+	hrp := key.GetHRP(networkID)
+	addrStrs := []string{}
+	for _, addr := range addrs {
 		addrStr, err := address.Format("P", hrp, addr[:])
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
-		controlKeysStrs = append(controlKeysStrs, addrStr)
+		addrStrs = append(addrStrs, addrStr)
 	}
-	return controlKeysStrs, threshold, nil
+	return addrStrs, nil
 }
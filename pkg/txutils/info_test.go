@@ -0,0 +1,51 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetElasticSubnetConfig(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	assetID := ids.GenerateTestID()
+	tx := &txs.Tx{
+		Unsigned: &txs.TransformSubnetTx{
+			Subnet:                   subnetID,
+			AssetID:                  assetID,
+			InitialSupply:            240_000_000,
+			MaximumSupply:            720_000_000,
+			MinStakeDuration:         uint32((24 * time.Hour).Seconds()),
+			MaxStakeDuration:         uint32((365 * 24 * time.Hour).Seconds()),
+			MaxValidatorWeightFactor: 5,
+		},
+	}
+	config, err := GetElasticSubnetConfig(tx)
+	require.NoError(err)
+	require.Equal(subnetID, config.SubnetID)
+	require.Equal(assetID, config.AssetID)
+	require.Equal(uint64(240_000_000), config.InitialSupply)
+	require.Equal(uint64(720_000_000), config.MaxSupply)
+	require.Equal(24*time.Hour, config.MinStakeDuration)
+	require.Equal(365*24*time.Hour, config.MaxStakeDuration)
+	require.Equal(byte(5), config.MaxValidatorWeightFactor)
+
+	_, err = GetElasticSubnetConfig(&txs.Tx{Unsigned: &txs.CreateChainTx{}})
+	require.Error(err)
+}
+
+func TestGetLedgerDisplayName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("CreateChain", GetLedgerDisplayName(&txs.Tx{Unsigned: &txs.CreateChainTx{}}))
+	require.Equal("RemoveSubnetValidator", GetLedgerDisplayName(&txs.Tx{Unsigned: &txs.RemoveSubnetValidatorTx{}}))
+	require.Equal("TransformSubnet", GetLedgerDisplayName(&txs.Tx{Unsigned: &txs.TransformSubnetTx{}}))
+	require.Equal("", GetLedgerDisplayName(&txs.Tx{Unsigned: &txs.CreateSubnetTx{}}))
+}
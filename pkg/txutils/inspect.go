@@ -0,0 +1,212 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+)
+
+// TxInfo is a human/machine readable description of a (partially) signed tx
+type TxInfo struct {
+	Type      string         `json:"type"`
+	Network   string         `json:"network"`
+	SubnetID  string         `json:"subnetID"`
+	Fee       uint64         `json:"fee"`
+	Chain     *ChainInfo     `json:"chain,omitempty"`
+	Validator *ValidatorInfo `json:"validator,omitempty"`
+	Signers   *SignersInfo   `json:"signers,omitempty"`
+}
+
+// ChainInfo describes the blockchain created by a CreateChainTx
+type ChainInfo struct {
+	Name string `json:"name"`
+	VMID string `json:"vmID"`
+	// sha256 of the genesis, to compare it with the local genesis file
+	GenesisHash string `json:"genesisHash"`
+}
+
+// ValidatorInfo describes the validator added or removed by a tx
+type ValidatorInfo struct {
+	NodeID    string     `json:"nodeID"`
+	Weight    uint64     `json:"weight,omitempty"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// SignersInfo describes the subnet auth signing status of a tx
+type SignersInfo struct {
+	Threshold uint32   `json:"threshold"`
+	Required  []string `json:"required"`
+	Signed    []string `json:"signed"`
+	Remaining []string `json:"remaining"`
+}
+
+// Inspect decodes the contents of [tx]. Signing status is only included
+// if [controlKeys] is given, in the same order as obtained by GetOwners
+func Inspect(tx *txs.Tx, controlKeys []string, threshold uint32) (*TxInfo, error) {
+	network, err := GetNetwork(tx)
+	if err != nil {
+		return nil, err
+	}
+	subnetID, err := GetSubnetID(tx)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := GetFee(tx)
+	if err != nil {
+		return nil, err
+	}
+	info := &TxInfo{
+		Type:     GetTxTypeName(tx),
+		Network:  network.Name(),
+		SubnetID: subnetID.String(),
+		Fee:      fee,
+	}
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *txs.CreateChainTx:
+		genesisHash := sha256.Sum256(unsignedTx.GenesisData)
+		info.Chain = &ChainInfo{
+			Name:        unsignedTx.ChainName,
+			VMID:        unsignedTx.VMID.String(),
+			GenesisHash: hex.EncodeToString(genesisHash[:]),
+		}
+	case *txs.AddSubnetValidatorTx:
+		info.Validator = newValidatorInfo(&unsignedTx.SubnetValidator.Validator)
+	case *txs.AddPermissionlessValidatorTx:
+		info.Validator = newValidatorInfo(&unsignedTx.Validator)
+	case *txs.RemoveSubnetValidatorTx:
+		info.Validator = &ValidatorInfo{
+			NodeID: unsignedTx.NodeID.String(),
+		}
+	}
+	if controlKeys != nil {
+		authSigners, remainingSigners, err := GetRemainingSigners(tx, controlKeys)
+		if err != nil {
+			return nil, err
+		}
+		info.Signers = &SignersInfo{
+			Threshold: threshold,
+			Required:  authSigners,
+			Signed:    []string{},
+			Remaining: remainingSigners,
+		}
+		remaining := map[string]struct{}{}
+		for _, addr := range remainingSigners {
+			remaining[addr] = struct{}{}
+		}
+		for _, addr := range authSigners {
+			if _, ok := remaining[addr]; !ok {
+				info.Signers.Signed = append(info.Signers.Signed, addr)
+			}
+		}
+	}
+	return info, nil
+}
+
+func newValidatorInfo(validator *txs.Validator) *ValidatorInfo {
+	startTime := validator.StartTime()
+	endTime := validator.EndTime()
+	return &ValidatorInfo{
+		NodeID:    validator.NodeID.String(),
+		Weight:    validator.Weight(),
+		StartTime: &startTime,
+		EndTime:   &endTime,
+	}
+}
+
+// get the type name of the unsigned tx, eg CreateChainTx
+func GetTxTypeName(tx *txs.Tx) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", tx.Unsigned), "*txs.")
+}
+
+// get the fee paid by tx, as the difference between its consumed and produced amounts
+func GetFee(tx *txs.Tx) (uint64, error) {
+	baseTx, err := getBaseTx(tx)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		// outputs that are not part of the fee, as staked or exported
+		extraOuts []*lux.TransferableOutput
+		// inputs that are not part of the fee, as imported
+		extraIns []*lux.TransferableInput
+		// subnet asset, burned by TransformSubnetTx, that is not part of the fee
+		subnetAssetID ids.ID
+	)
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *txs.TransformSubnetTx:
+		subnetAssetID = unsignedTx.AssetID
+	case *txs.AddPermissionlessValidatorTx:
+		extraOuts = unsignedTx.StakeOuts
+	case *txs.AddPermissionlessDelegatorTx:
+		extraOuts = unsignedTx.StakeOuts
+	case *txs.ExportTx:
+		extraOuts = unsignedTx.ExportedOutputs
+	case *txs.ImportTx:
+		extraIns = unsignedTx.ImportedInputs
+	}
+	consumed := uint64(0)
+	for _, ins := range [][]*lux.TransferableInput{baseTx.Ins, extraIns} {
+		for _, in := range ins {
+			if in.AssetID() != subnetAssetID {
+				consumed += in.In.Amount()
+			}
+		}
+	}
+	produced := uint64(0)
+	for _, outs := range [][]*lux.TransferableOutput{baseTx.Outs, extraOuts} {
+		for _, out := range outs {
+			if out.AssetID() != subnetAssetID {
+				produced += out.Out.Amount()
+			}
+		}
+	}
+	if produced > consumed {
+		return 0, fmt.Errorf("tx produces %d more than it consumes", produced-consumed)
+	}
+	return consumed - produced, nil
+}
+
+// get the base tx of [tx], holding its inputs and outputs
+func getBaseTx(tx *txs.Tx) (*txs.BaseTx, error) {
+	switch unsignedTx := tx.Unsigned.(type) {
+	case *txs.RemoveSubnetValidatorTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.AddSubnetValidatorTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.CreateChainTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.TransformSubnetTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.AddPermissionlessValidatorTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.AddPermissionlessDelegatorTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.CreateSubnetTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.ExportTx:
+		return &unsignedTx.BaseTx, nil
+	case *txs.ImportTx:
+		return &unsignedTx.BaseTx, nil
+	default:
+		return nil, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	}
+}
+
+// returns true if [tx] has to be signed by the subnet control keys
+func NeedsSubnetAuth(tx *txs.Tx) bool {
+	switch tx.Unsigned.(type) {
+	case *txs.RemoveSubnetValidatorTx, *txs.AddSubnetValidatorTx, *txs.CreateChainTx, *txs.TransformSubnetTx:
+		return true
+	default:
+		return false
+	}
+}
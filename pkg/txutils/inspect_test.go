@@ -0,0 +1,126 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCreateChainTx(consumed uint64, produced uint64, sigs [][65]byte) *txs.Tx {
+	assetID := ids.GenerateTestID()
+	return &txs.Tx{
+		Unsigned: &txs.CreateChainTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: lux.BaseTx{
+					NetworkID: models.FujiNetwork.ID,
+					Ins: []*lux.TransferableInput{{
+						Asset: lux.Asset{ID: assetID},
+						In:    &secp256k1fx.TransferInput{Amt: consumed},
+					}},
+					Outs: []*lux.TransferableOutput{{
+						Asset: lux.Asset{ID: assetID},
+						Out:   &secp256k1fx.TransferOutput{Amt: produced},
+					}},
+				},
+			},
+			SubnetID:    ids.GenerateTestID(),
+			ChainName:   "testChain",
+			VMID:        ids.GenerateTestID(),
+			GenesisData: []byte("{}"),
+			SubnetAuth:  &secp256k1fx.Input{SigIndices: []uint32{0, 2}},
+		},
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{Sigs: [][65]byte{{1}}},
+			&secp256k1fx.Credential{Sigs: sigs},
+		},
+	}
+}
+
+func TestInspectCreateChainTx(t *testing.T) {
+	require := require.New(t)
+
+	tx := newTestCreateChainTx(1_000_000_000, 900_000_000, [][65]byte{{1}, {}})
+	controlKeys := []string{"P-fuji1a", "P-fuji1b", "P-fuji1c"}
+	info, err := Inspect(tx, controlKeys, 2)
+	require.NoError(err)
+
+	require.Equal("CreateChainTx", info.Type)
+	require.Equal("Fuji", info.Network)
+	require.Equal(uint64(100_000_000), info.Fee)
+	require.NotNil(info.Chain)
+	require.Equal("testChain", info.Chain.Name)
+	// sha256 of "{}"
+	require.Equal("44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", info.Chain.GenesisHash)
+	require.Nil(info.Validator)
+
+	require.NotNil(info.Signers)
+	require.Equal(uint32(2), info.Signers.Threshold)
+	require.Equal([]string{"P-fuji1a", "P-fuji1c"}, info.Signers.Required)
+	require.Equal([]string{"P-fuji1a"}, info.Signers.Signed)
+	require.Equal([]string{"P-fuji1c"}, info.Signers.Remaining)
+
+	info, err = Inspect(tx, nil, 0)
+	require.NoError(err)
+	require.Nil(info.Signers)
+}
+
+func TestGetFeeOverspend(t *testing.T) {
+	tx := newTestCreateChainTx(1, 2, nil)
+	_, err := GetFee(tx)
+	require.Error(t, err)
+}
+
+func TestGetFeeExportImportTx(t *testing.T) {
+	require := require.New(t)
+
+	assetID := ids.GenerateTestID()
+	newIns := func(amount uint64) []*lux.TransferableInput {
+		return []*lux.TransferableInput{{
+			Asset: lux.Asset{ID: assetID},
+			In:    &secp256k1fx.TransferInput{Amt: amount},
+		}}
+	}
+	newOuts := func(amount uint64) []*lux.TransferableOutput {
+		return []*lux.TransferableOutput{{
+			Asset: lux.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}}
+	}
+
+	exportTx := &txs.Tx{
+		Unsigned: &txs.ExportTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: lux.BaseTx{
+					Ins:  newIns(1_000_000_000),
+					Outs: newOuts(400_000_000),
+				},
+			},
+			ExportedOutputs: newOuts(500_000_000),
+		},
+	}
+	fee, err := GetFee(exportTx)
+	require.NoError(err)
+	require.Equal(uint64(100_000_000), fee)
+
+	importTx := &txs.Tx{
+		Unsigned: &txs.ImportTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: lux.BaseTx{
+					Outs: newOuts(499_000_000),
+				},
+			},
+			ImportedInputs: newIns(500_000_000),
+		},
+	}
+	fee, err = GetFee(importTx)
+	require.NoError(err)
+	require.Equal(uint64(1_000_000), fee)
+}
@@ -45,7 +45,12 @@ func LoadFromDisk(txPath string) (*txs.Tx, error) {
 	if err != nil {
 		return nil, err
 	}
-	txBytes, err := formatting.Decode(formatting.Hex, string(txEncodedBytes))
+	return decodeTx(string(txEncodedBytes))
+}
+
+// decodes a tx encoded in hex + checksum, as saved by SaveToDisk
+func decodeTx(txStr string) (*txs.Tx, error) {
+	txBytes, err := formatting.Decode(formatting.Hex, txStr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't decode signed tx: %w", err)
 	}
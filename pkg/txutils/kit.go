@@ -0,0 +1,348 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"golang.org/x/exp/slices"
+)
+
+const signingKitVersion = 1
+
+var (
+	ErrNotSigningKit   = errors.New("file is not a signing kit")
+	ErrInvalidKit      = errors.New("invalid signing kit")
+	ErrKitOwnersChange = errors.New("subnet owners on the network differ from the ones in the signing kit")
+)
+
+// SigningKit bundles a multisig tx with all the network data needed to sign
+// it on a disconnected machine:
+//   - the CreateSubnetTx of the subnet, that defines its control keys and threshold
+//   - the UTXOs consumed by the tx, all owned by the fee payer
+//
+// Control keys and threshold are included for readability, but they are
+// always verified against the CreateSubnetTx. The fee payer is shown to the
+// signers, and the UTXOs are verified to be owned by it.
+type SigningKit struct {
+	Version           int      `json:"version"`
+	NetworkID         uint32   `json:"networkID"`
+	SubnetID          ids.ID   `json:"subnetID"`
+	ControlKeys       []string `json:"controlKeys"`
+	Threshold         uint32   `json:"threshold"`
+	FeePayer          []string `json:"feePayer"`
+	FeePayerThreshold uint32   `json:"feePayerThreshold"`
+	Tx                string   `json:"tx"`
+	SubnetTx          string   `json:"subnetTx"`
+	UTXOs             []string `json:"utxos"`
+}
+
+// creates a signing kit for [tx], getting the needed data from [network]
+func NewSigningKit(network models.Network, tx *txs.Tx) (*SigningKit, error) {
+	if !NeedsSubnetAuth(tx) {
+		return nil, fmt.Errorf("unexpected unsigned tx type %T", tx.Unsigned)
+	}
+	subnetID, err := GetSubnetID(tx)
+	if err != nil {
+		return nil, err
+	}
+	pClient := platformvm.NewClient(network.Endpoint)
+	ctx := context.Background()
+	subnetTxBytes, err := pClient.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("subnet tx %s query error: %w", subnetID, err)
+	}
+	subnetTx, err := txs.Parse(txs.Codec, subnetTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal tx %s: %w", subnetID, err)
+	}
+	utxos, err := fetchConsumedUTXOs(ctx, pClient, tx)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKit(network.ID, tx, subnetTx, utxos)
+}
+
+func newSigningKit(networkID uint32, tx *txs.Tx, subnetTx *txs.Tx, utxos []*lux.UTXO) (*SigningKit, error) {
+	controlKeys, threshold, err := GetSubnetTxOwners(networkID, subnetTx)
+	if err != nil {
+		return nil, err
+	}
+	kit := &SigningKit{
+		Version:     signingKitVersion,
+		NetworkID:   networkID,
+		SubnetID:    subnetTx.ID(),
+		ControlKeys: controlKeys,
+		Threshold:   threshold,
+		FeePayer:    []string{},
+		UTXOs:       []string{},
+	}
+	kit.SubnetTx, err = formatting.Encode(formatting.Hex, subnetTx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	var feePayer *secp256k1fx.OutputOwners
+	for _, utxo := range utxos {
+		owners, err := getUTXOOwners(utxo)
+		if err != nil {
+			return nil, err
+		}
+		if feePayer == nil {
+			feePayer = owners
+			kit.FeePayer, err = formatPChainAddrs(networkID, owners.Addrs)
+			if err != nil {
+				return nil, err
+			}
+			kit.FeePayerThreshold = owners.Threshold
+		} else if !feePayer.Equals(owners) {
+			return nil, errors.New("UTXOs consumed by the tx have different owners")
+		}
+		utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return nil, err
+		}
+		utxoStr, err := formatting.Encode(formatting.Hex, utxoBytes)
+		if err != nil {
+			return nil, err
+		}
+		kit.UTXOs = append(kit.UTXOs, utxoStr)
+	}
+	if err := kit.SetTx(tx); err != nil {
+		return nil, err
+	}
+	return kit, nil
+}
+
+// fetches the UTXOs consumed by [tx], from the P-Chain txs that produced them.
+// UTXOs imported from other chains are skipped: their inputs are signed by the tx
+// creator, and the signer leaves inputs with unknown UTXOs untouched
+func fetchConsumedUTXOs(ctx context.Context, pClient platformvm.Client, tx *txs.Tx) ([]*lux.UTXO, error) {
+	utxos := []*lux.UTXO{}
+	sourceTxs := map[ids.ID]*txs.Tx{}
+	for _, utxoID := range consumedUTXOIDs(tx) {
+		sourceTx, ok := sourceTxs[utxoID.TxID]
+		if !ok {
+			sourceTxBytes, err := pClient.GetTx(ctx, utxoID.TxID)
+			if err != nil {
+				continue
+			}
+			sourceTx, err = txs.Parse(txs.Codec, sourceTxBytes)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't unmarshal tx %s: %w", utxoID.TxID, err)
+			}
+			sourceTxs[utxoID.TxID] = sourceTx
+		}
+		producedUTXOs := sourceTx.UTXOs()
+		if utxoID.OutputIndex < uint32(len(producedUTXOs)) {
+			utxos = append(utxos, producedUTXOs[utxoID.OutputIndex])
+		}
+	}
+	return utxos, nil
+}
+
+// returns the owners of a UTXO spendable by a secp256k1 input
+func getUTXOOwners(utxo *lux.UTXO) (*secp256k1fx.OutputOwners, error) {
+	out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T for UTXO %s", utxo.Out, utxo.InputID())
+	}
+	return &out.OutputOwners, nil
+}
+
+func consumedUTXOIDs(tx *txs.Tx) []lux.UTXOID {
+	baseTx, err := getBaseTx(tx)
+	if err != nil {
+		return nil
+	}
+	utxoIDs := []lux.UTXOID{}
+	// inputs are already sorted, so the UTXOs keep the tx order
+	for _, in := range baseTx.Ins {
+		utxoIDs = append(utxoIDs, in.UTXOID)
+	}
+	return utxoIDs
+}
+
+// returns true if [kb] is the content of a signing kit file
+func IsSigningKit(kb []byte) bool {
+	var kit SigningKit
+	return json.Unmarshal(kb, &kit) == nil && kit.Version > 0 && kit.Tx != ""
+}
+
+// returns true if [path] is a signing kit file
+func IsSigningKitFile(path string) bool {
+	kb, err := os.ReadFile(path)
+	return err == nil && IsSigningKit(kb)
+}
+
+// loads a signing kit from [kitPath]
+func LoadSigningKit(kitPath string) (*SigningKit, error) {
+	kb, err := os.ReadFile(kitPath)
+	if err != nil {
+		return nil, err
+	}
+	if !IsSigningKit(kb) {
+		return nil, ErrNotSigningKit
+	}
+	var kit SigningKit
+	if err := json.Unmarshal(kb, &kit); err != nil {
+		return nil, err
+	}
+	if kit.Version > signingKitVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidKit, kit.Version)
+	}
+	return &kit, nil
+}
+
+// saves the signing kit to [kitPath]
+func (kit *SigningKit) Save(kitPath string, forceOverwrite bool) error {
+	if _, err := os.Stat(kitPath); err == nil && !forceOverwrite {
+		return fmt.Errorf("couldn't create file to write signing kit to: file exists")
+	}
+	kb, err := json.MarshalIndent(kit, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(kitPath, kb, constants.WriteReadReadPerms)
+}
+
+// decodes the tx of the kit
+func (kit *SigningKit) GetTx() (*txs.Tx, error) {
+	return decodeTx(kit.Tx)
+}
+
+// replaces the tx of the kit, eg after signing it
+func (kit *SigningKit) SetTx(tx *txs.Tx) error {
+	txBytes, err := txs.Codec.Marshal(txs.Version, tx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal signed tx: %w", err)
+	}
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode signed tx: %w", err)
+	}
+	kit.Tx = txStr
+	return nil
+}
+
+// Verify checks that the kit is self consistent, so it can be trusted offline:
+//   - the tx belongs to the kit network and subnet
+//   - the CreateSubnetTx is the one of the subnet, and its owners are the kit ones
+//   - the UTXOs are the ones consumed by the tx, and are owned by the fee payer
+//
+// Returns the decoded tx, and a signer backend with the kit data
+func (kit *SigningKit) Verify() (*txs.Tx, *KitSignerBackend, error) {
+	tx, err := kit.GetTx()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !NeedsSubnetAuth(tx) {
+		return nil, nil, fmt.Errorf("%w: unexpected unsigned tx type %T", ErrInvalidKit, tx.Unsigned)
+	}
+	network, err := GetNetwork(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if network.ID != kit.NetworkID {
+		return nil, nil, fmt.Errorf("%w: tx network ID %d differs from kit network ID %d", ErrInvalidKit, network.ID, kit.NetworkID)
+	}
+	subnetID, err := GetSubnetID(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subnetID != kit.SubnetID {
+		return nil, nil, fmt.Errorf("%w: tx subnet %s differs from kit subnet %s", ErrInvalidKit, subnetID, kit.SubnetID)
+	}
+	subnetTx, err := decodeTx(kit.SubnetTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the tx ID is the hash of its bytes, so the subnet tx can't be forged
+	if subnetTx.ID() != subnetID {
+		return nil, nil, fmt.Errorf("%w: subnet tx %s is not the one of subnet %s", ErrInvalidKit, subnetTx.ID(), subnetID)
+	}
+	controlKeys, threshold, err := GetSubnetTxOwners(kit.NetworkID, subnetTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !slices.Equal(controlKeys, kit.ControlKeys) || threshold != kit.Threshold {
+		return nil, nil, fmt.Errorf("%w: control keys or threshold differ from the ones of the subnet tx", ErrInvalidKit)
+	}
+	backend := &KitSignerBackend{
+		utxos: map[ids.ID]*lux.UTXO{},
+		txs:   map[ids.ID]*txs.Tx{subnetID: subnetTx},
+	}
+	consumed := map[ids.ID]*lux.TransferableInput{}
+	if baseTx, err := getBaseTx(tx); err == nil {
+		for _, in := range baseTx.Ins {
+			consumed[in.InputID()] = in
+		}
+	}
+	for _, utxoStr := range kit.UTXOs {
+		utxoBytes, err := formatting.Decode(formatting.Hex, utxoStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidKit, err)
+		}
+		var utxo lux.UTXO
+		if _, err := txs.Codec.Unmarshal(utxoBytes, &utxo); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidKit, err)
+		}
+		in, ok := consumed[utxo.InputID()]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: UTXO %s is not consumed by the tx", ErrInvalidKit, utxo.InputID())
+		}
+		out, ok := utxo.Out.(lux.TransferableOut)
+		if !ok || out.Amount() != in.In.Amount() || utxo.AssetID() != in.AssetID() {
+			return nil, nil, fmt.Errorf("%w: UTXO %s does not match the tx input", ErrInvalidKit, utxo.InputID())
+		}
+		// the owners decide which keys sign the input, so they can't be
+		// other than the fee payer ones the signers are shown
+		owners, err := getUTXOOwners(&utxo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidKit, err)
+		}
+		ownerAddrs, err := formatPChainAddrs(kit.NetworkID, owners.Addrs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !slices.Equal(ownerAddrs, kit.FeePayer) || owners.Threshold != kit.FeePayerThreshold {
+			return nil, nil, fmt.Errorf("%w: UTXO %s is not owned by the fee payer", ErrInvalidKit, utxo.InputID())
+		}
+		backend.utxos[utxo.InputID()] = &utxo
+	}
+	return tx, backend, nil
+}
+
+// KitSignerBackend serves the P-Chain signer with the data of a signing kit
+type KitSignerBackend struct {
+	utxos map[ids.ID]*lux.UTXO
+	txs   map[ids.ID]*txs.Tx
+}
+
+func (b *KitSignerBackend) GetUTXO(_ context.Context, _ ids.ID, utxoID ids.ID) (*lux.UTXO, error) {
+	utxo, ok := b.utxos[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (b *KitSignerBackend) GetTx(_ context.Context, txID ids.ID) (*txs.Tx, error) {
+	tx, ok := b.txs[txID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return tx, nil
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubnetTx(t *testing.T, threshold uint32, numOwners int) *txs.Tx {
+	owners := &secp256k1fx.OutputOwners{Threshold: threshold}
+	for i := 0; i < numOwners; i++ {
+		owners.Addrs = append(owners.Addrs, ids.GenerateTestShortID())
+	}
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{NetworkID: models.FujiNetwork.ID}},
+			Owner:  owners,
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+// returns a kit for a CreateChainTx on a new subnet, with its consumed UTXO
+func newTestSigningKit(t *testing.T) (*SigningKit, *txs.Tx, *lux.UTXO) {
+	require := require.New(t)
+
+	subnetTx := newTestSubnetTx(t, 2, 3)
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  lux.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1_000_000_000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+	tx := newTestCreateChainTx(1_000_000_000, 900_000_000, [][65]byte{{}, {}})
+	unsignedTx := tx.Unsigned.(*txs.CreateChainTx)
+	unsignedTx.SubnetID = subnetTx.ID()
+	unsignedTx.Ins[0].UTXOID = utxo.UTXOID
+	unsignedTx.Ins[0].Asset = utxo.Asset
+	require.NoError(tx.Initialize(txs.Codec))
+
+	kit, err := newSigningKit(models.FujiNetwork.ID, tx, subnetTx, []*lux.UTXO{utxo})
+	require.NoError(err)
+	return kit, tx, utxo
+}
+
+func TestSigningKitSaveLoad(t *testing.T) {
+	require := require.New(t)
+
+	kit, tx, utxo := newTestSigningKit(t)
+	require.Len(kit.ControlKeys, 3)
+	require.Equal(uint32(2), kit.Threshold)
+	require.Len(kit.FeePayer, 1)
+	require.Equal(uint32(1), kit.FeePayerThreshold)
+
+	kitPath := filepath.Join(t.TempDir(), "kit.json")
+	require.NoError(kit.Save(kitPath, false))
+	require.Error(kit.Save(kitPath, false))
+	require.True(IsSigningKitFile(kitPath))
+
+	loadedKit, err := LoadSigningKit(kitPath)
+	require.NoError(err)
+	require.Equal(kit, loadedKit)
+
+	verifiedTx, backend, err := loadedKit.Verify()
+	require.NoError(err)
+	require.Equal(tx.ID(), verifiedTx.ID())
+
+	ctx := context.Background()
+	gotUTXO, err := backend.GetUTXO(ctx, ids.Empty, utxo.InputID())
+	require.NoError(err)
+	require.Equal(utxo.InputID(), gotUTXO.InputID())
+	_, err = backend.GetUTXO(ctx, ids.Empty, ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+	subnetTx, err := backend.GetTx(ctx, kit.SubnetID)
+	require.NoError(err)
+	require.Equal(kit.SubnetID, subnetTx.ID())
+}
+
+func TestSigningKitTxFileIsNotKit(t *testing.T) {
+	require := require.New(t)
+
+	_, tx, _ := newTestSigningKit(t)
+	txPath := filepath.Join(t.TempDir(), "tx")
+	require.NoError(SaveToDisk(tx, txPath, false))
+	require.False(IsSigningKitFile(txPath))
+	_, err := LoadSigningKit(txPath)
+	require.ErrorIs(err, ErrNotSigningKit)
+}
+
+func TestSigningKitVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, kit *SigningKit)
+	}{
+		{
+			name: "control keys",
+			tamper: func(_ *testing.T, kit *SigningKit) {
+				kit.ControlKeys = kit.ControlKeys[:1]
+			},
+		},
+		{
+			name: "threshold",
+			tamper: func(_ *testing.T, kit *SigningKit) {
+				kit.Threshold = 1
+			},
+		},
+		{
+			name: "subnet tx",
+			tamper: func(t *testing.T, kit *SigningKit) {
+				otherKit, _, _ := newTestSigningKit(t)
+				kit.SubnetTx = otherKit.SubnetTx
+			},
+		},
+		{
+			name: "network",
+			tamper: func(_ *testing.T, kit *SigningKit) {
+				kit.NetworkID = models.MainnetNetwork.ID
+			},
+		},
+		{
+			name: "utxo",
+			tamper: func(t *testing.T, kit *SigningKit) {
+				otherKit, _, _ := newTestSigningKit(t)
+				kit.UTXOs = otherKit.UTXOs
+			},
+		},
+		{
+			name: "utxo owner",
+			tamper: func(t *testing.T, kit *SigningKit) {
+				// same UTXO and amount, but owned by someone else
+				utxoBytes, err := formatting.Decode(formatting.Hex, kit.UTXOs[0])
+				require.NoError(t, err)
+				var utxo lux.UTXO
+				_, err = txs.Codec.Unmarshal(utxoBytes, &utxo)
+				require.NoError(t, err)
+				utxo.Out.(*secp256k1fx.TransferOutput).Addrs = []ids.ShortID{ids.GenerateTestShortID()}
+				utxoBytes, err = txs.Codec.Marshal(txs.Version, &utxo)
+				require.NoError(t, err)
+				kit.UTXOs[0], err = formatting.Encode(formatting.Hex, utxoBytes)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "fee payer threshold",
+			tamper: func(_ *testing.T, kit *SigningKit) {
+				kit.FeePayerThreshold = 2
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kit, _, _ := newTestSigningKit(t)
+			tt.tamper(t, kit)
+			_, _, err := kit.Verify()
+			require.ErrorIs(t, err, ErrInvalidKit)
+		})
+	}
+}
+
+func TestSigningKitSetTx(t *testing.T) {
+	require := require.New(t)
+
+	kit, tx, _ := newTestSigningKit(t)
+	signedTx := &txs.Tx{
+		Unsigned: tx.Unsigned,
+		Creds: []verify.Verifiable{
+			tx.Creds[0],
+			&secp256k1fx.Credential{Sigs: [][65]byte{{1}, {}}},
+		},
+	}
+	require.NoError(kit.SetTx(signedTx))
+	verifiedTx, _, err := kit.Verify()
+	require.NoError(err)
+	_, remaining, err := GetRemainingSigners(verifiedTx, kit.ControlKeys)
+	require.NoError(err)
+	require.Len(remaining, 1)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+var (
+	ErrNothingToMerge      = errors.New("at least two txs are needed to merge")
+	ErrDifferentUnsignedTx = errors.New("txs to merge are not copies of the same unsigned tx")
+)
+
+// merges the signatures of [txList], copies of the same unsigned tx that
+// were signed independently, into a new tx
+//   - verifies that the unsigned bytes of all txs are identical
+//   - for each signature slot of each cred, takes the signature from
+//     the first tx where it is not empty
+//   - fails if two txs hold different signatures for the same slot
+func Merge(txList []*txs.Tx) (*txs.Tx, error) {
+	if len(txList) < 2 {
+		return nil, ErrNothingToMerge
+	}
+	base := txList[0]
+	emptySig := [secp256k1.SignatureLen]byte{}
+	creds := make([]verify.Verifiable, len(base.Creds))
+	for credIndex := range base.Creds {
+		cred, ok := base.Creds[credIndex].(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", base.Creds[credIndex])
+		}
+		sigs := make([][secp256k1.SignatureLen]byte, len(cred.Sigs))
+		copy(sigs, cred.Sigs)
+		creds[credIndex] = &secp256k1fx.Credential{Sigs: sigs}
+	}
+	for txIndex, tx := range txList[1:] {
+		if !bytes.Equal(base.Unsigned.Bytes(), tx.Unsigned.Bytes()) {
+			return nil, fmt.Errorf("%w: tx %d differs from tx 0", ErrDifferentUnsignedTx, txIndex+1)
+		}
+		if len(tx.Creds) != len(creds) {
+			return nil, fmt.Errorf("%w: tx %d has %d creds, expected %d", ErrDifferentUnsignedTx, txIndex+1, len(tx.Creds), len(creds))
+		}
+		for credIndex := range tx.Creds {
+			cred, ok := tx.Creds[credIndex].(*secp256k1fx.Credential)
+			if !ok {
+				return nil, fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", tx.Creds[credIndex])
+			}
+			mergedSigs := creds[credIndex].(*secp256k1fx.Credential).Sigs
+			if len(cred.Sigs) != len(mergedSigs) {
+				return nil, fmt.Errorf("%w: cred %d of tx %d has %d signatures, expected %d",
+					ErrDifferentUnsignedTx,
+					credIndex,
+					txIndex+1,
+					len(cred.Sigs),
+					len(mergedSigs),
+				)
+			}
+			for i, sig := range cred.Sigs {
+				switch {
+				case sig == emptySig:
+				case mergedSigs[i] == emptySig:
+					mergedSigs[i] = sig
+				case mergedSigs[i] != sig:
+					return nil, fmt.Errorf("conflicting signature %d of cred %d in tx %d", i, credIndex, txIndex+1)
+				}
+			}
+		}
+	}
+	merged := &txs.Tx{
+		Unsigned: base.Unsigned,
+		Creds:    creds,
+	}
+	if err := merged.Initialize(txs.Codec); err != nil {
+		return nil, fmt.Errorf("error initializing merged tx: %w", err)
+	}
+	return merged, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"testing"
+
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+// returns a copy of [tx] with the given subnet auth signatures
+func withSubnetAuthSigs(t *testing.T, tx *txs.Tx, sigs [][65]byte) *txs.Tx {
+	signedTx := &txs.Tx{
+		Unsigned: tx.Unsigned,
+		Creds: []verify.Verifiable{
+			tx.Creds[0],
+			&secp256k1fx.Credential{Sigs: sigs},
+		},
+	}
+	require.NoError(t, signedTx.Initialize(txs.Codec))
+	return signedTx
+}
+
+func TestMerge(t *testing.T) {
+	require := require.New(t)
+
+	tx := newTestCreateChainTx(2, 1, nil)
+	txA := withSubnetAuthSigs(t, tx, [][65]byte{{1}, {}})
+	txB := withSubnetAuthSigs(t, tx, [][65]byte{{}, {2}})
+
+	merged, err := Merge([]*txs.Tx{txA, txB})
+	require.NoError(err)
+	_, remaining, err := GetRemainingSigners(merged, []string{"a", "b", "c"})
+	require.NoError(err)
+	require.Empty(remaining)
+	require.Equal([][65]byte{{1}, {2}}, merged.Creds[1].(*secp256k1fx.Credential).Sigs)
+	// inputs are not modified
+	require.Equal([][65]byte{{1}, {}}, txA.Creds[1].(*secp256k1fx.Credential).Sigs)
+
+	txConflict := withSubnetAuthSigs(t, tx, [][65]byte{{3}, {}})
+	_, err = Merge([]*txs.Tx{txA, txConflict})
+	require.ErrorContains(err, "conflicting signature")
+
+	otherTx := withSubnetAuthSigs(t, newTestCreateChainTx(2, 1, nil), [][65]byte{{}, {2}})
+	_, err = Merge([]*txs.Tx{txA, otherTx})
+	require.ErrorIs(err, ErrDifferentUnsignedTx)
+
+	_, err = Merge([]*txs.Tx{txA})
+	require.ErrorIs(err, ErrNothingToMerge)
+}
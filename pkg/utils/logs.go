@@ -0,0 +1,168 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// node logs start with a timestamp without year, eg [08-25|14:03:12.345]
+const logTimestampLayout = "[01-02|15:04:05.000]"
+
+// LogSource is a log file, whose lines are prefixed with [Prefix] when printed
+type LogSource struct {
+	Prefix string
+	Path   string
+}
+
+// LogLine is a log entry, that may span several lines, eg a stack trace
+type LogLine struct {
+	Prefix string
+	Time   time.Time
+	Text   string
+}
+
+// ParseLogTimestamp parses the timestamp at the start of a node log line. As it
+// does not include the year, the one of [now] is used
+func ParseLogTimestamp(line string, now time.Time) (time.Time, bool) {
+	if len(line) < len(logTimestampLayout) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(logTimestampLayout, line[:len(logTimestampLayout)], now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	t = t.AddDate(now.Year(), 0, 0)
+	// logs of the last days of the previous year
+	if t.After(now.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t, true
+}
+
+// LogTailer reads the lines added to a set of log files since its last read
+type LogTailer struct {
+	sources []LogSource
+	offsets []int64
+	// last entry of each source, that may still get continuation lines
+	pending []*LogLine
+	// time of the last entry of each source, for lines read without it
+	lastTimes []time.Time
+	re        *regexp.Regexp
+	since     time.Time
+	now       func() time.Time
+}
+
+func NewLogTailer(sources []LogSource) *LogTailer {
+	return &LogTailer{
+		sources:   sources,
+		offsets:   make([]int64, len(sources)),
+		pending:   make([]*LogLine, len(sources)),
+		lastTimes: make([]time.Time, len(sources)),
+		now:       time.Now,
+	}
+}
+
+// SetFilter makes the tailer only return the entries logged at or after [since],
+// if not zero, that match [re], if not nil. Other entries are dropped while
+// reading, so they are not kept in memory
+func (t *LogTailer) SetFilter(re *regexp.Regexp, since time.Time) {
+	t.re = re
+	t.since = since
+}
+
+// Read returns the complete log entries added since the last read, merged by
+// timestamp. Entries of a source are only returned once its next entry starts,
+// or when [flush] is true. Missing files are skipped, as nodes may not have
+// created them yet
+func (t *LogTailer) Read(flush bool) ([]LogLine, error) {
+	lines := []LogLine{}
+	addLine := func(line LogLine) {
+		if matchLogLine(line, t.re, t.since) {
+			lines = append(lines, line)
+		}
+	}
+	now := t.now()
+	for i, source := range t.sources {
+		err := t.readSource(i, func(text string) {
+			ts, ok := ParseLogTimestamp(text, now)
+			switch {
+			case ok:
+				if t.pending[i] != nil {
+					addLine(*t.pending[i])
+				}
+				t.pending[i] = &LogLine{Prefix: source.Prefix, Time: ts, Text: text}
+				t.lastTimes[i] = ts
+			case t.pending[i] != nil:
+				t.pending[i].Text += "\n" + text
+			default:
+				// continuation of an entry already returned, or a file not
+				// starting with a timestamp
+				t.pending[i] = &LogLine{Prefix: source.Prefix, Time: t.lastTimes[i], Text: text}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if flush && t.pending[i] != nil {
+			addLine(*t.pending[i])
+			t.pending[i] = nil
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+	return lines, nil
+}
+
+// streams the complete lines added to source [i] since the last read to [handleLine]
+func (t *LogTailer) readSource(i int, handleLine func(string)) error {
+	f, err := os.Open(t.sources[i].Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// the file was truncated or rotated
+	if info.Size() < t.offsets[i] {
+		t.offsets[i] = 0
+	}
+	if _, err := f.Seek(t.offsets[i], io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			// an incomplete last line is read again on the next read
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		t.offsets[i] += int64(len(line))
+		handleLine(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+	}
+}
+
+// returns true if [line] was logged at or after [since], if not zero, and
+// matches [re], if not nil
+func matchLogLine(line LogLine, re *regexp.Regexp, since time.Time) bool {
+	if !since.IsZero() && !line.Time.IsZero() && line.Time.Before(since) {
+		return false
+	}
+	return re == nil || re.MatchString(line.Text)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	ts, ok := ParseLogTimestamp("[01-02|09:30:15.250] INFO <C Chain> started", now)
+	require.True(ok)
+	require.Equal(time.Date(2023, 1, 2, 9, 30, 15, 250_000_000, time.UTC), ts)
+
+	// logged at the end of the previous year
+	ts, ok = ParseLogTimestamp("[12-31|23:59:59.000] INFO done", now)
+	require.True(ok)
+	require.Equal(2022, ts.Year())
+
+	_, ok = ParseLogTimestamp("goroutine 1 [running]:", now)
+	require.False(ok)
+}
+
+func TestLogTailer(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	node1Log := filepath.Join(dir, "node1.log")
+	node2Log := filepath.Join(dir, "node2.log")
+	require.NoError(os.WriteFile(node1Log, []byte(
+		"[01-02|09:00:01.000] INFO first\n"+
+			"[01-02|09:00:03.000] ERROR third\n"+
+			"stack trace\n"+
+			"[01-02|09:00:04.000] INFO incomplete"), 0o600))
+	require.NoError(os.WriteFile(node2Log, []byte("[01-02|09:00:02.000] INFO second\n"), 0o600))
+
+	tailer := NewLogTailer([]LogSource{
+		{Prefix: "node1", Path: node1Log},
+		{Prefix: "node2", Path: node2Log},
+		{Prefix: "node3", Path: filepath.Join(dir, "missing.log")},
+	})
+	tailer.now = func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) }
+
+	lines, err := tailer.Read(true)
+	require.NoError(err)
+	require.Len(lines, 3)
+	require.Equal("node1", lines[0].Prefix)
+	require.Equal("node2", lines[1].Prefix)
+	require.Equal("[01-02|09:00:03.000] ERROR third\nstack trace", lines[2].Text)
+
+	lines, err = tailer.Read(true)
+	require.NoError(err)
+	require.Empty(lines)
+
+	f, err := os.OpenFile(node1Log, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(err)
+	_, err = f.WriteString(" line\n")
+	require.NoError(err)
+	require.NoError(f.Close())
+	lines, err = tailer.Read(true)
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal("[01-02|09:00:04.000] INFO incomplete line", lines[0].Text)
+
+	re := regexp.MustCompile("ERROR")
+	require.NoError(os.WriteFile(node2Log, []byte(
+		"[01-02|09:00:00.000] ERROR old\n"+
+			"[01-02|09:50:00.000] ERROR new\n"+
+			"stack trace\n"+
+			"[01-02|09:50:00.000] INFO new\n"), 0o600))
+	tailer = NewLogTailer([]LogSource{{Prefix: "node2", Path: node2Log}})
+	tailer.now = func() time.Time { return time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC) }
+	tailer.SetFilter(re, time.Date(2023, 1, 2, 9, 30, 0, 0, time.UTC))
+	lines, err = tailer.Read(true)
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal("[01-02|09:50:00.000] ERROR new\nstack trace", lines[0].Text)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package ux
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat selects how listing and describe commands render their results
+type OutputFormat string
+
+const (
+	TableOutput OutputFormat = "table"
+	JSONOutput  OutputFormat = "json"
+	YAMLOutput  OutputFormat = "yaml"
+
+	// OutputDocumentVersion is bumped whenever a structured document
+	// changes in a non backwards compatible way
+	OutputDocumentVersion = "v1"
+)
+
+// OutputDocument is the envelope of every structured (json/yaml) output,
+// so that scripts can check what they are parsing before looking at the data
+type OutputDocument struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Data       any    `json:"data"`
+}
+
+// ParseOutputFormat validates the value given to the --output flag
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch f := OutputFormat(s); f {
+	case TableOutput, JSONOutput, YAMLOutput:
+		return f, nil
+	case "":
+		return TableOutput, nil
+	}
+	return "", fmt.Errorf("invalid output format %q: must be one of %s, %s, %s", s, TableOutput, JSONOutput, YAMLOutput)
+}
+
+// IsStructured returns true if the output is meant to be consumed by a program
+// instead of a human
+func (f OutputFormat) IsStructured() bool {
+	return f == JSONOutput || f == YAMLOutput
+}
+
+// PrintDocument wraps data into a versioned OutputDocument of the given kind
+// and writes it to w using the given format
+func PrintDocument(w io.Writer, format OutputFormat, kind string, data any) error {
+	doc := OutputDocument{
+		APIVersion: OutputDocumentVersion,
+		Kind:       kind,
+		Data:       data,
+	}
+	// json is always generated first, so json and yaml documents
+	// share the same field names and ordering
+	jsonBytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case JSONOutput:
+		_, err = fmt.Fprintln(w, string(jsonBytes))
+		return err
+	case YAMLOutput:
+		var node yaml.Node
+		if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+			return err
+		}
+		resetYAMLStyle(&node)
+		yamlBytes, err := yaml.Marshal(&node)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(yamlBytes)
+		return err
+	}
+	return fmt.Errorf("output format %q is not a structured format", format)
+}
+
+// resetYAMLStyle drops the flow/quoted styles inherited from the json
+// source, so the result looks like regular block yaml
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package ux
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type testEntry struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Label   string `json:"label"`
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	require := require.New(t)
+
+	f, err := ParseOutputFormat("")
+	require.NoError(err)
+	require.Equal(TableOutput, f)
+	require.False(f.IsStructured())
+
+	f, err = ParseOutputFormat("json")
+	require.NoError(err)
+	require.True(f.IsStructured())
+
+	f, err = ParseOutputFormat("yaml")
+	require.NoError(err)
+	require.True(f.IsStructured())
+
+	_, err = ParseOutputFormat("xml")
+	require.Error(err)
+}
+
+func TestPrintDocument(t *testing.T) {
+	require := require.New(t)
+
+	data := []testEntry{{Name: "mySubnet", Enabled: true, Label: "true"}}
+
+	var out bytes.Buffer
+	require.NoError(PrintDocument(&out, JSONOutput, "SubnetList", data))
+	var jsonDoc struct {
+		APIVersion string      `json:"apiVersion"`
+		Kind       string      `json:"kind"`
+		Data       []testEntry `json:"data"`
+	}
+	require.NoError(json.Unmarshal(out.Bytes(), &jsonDoc))
+	require.Equal(OutputDocumentVersion, jsonDoc.APIVersion)
+	require.Equal("SubnetList", jsonDoc.Kind)
+	require.Equal(data, jsonDoc.Data)
+
+	out.Reset()
+	require.NoError(PrintDocument(&out, YAMLOutput, "SubnetList", data))
+	require.NotContains(out.String(), "{")
+	var yamlDoc struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Data       []struct {
+			Name    string `yaml:"name"`
+			Enabled bool   `yaml:"enabled"`
+			Label   string `yaml:"label"`
+		} `yaml:"data"`
+	}
+	require.NoError(yaml.Unmarshal(out.Bytes(), &yamlDoc))
+	require.Equal(OutputDocumentVersion, yamlDoc.APIVersion)
+	require.Equal("mySubnet", yamlDoc.Data[0].Name)
+	require.True(yamlDoc.Data[0].Enabled)
+	// string values that look like other types must stay strings
+	require.Equal("true", yamlDoc.Data[0].Label)
+
+	require.Error(PrintDocument(&out, TableOutput, "SubnetList", data))
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/statemachine"
 	"github.com/luxdefi/subnet-evm/core"
 	"github.com/ethereum/go-ethereum/common"
@@ -45,7 +46,7 @@ func getAllocation(
 		[]string{defaultAirdrop, customAirdrop, goBackMsg},
 	)
 	if err != nil {
-		return allocation, statemachine.Stop, err
+		return allocation, statemachine.Stop, prompts.WithHint(err, specHint("allocations"))
 	}
 
 	if airdropType == defaultAirdrop {
@@ -62,12 +63,12 @@ func getAllocation(
 	for {
 		addressHex, err = app.Prompt.CaptureAddress("Address to airdrop to")
 		if err != nil {
-			return nil, statemachine.Stop, err
+			return nil, statemachine.Stop, prompts.WithHint(err, specHint("allocations.address"))
 		}
 
 		amount, err := app.Prompt.CapturePositiveBigInt(captureAmountLabel)
 		if err != nil {
-			return nil, statemachine.Stop, err
+			return nil, statemachine.Stop, prompts.WithHint(err, specHint("allocations.balance"))
 		}
 
 		amount = amount.Mul(amount, multiplier)
@@ -82,7 +83,7 @@ func getAllocation(
 
 		continueAirdrop, err := app.Prompt.CaptureNoYes(extendAirdrop)
 		if err != nil {
-			return nil, statemachine.Stop, err
+			return nil, statemachine.Stop, prompts.WithHint(err, specHint("allocations"))
 		}
 		if !continueAirdrop {
 			return allocation, statemachine.Forward, nil
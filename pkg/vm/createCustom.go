@@ -71,7 +71,7 @@ func loadCustomGenesis(app *application.Lux, genesisPath string) ([]byte, error)
 	if genesisPath == "" {
 		genesisPath, err = app.Prompt.CaptureExistingFilepath("Enter path to custom genesis")
 		if err != nil {
-			return nil, err
+			return nil, prompts.WithHint(err, "--genesis")
 		}
 	}
 
@@ -91,7 +91,7 @@ func SetCustomVMSourceCodeFields(app *application.Lux, sc *models.Sidecar, custo
 	if customVMRepoURL == "" {
 		customVMRepoURL, err = app.Prompt.CaptureURL("Source code repository URL")
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--custom-vm-repo-url")
 		}
 	}
 	if customVMBranch != "" {
@@ -104,7 +104,7 @@ func SetCustomVMSourceCodeFields(app *application.Lux, sc *models.Sidecar, custo
 	if customVMBranch == "" {
 		customVMBranch, err = app.Prompt.CaptureRepoBranch("Branch", customVMRepoURL)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--custom-vm-branch")
 		}
 	}
 	if customVMBuildScript != "" {
@@ -117,7 +117,7 @@ func SetCustomVMSourceCodeFields(app *application.Lux, sc *models.Sidecar, custo
 	if customVMBuildScript == "" {
 		customVMBuildScript, err = app.Prompt.CaptureRepoFile("Build script", customVMRepoURL, customVMBranch)
 		if err != nil {
-			return err
+			return prompts.WithHint(err, "--custom-vm-build-script")
 		}
 	}
 	sc.CustomVMRepoURL = customVMRepoURL
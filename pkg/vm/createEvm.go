@@ -13,6 +13,7 @@ import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/constants"
 	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/statemachine"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/subnet-evm/core"
@@ -42,7 +43,7 @@ func CreateEvmSubnetConfig(app *application.Lux, subnetName string, genesisPath
 
 		subnetEVMVersion, err = getVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, subnetEVMVersion, false)
 		if err != nil {
-			return nil, &models.Sidecar{}, err
+			return nil, &models.Sidecar{}, prompts.WithHint(err, "--vm-version or --latest")
 		}
 
 		rpcVersion, err := GetRPCProtocolVersion(app, models.SubnetEvm, subnetEVMVersion)
@@ -70,7 +71,6 @@ func createEvmGenesis(
 ) ([]byte, *models.Sidecar, error) {
 	ux.Logger.PrintToUser("creating subnet %s", subnetName)
 
-	genesis := core.Genesis{}
 	conf := params.SubnetEVMDefaultChainConfig
 
 	const (
@@ -114,6 +114,23 @@ func createEvmGenesis(
 		subnetEvmState.NextState(direction)
 	}
 
+	conf.ChainID = chainID
+
+	return buildEvmGenesis(app, subnetName, tokenName, vmVersion, conf, allocation)
+}
+
+// buildEvmGenesis validates and serializes the genesis, and creates the
+// sidecar for a Subnet-EVM subnet
+func buildEvmGenesis(
+	app *application.Lux,
+	subnetName string,
+	tokenName string,
+	vmVersion string,
+	conf *params.ChainConfig,
+	allocation core.GenesisAlloc,
+) ([]byte, *models.Sidecar, error) {
+	genesis := core.Genesis{}
+
 	if conf != nil && conf.GenesisPrecompiles[txallowlist.ConfigKey] != nil {
 		allowListCfg, ok := conf.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
 		if !ok {
@@ -127,8 +144,6 @@ func createEvmGenesis(
 		}
 	}
 
-	conf.ChainID = chainID
-
 	genesis.Alloc = allocation
 	genesis.Config = conf
 	genesis.Difficulty = Difficulty
@@ -10,20 +10,22 @@ import (
 	"github.com/luxdefi/cli/pkg/application"
 	"github.com/luxdefi/cli/pkg/binutils"
 	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/statemachine"
 	"github.com/luxdefi/cli/pkg/ux"
 )
 
 func getChainID(app *application.Lux) (*big.Int, error) {
 	ux.Logger.PrintToUser("Enter your subnet's ChainId. It can be any positive integer.")
-	return app.Prompt.CapturePositiveBigInt("ChainId")
+	chainID, err := app.Prompt.CapturePositiveBigInt("ChainId")
+	return chainID, prompts.WithHint(err, specHint("chainId"))
 }
 
 func getTokenName(app *application.Lux) (string, error) {
 	ux.Logger.PrintToUser("Select a symbol for your subnet's native token")
 	tokenName, err := app.Prompt.CaptureString("Token symbol")
 	if err != nil {
-		return "", err
+		return "", prompts.WithHint(err, specHint("tokenName"))
 	}
 
 	return tokenName, nil
@@ -76,7 +78,7 @@ func askForVMVersion(
 		versionOptions,
 	)
 	if err != nil {
-		return "", statemachine.Stop, err
+		return "", statemachine.Stop, prompts.WithHint(err, "--vm-version or --latest")
 	}
 
 	if versionOption == goBackMsg {
@@ -99,7 +101,7 @@ func askForVMVersion(
 	}
 	version, err := app.Prompt.CaptureList("Pick the version for this VM", versions)
 	if err != nil {
-		return "", statemachine.Stop, err
+		return "", statemachine.Stop, prompts.WithHint(err, "--vm-version")
 	}
 
 	return version, statemachine.Forward, nil
@@ -124,7 +126,7 @@ func getDescriptors(app *application.Lux, subnetEVMVersion string) (
 
 	subnetEVMVersion, err = getVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, subnetEVMVersion, false)
 	if err != nil {
-		return nil, "", "", statemachine.Stop, err
+		return nil, "", "", statemachine.Stop, prompts.WithHint(err, "--vm-version or --latest")
 	}
 
 	return chainID, tokenName, subnetEVMVersion, statemachine.Forward, nil
@@ -0,0 +1,361 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/constants"
+	"github.com/luxdefi/cli/pkg/models"
+	"github.com/luxdefi/cli/pkg/prompts"
+	"github.com/luxdefi/cli/pkg/ux"
+	"github.com/luxdefi/subnet-evm/commontype"
+	"github.com/luxdefi/subnet-evm/core"
+	"github.com/luxdefi/subnet-evm/params"
+	"github.com/luxdefi/subnet-evm/precompile/allowlist"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/feemanager"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/luxdefi/subnet-evm/precompile/precompileconfig"
+	"github.com/luxdefi/subnet-evm/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LowFeePreset    = "low"
+	MediumFeePreset = "medium"
+	HighFeePreset   = "high"
+	CustomFeePreset = "custom"
+)
+
+// EvmSpec is the declarative equivalent of the answers given to the
+// Subnet-EVM creation wizard. It can be written either in yaml or json.
+type EvmSpec struct {
+	VMVersion   string              `yaml:"vmVersion,omitempty"`
+	ChainID     uint64              `yaml:"chainId"`
+	TokenName   string              `yaml:"tokenName"`
+	Fee         EvmFeeSpec          `yaml:"fee"`
+	Allocations []EvmAllocationSpec `yaml:"allocations,omitempty"`
+	Precompiles EvmPrecompilesSpec  `yaml:"precompiles,omitempty"`
+}
+
+// EvmFeeSpec either selects one of the wizard presets (low, medium, high)
+// or, with the custom preset, gives every fee parameter
+type EvmFeeSpec struct {
+	Preset                   string  `yaml:"preset"`
+	GasLimit                 *uint64 `yaml:"gasLimit,omitempty"`
+	TargetBlockRate          *uint64 `yaml:"targetBlockRate,omitempty"`
+	MinBaseFee               *uint64 `yaml:"minBaseFee,omitempty"`
+	TargetGas                *uint64 `yaml:"targetGas,omitempty"`
+	BaseFeeChangeDenominator *uint64 `yaml:"baseFeeChangeDenominator,omitempty"`
+	MinBlockGasCost          *uint64 `yaml:"minBlockGasCost,omitempty"`
+	MaxBlockGasCost          *uint64 `yaml:"maxBlockGasCost,omitempty"`
+	BlockGasCostStep         *uint64 `yaml:"blockGasCostStep,omitempty"`
+}
+
+// EvmAllocationSpec airdrops Balance LUX (not wei) to Address at genesis
+type EvmAllocationSpec struct {
+	Address string `yaml:"address"`
+	Balance string `yaml:"balance"`
+}
+
+type EvmAllowListSpec struct {
+	AdminAddresses   []string `yaml:"adminAddresses,omitempty"`
+	EnabledAddresses []string `yaml:"enabledAddresses,omitempty"`
+}
+
+// EvmRewardManagerSpec burns fees by default, unless either
+// AllowFeeRecipients or RewardAddress are given
+type EvmRewardManagerSpec struct {
+	EvmAllowListSpec   `yaml:",inline"`
+	AllowFeeRecipients bool   `yaml:"allowFeeRecipients,omitempty"`
+	RewardAddress      string `yaml:"rewardAddress,omitempty"`
+}
+
+// EvmPrecompilesSpec enables the precompiles that are not nil
+type EvmPrecompilesSpec struct {
+	NativeMinter      *EvmAllowListSpec     `yaml:"nativeMinter,omitempty"`
+	ContractAllowList *EvmAllowListSpec     `yaml:"contractAllowList,omitempty"`
+	TxAllowList       *EvmAllowListSpec     `yaml:"txAllowList,omitempty"`
+	FeeManager        *EvmAllowListSpec     `yaml:"feeManager,omitempty"`
+	RewardManager     *EvmRewardManagerSpec `yaml:"rewardManager,omitempty"`
+}
+
+// specHint names the spec field that answers a wizard prompt, for the errors
+// of the non interactive mode
+func specHint(field string) string {
+	return "the " + field + " field of a --from-spec file"
+}
+
+// LoadEvmSpec reads a spec file, rejecting unknown fields so that typos
+// don't silently fall back to defaults
+func LoadEvmSpec(specPath string) (*EvmSpec, error) {
+	specBytes, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEvmSpec(specBytes)
+}
+
+func ParseEvmSpec(specBytes []byte) (*EvmSpec, error) {
+	spec := &EvmSpec{}
+	decoder := yaml.NewDecoder(bytes.NewReader(specBytes))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("invalid subnet spec: %w", err)
+	}
+	return spec, nil
+}
+
+// CreateEvmSubnetConfigFromSpec builds the same genesis and sidecar as the
+// creation wizard, taking every answer from spec. subnetEVMVersion is only
+// used if the spec does not set vmVersion.
+func CreateEvmSubnetConfigFromSpec(
+	app *application.Lux,
+	subnetName string,
+	spec *EvmSpec,
+	subnetEVMVersion string,
+) ([]byte, *models.Sidecar, error) {
+	ux.Logger.PrintToUser("creating subnet %s from spec", subnetName)
+
+	if spec.TokenName == "" {
+		return nil, nil, errors.New("subnet spec: tokenName is required")
+	}
+	if spec.VMVersion != "" {
+		subnetEVMVersion = spec.VMVersion
+	}
+	vmVersion, err := getVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, subnetEVMVersion, false)
+	if err != nil {
+		return nil, nil, prompts.WithHint(err, "the vmVersion spec field, --vm-version or --latest")
+	}
+
+	conf, allocation, err := spec.ToGenesisParams()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buildEvmGenesis(app, subnetName, spec.TokenName, vmVersion, conf, allocation)
+}
+
+// ToGenesisParams validates the spec, and converts it into the chain config and
+// genesis allocation that the wizard would have generated
+func (spec *EvmSpec) ToGenesisParams() (*params.ChainConfig, core.GenesisAlloc, error) {
+	if spec.ChainID == 0 {
+		return nil, nil, errors.New("subnet spec: chainId is required and must be positive")
+	}
+
+	conf := *params.SubnetEVMDefaultChainConfig
+	conf.GenesisPrecompiles = params.Precompiles{}
+	conf.ChainID = new(big.Int).SetUint64(spec.ChainID)
+
+	feeConfig, err := spec.Fee.toFeeConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	conf.FeeConfig = feeConfig
+
+	allocation, err := spec.toAllocation()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := spec.Precompiles.addTo(conf.GenesisPrecompiles); err != nil {
+		return nil, nil, err
+	}
+
+	return &conf, allocation, nil
+}
+
+func (fee EvmFeeSpec) toFeeConfig() (commontype.FeeConfig, error) {
+	feeConfig := StarterFeeConfig
+	switch fee.Preset {
+	case LowFeePreset:
+		feeConfig.TargetGas = slowTarget
+	case MediumFeePreset:
+		feeConfig.TargetGas = mediumTarget
+	case HighFeePreset:
+		feeConfig.TargetGas = fastTarget
+	case CustomFeePreset:
+		feeParams := []struct {
+			name  string
+			value *uint64
+		}{
+			{"gasLimit", fee.GasLimit},
+			{"targetBlockRate", fee.TargetBlockRate},
+			{"minBaseFee", fee.MinBaseFee},
+			{"targetGas", fee.TargetGas},
+			{"baseFeeChangeDenominator", fee.BaseFeeChangeDenominator},
+			{"minBlockGasCost", fee.MinBlockGasCost},
+			{"maxBlockGasCost", fee.MaxBlockGasCost},
+			{"blockGasCostStep", fee.BlockGasCostStep},
+		}
+		for _, p := range feeParams {
+			if p.value == nil {
+				return feeConfig, fmt.Errorf("subnet spec: fee.%s is required for the %s fee preset", p.name, CustomFeePreset)
+			}
+		}
+		feeConfig = commontype.FeeConfig{
+			GasLimit:                 new(big.Int).SetUint64(*fee.GasLimit),
+			TargetBlockRate:          *fee.TargetBlockRate,
+			MinBaseFee:               new(big.Int).SetUint64(*fee.MinBaseFee),
+			TargetGas:                new(big.Int).SetUint64(*fee.TargetGas),
+			BaseFeeChangeDenominator: new(big.Int).SetUint64(*fee.BaseFeeChangeDenominator),
+			MinBlockGasCost:          new(big.Int).SetUint64(*fee.MinBlockGasCost),
+			MaxBlockGasCost:          new(big.Int).SetUint64(*fee.MaxBlockGasCost),
+			BlockGasCostStep:         new(big.Int).SetUint64(*fee.BlockGasCostStep),
+		}
+	case "":
+		return feeConfig, fmt.Errorf("subnet spec: fee.preset is required, must be one of %s, %s, %s, %s",
+			LowFeePreset, MediumFeePreset, HighFeePreset, CustomFeePreset)
+	default:
+		return feeConfig, fmt.Errorf("subnet spec: invalid fee.preset %q, must be one of %s, %s, %s, %s",
+			fee.Preset, LowFeePreset, MediumFeePreset, HighFeePreset, CustomFeePreset)
+	}
+	if fee.Preset != CustomFeePreset && fee != (EvmFeeSpec{Preset: fee.Preset}) {
+		return feeConfig, fmt.Errorf("subnet spec: fee parameters can only be given with the %s fee preset", CustomFeePreset)
+	}
+	return feeConfig, nil
+}
+
+// toAllocation defaults to the wizard's default airdrop if no allocations are given
+func (spec *EvmSpec) toAllocation() (core.GenesisAlloc, error) {
+	if len(spec.Allocations) == 0 {
+		ux.Logger.PrintToUser("No allocations given, airdropping 1 million tokens to the default address (do not use in production)")
+		return getDefaultAllocation(defaultEvmAirdropAmount)
+	}
+	allocation := core.GenesisAlloc{}
+	for i, alloc := range spec.Allocations {
+		address, err := parseSpecAddress(fmt.Sprintf("allocations[%d].address", i), alloc.Address)
+		if err != nil {
+			return nil, err
+		}
+		amount, ok := new(big.Int).SetString(alloc.Balance, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("subnet spec: allocations[%d].balance must be a positive integer amount of LUX, got %q", i, alloc.Balance)
+		}
+		if _, ok := allocation[address]; ok {
+			return nil, fmt.Errorf("subnet spec: allocations[%d].address %s is duplicated", i, address.Hex())
+		}
+		allocation[address] = core.GenesisAccount{
+			Balance: amount.Mul(amount, oneLux),
+		}
+	}
+	return allocation, nil
+}
+
+func (p EvmPrecompilesSpec) addTo(precompiles params.Precompiles) error {
+	if p.NativeMinter != nil {
+		allowList, err := p.NativeMinter.toAllowListConfig("precompiles.nativeMinter")
+		if err != nil {
+			return err
+		}
+		precompiles[nativeminter.ConfigKey] = &nativeminter.Config{
+			AllowListConfig: allowList,
+			Upgrade:         genesisUpgrade(),
+		}
+	}
+	if p.ContractAllowList != nil {
+		allowList, err := p.ContractAllowList.toAllowListConfig("precompiles.contractAllowList")
+		if err != nil {
+			return err
+		}
+		precompiles[deployerallowlist.ConfigKey] = &deployerallowlist.Config{
+			AllowListConfig: allowList,
+			Upgrade:         genesisUpgrade(),
+		}
+	}
+	if p.TxAllowList != nil {
+		allowList, err := p.TxAllowList.toAllowListConfig("precompiles.txAllowList")
+		if err != nil {
+			return err
+		}
+		precompiles[txallowlist.ConfigKey] = &txallowlist.Config{
+			AllowListConfig: allowList,
+			Upgrade:         genesisUpgrade(),
+		}
+	}
+	if p.FeeManager != nil {
+		allowList, err := p.FeeManager.toAllowListConfig("precompiles.feeManager")
+		if err != nil {
+			return err
+		}
+		precompiles[feemanager.ConfigKey] = &feemanager.Config{
+			AllowListConfig: allowList,
+			Upgrade:         genesisUpgrade(),
+		}
+	}
+	if p.RewardManager != nil {
+		allowList, err := p.RewardManager.toAllowListConfig("precompiles.rewardManager")
+		if err != nil {
+			return err
+		}
+		initialRewardConfig := &rewardmanager.InitialRewardConfig{
+			AllowFeeRecipients: p.RewardManager.AllowFeeRecipients,
+		}
+		if p.RewardManager.RewardAddress != "" {
+			if p.RewardManager.AllowFeeRecipients {
+				return errors.New("subnet spec: precompiles.rewardManager accepts either allowFeeRecipients or rewardAddress, not both")
+			}
+			initialRewardConfig.RewardAddress, err = parseSpecAddress("precompiles.rewardManager.rewardAddress", p.RewardManager.RewardAddress)
+			if err != nil {
+				return err
+			}
+		}
+		precompiles[rewardmanager.ConfigKey] = &rewardmanager.Config{
+			AllowListConfig:     allowList,
+			Upgrade:             genesisUpgrade(),
+			InitialRewardConfig: initialRewardConfig,
+		}
+	}
+	return nil
+}
+
+// toAllowListConfig requires at least one admin, as the wizard does
+func (a EvmAllowListSpec) toAllowListConfig(field string) (allowlist.AllowListConfig, error) {
+	config := allowlist.AllowListConfig{}
+	if len(a.AdminAddresses) == 0 {
+		return config, fmt.Errorf("subnet spec: %s.adminAddresses needs at least one address", field)
+	}
+	for i, addr := range a.AdminAddresses {
+		address, err := parseSpecAddress(fmt.Sprintf("%s.adminAddresses[%d]", field, i), addr)
+		if err != nil {
+			return config, err
+		}
+		config.AdminAddresses = append(config.AdminAddresses, address)
+	}
+	for i, addr := range a.EnabledAddresses {
+		address, err := parseSpecAddress(fmt.Sprintf("%s.enabledAddresses[%d]", field, i), addr)
+		if err != nil {
+			return config, err
+		}
+		for _, admin := range config.AdminAddresses {
+			if admin == address {
+				return config, fmt.Errorf("subnet spec: %s can't have address %s in both admin and enabled addresses", field, address.Hex())
+			}
+		}
+		config.EnabledAddresses = append(config.EnabledAddresses, address)
+	}
+	return config, nil
+}
+
+func parseSpecAddress(field string, addr string) (common.Address, error) {
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, fmt.Errorf("subnet spec: %s is not a valid hex address: %q", field, addr)
+	}
+	return common.HexToAddress(addr), nil
+}
+
+func genesisUpgrade() precompileconfig.Upgrade {
+	return precompileconfig.Upgrade{
+		BlockTimestamp: utils.NewUint64(0),
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2022, Lux Partners Limited, All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/luxdefi/subnet-evm/params"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/luxdefi/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+vmVersion: v0.5.3
+chainId: 12345
+tokenName: TST
+fee:
+  preset: medium
+allocations:
+  - address: "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+    balance: 1000
+precompiles:
+  txAllowList:
+    adminAddresses: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]
+  nativeMinter:
+    adminAddresses: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]
+  rewardManager:
+    adminAddresses: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]
+    allowFeeRecipients: true
+`
+
+func TestEvmSpecToGenesisParams(t *testing.T) {
+	require := require.New(t)
+
+	spec, err := ParseEvmSpec([]byte(testSpec))
+	require.NoError(err)
+	require.Equal("v0.5.3", spec.VMVersion)
+	require.Equal("TST", spec.TokenName)
+
+	conf, alloc, err := spec.ToGenesisParams()
+	require.NoError(err)
+	require.Equal(big.NewInt(12345), conf.ChainID)
+	require.Equal(mediumTarget, conf.FeeConfig.TargetGas)
+	require.Equal(StarterFeeConfig.GasLimit, conf.FeeConfig.GasLimit)
+
+	require.Len(alloc, 1)
+	expectedBalance := new(big.Int).Mul(big.NewInt(1000), oneLux)
+	require.Equal(expectedBalance, alloc[PrefundedEwoqAddress].Balance)
+
+	require.Len(conf.GenesisPrecompiles, 3)
+	txConfig, ok := conf.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{PrefundedEwoqAddress}, txConfig.AdminAddresses)
+	require.Equal(uint64(0), *txConfig.BlockTimestamp)
+	require.NotNil(conf.GenesisPrecompiles[nativeminter.ConfigKey])
+	rewardConfig, ok := conf.GenesisPrecompiles[rewardmanager.ConfigKey].(*rewardmanager.Config)
+	require.True(ok)
+	require.True(rewardConfig.InitialRewardConfig.AllowFeeRecipients)
+
+	// the default chain config must not be modified
+	require.Empty(params.SubnetEVMDefaultChainConfig.GenesisPrecompiles)
+}
+
+func TestEvmSpecValidation(t *testing.T) {
+	type test struct {
+		name          string
+		spec          string
+		errorContains string
+	}
+	tests := []test{
+		{
+			name:          "unknown field",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: low\nchainID: 2\n",
+			errorContains: "chainID",
+		},
+		{
+			name:          "missing chain id",
+			spec:          "tokenName: TST\nfee:\n  preset: low\n",
+			errorContains: "chainId",
+		},
+		{
+			name:          "invalid fee preset",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: fastest\n",
+			errorContains: "fee.preset",
+		},
+		{
+			name:          "custom fee with missing parameter",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: custom\n  gasLimit: 8000000\n",
+			errorContains: "fee.targetBlockRate",
+		},
+		{
+			name:          "fee parameter without custom preset",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: low\n  gasLimit: 8000000\n",
+			errorContains: "custom",
+		},
+		{
+			name:          "invalid allocation address",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: low\nallocations:\n  - address: 0x12\n    balance: 1\n",
+			errorContains: "allocations[0].address",
+		},
+		{
+			name:          "invalid allocation balance",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: low\nallocations:\n  - address: \"0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC\"\n    balance: ten\n",
+			errorContains: "allocations[0].balance",
+		},
+		{
+			name:          "precompile without admins",
+			spec:          "chainId: 1\ntokenName: TST\nfee:\n  preset: low\nallocations:\n  - address: \"0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC\"\n    balance: 1\nprecompiles:\n  feeManager: {}\n",
+			errorContains: "precompiles.feeManager.adminAddresses",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseEvmSpec([]byte(tt.spec))
+			if err == nil {
+				_, _, err = spec.ToGenesisParams()
+			}
+			require.ErrorContains(t, err, tt.errorContains)
+		})
+	}
+}
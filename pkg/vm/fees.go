@@ -5,6 +5,7 @@ package vm
 
 import (
 	"github.com/luxdefi/cli/pkg/application"
+	"github.com/luxdefi/cli/pkg/prompts"
 	"github.com/luxdefi/cli/pkg/statemachine"
 	"github.com/luxdefi/cli/pkg/ux"
 	"github.com/luxdefi/subnet-evm/commontype"
@@ -39,7 +40,7 @@ func GetFeeConfig(config params.ChainConfig, app *application.Lux) (
 		feeConfigOptions,
 	)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.preset"))
 	}
 
 	config.FeeConfig = StarterFeeConfig
@@ -62,42 +63,42 @@ func GetFeeConfig(config params.ChainConfig, app *application.Lux) (
 
 	gasLimit, err := app.Prompt.CapturePositiveBigInt(setGasLimit)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.gasLimit"))
 	}
 
 	blockRate, err := app.Prompt.CapturePositiveBigInt(setBlockRate)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.targetBlockRate"))
 	}
 
 	minBaseFee, err := app.Prompt.CapturePositiveBigInt(setMinBaseFee)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.minBaseFee"))
 	}
 
 	targetGas, err := app.Prompt.CapturePositiveBigInt(setTargetGas)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.targetGas"))
 	}
 
 	baseDenominator, err := app.Prompt.CapturePositiveBigInt(setBaseFeeChangeDenominator)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.baseFeeChangeDenominator"))
 	}
 
 	minBlockGas, err := app.Prompt.CapturePositiveBigInt(setMinBlockGas)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.minBlockGasCost"))
 	}
 
 	maxBlockGas, err := app.Prompt.CapturePositiveBigInt(setMaxBlockGas)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.maxBlockGasCost"))
 	}
 
 	gasStep, err := app.Prompt.CapturePositiveBigInt(setGasStep)
 	if err != nil {
-		return config, statemachine.Stop, err
+		return config, statemachine.Stop, prompts.WithHint(err, specHint("fee.blockGasCostStep"))
 	}
 
 	feeConf := commontype.FeeConfig{
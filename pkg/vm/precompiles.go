@@ -57,7 +57,7 @@ func configureRewardManager(app *application.Lux) (rewardmanager.Config, bool, e
 		"on your subnet, including burning or sending fees.\nFor more information visit " +
 		"https://docs.lux.network/subnets/customize-a-subnet#changing-fee-reward-mechanisms\n\n"
 
-	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, app)
+	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, "precompiles.rewardManager", app)
 	if err != nil {
 		return config, false, err
 	}
@@ -83,7 +83,7 @@ func ConfigureInitialRewardConfig(app *application.Lux) (*rewardmanager.InitialR
 	burnPrompt := "Should fees be burnt?"
 	burnFees, err := app.Prompt.CaptureYesNo(burnPrompt)
 	if err != nil {
-		return config, err
+		return config, prompts.WithHint(err, specHint("precompiles.rewardManager"))
 	}
 	if burnFees {
 		return config, nil
@@ -92,7 +92,7 @@ func ConfigureInitialRewardConfig(app *application.Lux) (*rewardmanager.InitialR
 	feeRcpdPrompt := "Allow block producers to claim fees?"
 	allowFeeRecipients, err := app.Prompt.CaptureYesNo(feeRcpdPrompt)
 	if err != nil {
-		return config, err
+		return config, prompts.WithHint(err, specHint("precompiles.rewardManager.allowFeeRecipients"))
 	}
 	if allowFeeRecipients {
 		config.AllowFeeRecipients = true
@@ -102,7 +102,7 @@ func ConfigureInitialRewardConfig(app *application.Lux) (*rewardmanager.InitialR
 	rewardPrompt := "Provide the address to which fees will be sent to"
 	rewardAddress, err := app.Prompt.CaptureAddress(rewardPrompt)
 	if err != nil {
-		return config, err
+		return config, prompts.WithHint(err, specHint("precompiles.rewardManager.rewardAddress"))
 	}
 	config.RewardAddress = rewardAddress
 	return config, nil
@@ -129,7 +129,7 @@ func configureContractAllowList(app *application.Lux) (deployerallowlist.Config,
 		"on your subnet.\nFor more information visit " +
 		"https://docs.lux.network/subnets/customize-a-subnet/#restricting-smart-contract-deployers\n\n"
 
-	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, app)
+	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, "precompiles.contractAllowList", app)
 	if err != nil {
 		return config, false, err
 	}
@@ -153,7 +153,7 @@ func configureTransactionAllowList(app *application.Lux) (txallowlist.Config, bo
 		"on your subnet.\nFor more information visit " +
 		"https://docs.lux.network/subnets/customize-a-subnet/#restricting-who-can-submit-transactions\n\n"
 
-	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, app)
+	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, "precompiles.txAllowList", app)
 	if err != nil {
 		return config, false, err
 	}
@@ -169,10 +169,12 @@ func configureTransactionAllowList(app *application.Lux) (txallowlist.Config, bo
 	return config, cancelled, nil
 }
 
-func getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info string, app *application.Lux) ([]common.Address, []common.Address, bool, error) {
+// specField is the spec field of the precompile, to name the one answering
+// each prompt in non interactive mode
+func getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, specField string, app *application.Lux) ([]common.Address, []common.Address, bool, error) {
 	admins, cancelled, err := getAddressList(adminPrompt, info, app)
 	if err != nil || cancelled {
-		return nil, nil, false, err
+		return nil, nil, false, prompts.WithHint(err, specHint(specField+".adminAddresses"))
 	}
 	adminsMap := make(map[string]bool)
 	for _, adminsAddress := range admins {
@@ -180,7 +182,7 @@ func getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info string, app *a
 	}
 	enabled, cancelled, err := getAddressList(enabledPrompt, info, app)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, false, prompts.WithHint(err, specHint(specField+".enabledAddresses"))
 	}
 	for _, enabledAddress := range enabled {
 		if _, ok := adminsMap[enabledAddress.String()]; ok {
@@ -198,7 +200,7 @@ func configureMinterList(app *application.Lux) (nativeminter.Config, bool, error
 		"on your subnet.\nFor more information visit " +
 		"https://docs.lux.network/subnets/customize-a-subnet#minting-native-coins\n\n"
 
-	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, app)
+	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, "precompiles.nativeMinter", app)
 	if err != nil {
 		return config, false, err
 	}
@@ -221,7 +223,7 @@ func configureFeeConfigAllowList(app *application.Lux) (feemanager.Config, bool,
 		"performing a hardfork.\nFor more information visit " +
 		"https://docs.lux.network/subnets/customize-a-subnet#configuring-dynamic-fees\n\n"
 
-	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, app)
+	admins, enabled, cancelled, err := getAdminAndEnabledAddresses(adminPrompt, enabledPrompt, info, "precompiles.feeManager", app)
 	if err != nil {
 		return config, false, err
 	}
@@ -269,7 +271,7 @@ func getPrecompiles(config params.ChainConfig, app *application.Lux) (
 
 		addPrecompile, err := app.Prompt.CaptureList(promptStr, []string{prompts.No, prompts.Yes, goBackMsg})
 		if err != nil {
-			return config, statemachine.Stop, err
+			return config, statemachine.Stop, prompts.WithHint(err, specHint("precompiles"))
 		}
 
 		switch addPrecompile {
@@ -284,7 +286,7 @@ func getPrecompiles(config params.ChainConfig, app *application.Lux) (
 			remainingPrecompiles,
 		)
 		if err != nil {
-			return config, statemachine.Stop, err
+			return config, statemachine.Stop, prompts.WithHint(err, specHint("precompiles"))
 		}
 
 		switch precompileDecision {